@@ -0,0 +1,132 @@
+/*
+	Works out when a site is open from its business hours and holidays.
+*/
+
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"avaros/models"
+)
+
+// Period is a span of time a site is open
+type Period struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// ParseClock reads a time of day in the form 15:04 as the minutes since midnight.
+// 24:00 is allowed for closing at midnight
+func ParseClock(clock string) (int, error) {
+	var hours, minutes int
+	_, err := fmt.Sscanf(clock, "%d:%d", &hours, &minutes)
+	if err != nil || len(clock) != 5 || hours < 0 || minutes < 0 || minutes > 59 ||
+		hours*60+minutes > 24*60 {
+		return 0, fmt.Errorf("Invalid time of day %q, expected HH:MM", clock)
+	}
+
+	return hours*60 + minutes, nil
+}
+
+// OpenPeriods gets the times between from and to the site is open, in order, with any
+// periods that run into each other joined up. A site without business hours is open
+// all day, every day it is not on holiday
+func OpenPeriods(hours []models.BusinessHours, holidays []models.Holiday, from time.Time, to time.Time) []Period {
+	closed := map[string]bool{}
+	for _, holiday := range holidays {
+		closed[holiday.Date.Format("2006-01-02")] = true
+	}
+
+	periods := []Period{}
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for day.Before(to) {
+		nextDay := day.AddDate(0, 0, 1)
+		if !closed[day.Format("2006-01-02")] {
+			if len(hours) == 0 {
+				periods = append(periods, Period{Start: day, End: nextDay})
+			}
+			for _, open := range hours {
+				if open.Weekday != day.Weekday() {
+					continue
+				}
+				opens, err := ParseClock(open.Opens)
+				if err != nil {
+					continue
+				}
+				closes, err := ParseClock(open.Closes)
+				if err != nil {
+					continue
+				}
+				periods = append(periods, Period{
+					Start: day.Add(time.Duration(opens) * time.Minute),
+					End:   day.Add(time.Duration(closes) * time.Minute),
+				})
+			}
+		}
+		day = nextDay
+	}
+
+	return clip(merge(periods), from, to)
+}
+
+// IsOpen checks if the site is open for the whole of the time supplied. If there is no end
+// the time only has to start when the site is open
+func IsOpen(hours []models.BusinessHours, holidays []models.Holiday, start time.Time, end *time.Time) bool {
+	to := start.Add(time.Minute)
+	if end != nil {
+		to = *end
+	}
+
+	for _, period := range OpenPeriods(hours, holidays, start, to) {
+		if !period.Start.After(start) && !period.End.Before(to) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// merge sorts the periods and joins any that overlap or touch
+func merge(periods []Period) []Period {
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].Start.Before(periods[j].Start)
+	})
+
+	merged := []Period{}
+	for _, period := range periods {
+		if !period.End.After(period.Start) {
+			continue
+		}
+		last := len(merged) - 1
+		if last >= 0 && !period.Start.After(merged[last].End) {
+			if period.End.After(merged[last].End) {
+				merged[last].End = period.End
+			}
+			continue
+		}
+		merged = append(merged, period)
+	}
+
+	return merged
+}
+
+// clip cuts the periods down to the time between from and to
+func clip(periods []Period, from time.Time, to time.Time) []Period {
+	clipped := []Period{}
+	for _, period := range periods {
+		if period.Start.Before(from) {
+			period.Start = from
+		}
+		if period.End.After(to) {
+			period.End = to
+		}
+		if period.End.After(period.Start) {
+			clipped = append(clipped, period)
+		}
+	}
+
+	return clipped
+}
@@ -0,0 +1,115 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"avaros/models"
+)
+
+var officeHours = []models.BusinessHours{
+	{Weekday: time.Monday, Opens: "09:00", Closes: "17:00"},
+	{Weekday: time.Tuesday, Opens: "09:00", Closes: "12:00"},
+	{Weekday: time.Tuesday, Opens: "13:00", Closes: "24:00"},
+	{Weekday: time.Wednesday, Opens: "00:00", Closes: "17:00"},
+}
+
+// 2026-10-19 is a Monday
+func at(day int, hour int, minute int) time.Time {
+	return time.Date(2026, time.October, day, hour, minute, 0, 0, time.Local)
+}
+
+func TestIsOpen(t *testing.T) {
+	holidays := []models.Holiday{{Date: at(26, 0, 0), Name: "Bank Holiday"}}
+	end := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name  string
+		start time.Time
+		end   *time.Time
+		open  bool
+	}{
+		{"within hours", at(19, 10, 0), end(at(19, 11, 0)), true},
+		{"before opening", at(19, 8, 30), end(at(19, 9, 30)), false},
+		{"3 am", at(19, 3, 0), end(at(19, 4, 0)), false},
+		{"over lunch", at(20, 11, 30), end(at(20, 13, 30)), false},
+		{"through midnight", at(20, 22, 0), end(at(21, 2, 0)), true},
+		{"weekend", at(24, 10, 0), end(at(24, 11, 0)), false},
+		{"holiday", at(26, 10, 0), end(at(26, 11, 0)), false},
+		{"no end", at(19, 16, 0), nil, true},
+	}
+
+	for _, test := range tests {
+		if IsOpen(officeHours, holidays, test.start, test.end) != test.open {
+			t.Errorf("%s: expected open to be %t", test.name, test.open)
+		}
+	}
+
+	if !IsOpen(nil, holidays, at(24, 3, 0), end(at(24, 4, 0))) {
+		t.Errorf("A site without business hours should always be open")
+	}
+}
+
+func TestOpenPeriods(t *testing.T) {
+	periods := OpenPeriods(officeHours, nil, at(20, 10, 0), at(21, 12, 0))
+
+	expected := []Period{
+		{Start: at(20, 10, 0), End: at(20, 12, 0)},
+		{Start: at(20, 13, 0), End: at(21, 12, 0)},
+	}
+	if len(periods) != len(expected) {
+		t.Fatalf("Expected %d periods, got %d", len(expected), len(periods))
+	}
+
+	for i := range expected {
+		if !periods[i].Start.Equal(expected[i].Start) || !periods[i].End.Equal(expected[i].End) {
+			t.Errorf("Period %d should be %v to %v", i, expected[i].Start, expected[i].End)
+		}
+	}
+}
+
+func TestParseICS(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20261225",
+		"DTEND;VALUE=DATE:20261227",
+		"SUMMARY:Christmas Day and St",
+		"  Stephen's Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20270101T000000",
+		"SUMMARY:New Year's Day",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	holidays, err := ParseICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("Error parsing calendar: %s", err.Error())
+	}
+
+	if len(holidays) != 3 {
+		t.Fatalf("Expected 3 holidays, got %d", len(holidays))
+	}
+
+	if holidays[1].Date.Day() != 26 || holidays[1].Name != "Christmas Day and St Stephen's Day" {
+		t.Errorf("Second holiday should be St Stephen's Day, got %v %s", holidays[1].Date, holidays[1].Name)
+	}
+
+	if holidays[2].Date.Year() != 2027 {
+		t.Errorf("Third holiday should be in 2027")
+	}
+}
+
+func TestParseHolidaysJSON(t *testing.T) {
+	holidays, err := ParseHolidaysJSON(strings.NewReader(`[{"date": "2026-03-17", "name": "St Patrick's Day"}]`))
+	if err != nil {
+		t.Fatalf("Error parsing holidays: %s", err.Error())
+	}
+
+	if len(holidays) != 1 || holidays[0].Date.Month() != time.March || holidays[0].Name != "St Patrick's Day" {
+		t.Errorf("Expected St Patrick's Day, got %v", holidays)
+	}
+}
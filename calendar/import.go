@@ -0,0 +1,127 @@
+/*
+	Reads holiday calendars from .ics files or JSON.
+*/
+
+package calendar
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"avaros/models"
+)
+
+// ParseICS reads the events in an iCalendar file as holidays, one for each day an
+// event covers. Only all day and date-time starts and ends are read, repeating
+// events are not expanded
+func ParseICS(r io.Reader) ([]models.Holiday, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := []models.Holiday{}
+	var start, end time.Time
+	var name string
+	inEvent := false
+	for _, line := range lines {
+		key, value := splitProperty(line)
+		switch {
+		case line == "BEGIN:VEVENT":
+			inEvent = true
+			start, end, name = time.Time{}, time.Time{}, ""
+		case line == "END:VEVENT":
+			inEvent = false
+			if start.IsZero() {
+				return nil, fmt.Errorf("Event %q has no start date", name)
+			}
+			// all day events end the day after their last day
+			if end.IsZero() || !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+				holidays = append(holidays, models.Holiday{Date: day, Name: name})
+			}
+		case !inEvent:
+		case key == "DTSTART":
+			start, err = parseICSDate(value)
+		case key == "DTEND":
+			end, err = parseICSDate(value)
+		case key == "SUMMARY":
+			name = strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\\`, `\`).Replace(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return holidays, nil
+}
+
+// ParseHolidaysJSON reads holidays from a JSON list of objects with a date in the form
+// 2006-01-02 and a name
+func ParseHolidaysJSON(r io.Reader) ([]models.Holiday, error) {
+	var entries []struct {
+		Date string `json:"date"`
+		Name string `json:"name"`
+	}
+	err := json.NewDecoder(r).Decode(&entries)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := []models.Holiday{}
+	for _, entry := range entries {
+		date, err := time.ParseInLocation("2006-01-02", entry.Date, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, models.Holiday{Date: date, Name: entry.Name})
+	}
+
+	return holidays, nil
+}
+
+// unfold reads the lines of an iCalendar file, joining lines that were folded onto the next
+func unfold(r io.Reader) ([]string, error) {
+	lines := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// splitProperty splits an iCalendar line into its name, without any parameters, and value
+func splitProperty(line string) (string, string) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return line, ""
+	}
+
+	name := line[:colon]
+	if semicolon := strings.Index(name, ";"); semicolon >= 0 {
+		name = name[:semicolon]
+	}
+
+	return strings.ToUpper(name), line[colon+1:]
+}
+
+// parseICSDate reads the day of an iCalendar date or date-time
+func parseICSDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("Invalid date %q", value)
+	}
+
+	return time.ParseInLocation("20060102", value[:8], time.Local)
+}
@@ -0,0 +1,7309 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	UserIdScopes = "userId.Scopes"
+)
+
+// Defines values for AttendeeRsvp.
+const (
+	AttendeeRsvpAccepted  AttendeeRsvp = "accepted"
+	AttendeeRsvpDeclined  AttendeeRsvp = "declined"
+	AttendeeRsvpPending   AttendeeRsvp = "pending"
+	AttendeeRsvpTentative AttendeeRsvp = "tentative"
+)
+
+// Defines values for CheckResultStatus.
+const (
+	CheckResultStatusDown CheckResultStatus = "down"
+	CheckResultStatusOk   CheckResultStatus = "ok"
+)
+
+// Defines values for ClosureRecurrence.
+const (
+	ClosureRecurrenceDaily  ClosureRecurrence = "daily"
+	ClosureRecurrenceNone   ClosureRecurrence = "none"
+	ClosureRecurrenceWeekly ClosureRecurrence = "weekly"
+	ClosureRecurrenceYearly ClosureRecurrence = "yearly"
+)
+
+// Defines values for ClosureRequestRecurrence.
+const (
+	ClosureRequestRecurrenceDaily  ClosureRequestRecurrence = "daily"
+	ClosureRequestRecurrenceNone   ClosureRequestRecurrence = "none"
+	ClosureRequestRecurrenceWeekly ClosureRequestRecurrence = "weekly"
+	ClosureRequestRecurrenceYearly ClosureRequestRecurrence = "yearly"
+)
+
+// Defines values for HealthResponseStatus.
+const (
+	HealthResponseStatusDown HealthResponseStatus = "down"
+	HealthResponseStatusOk   HealthResponseStatus = "ok"
+)
+
+// Defines values for ReservationStatus.
+const (
+	ReservationStatusBumped    ReservationStatus = "bumped"
+	ReservationStatusConfirmed ReservationStatus = "confirmed"
+	ReservationStatusHold      ReservationStatus = "hold"
+	ReservationStatusPending   ReservationStatus = "pending"
+	ReservationStatusRejected  ReservationStatus = "rejected"
+)
+
+// Defines values for ReservationEventOperation.
+const (
+	DELETE ReservationEventOperation = "DELETE"
+	INSERT ReservationEventOperation = "INSERT"
+	STATE  ReservationEventOperation = "STATE"
+	UPDATE ReservationEventOperation = "UPDATE"
+)
+
+// Defines values for RsvpRequestRsvp.
+const (
+	RsvpRequestRsvpAccepted  RsvpRequestRsvp = "accepted"
+	RsvpRequestRsvpDeclined  RsvpRequestRsvp = "declined"
+	RsvpRequestRsvpTentative RsvpRequestRsvp = "tentative"
+)
+
+// Defines values for WaitlistEntryStatus.
+const (
+	WaitlistEntryStatusAccepted  WaitlistEntryStatus = "accepted"
+	WaitlistEntryStatusCancelled WaitlistEntryStatus = "cancelled"
+	WaitlistEntryStatusExpired   WaitlistEntryStatus = "expired"
+	WaitlistEntryStatusOffered   WaitlistEntryStatus = "offered"
+	WaitlistEntryStatusWaiting   WaitlistEntryStatus = "waiting"
+)
+
+// Attendee Someone invited to a reservation, either a user or an email address
+type Attendee struct {
+	Email         *string       `json:"email,omitempty"`
+	Id            *int32        `json:"id,omitempty"`
+	ReservationId *int32        `json:"reservationId,omitempty"`
+	Rsvp          *AttendeeRsvp `json:"rsvp,omitempty"`
+	UserId        *int32        `json:"userId,omitempty"`
+}
+
+// AttendeeRsvp defines model for Attendee.Rsvp.
+type AttendeeRsvp string
+
+// AttendeesRequest defines model for AttendeesRequest.
+type AttendeesRequest struct {
+	Attendees []Attendee `json:"attendees"`
+}
+
+// BatchReservationRequest defines model for BatchReservationRequest.
+type BatchReservationRequest struct {
+	Attendees   *[]Attendee `json:"attendees,omitempty"`
+	Description *string     `json:"description,omitempty"`
+
+	// OnBehalfOf The user to reserve it for, who must have made the user their delegate
+	OnBehalfOf *int32 `json:"onBehalfOf,omitempty"`
+
+	// Priority 0 for normal, 1 for high and 2 for critical. Higher priorities displace lower ones
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Private Only the organizer and attendees see the details in free/busy
+	Private *bool `json:"private,omitempty"`
+
+	// ReservationLength How many minutes the reservation lasts, until it is ended if left out
+	ReservationLength *int    `json:"reservationLength,omitempty"`
+	RoomIds           []int32 `json:"roomIds"`
+
+	// StartTime When the reservation starts, now if left out
+	StartTime *time.Time `json:"startTime,omitempty"`
+	Title     *string    `json:"title,omitempty"`
+}
+
+// Bump defines model for Bump.
+type Bump struct {
+	ByReservationId int32 `json:"byReservationId"`
+
+	// ByUserId Who made the reservation that displaced it
+	ByUserId      int32     `json:"byUserId"`
+	Created       time.Time `json:"created"`
+	Id            int32     `json:"id"`
+	Reason        string    `json:"reason"`
+	ReservationId int32     `json:"reservationId"`
+}
+
+// BusinessHours defines model for BusinessHours.
+type BusinessHours struct {
+	Closes string `json:"closes"`
+	Opens  string `json:"opens"`
+
+	// Weekday 0 is Sunday
+	Weekday int `json:"weekday"`
+}
+
+// Busy defines model for Busy.
+type Busy struct {
+	// Closed The room is closed rather than reserved
+	Closed        *bool   `json:"closed,omitempty"`
+	Private       *bool   `json:"private,omitempty"`
+	ReservationId *int32  `json:"reservationId,omitempty"`
+	RoomId        *int32  `json:"roomId,omitempty"`
+	Title         *string `json:"title,omitempty"`
+}
+
+// BusyPeriod defines model for BusyPeriod.
+type BusyPeriod struct {
+	Busy  []Busy    `json:"busy"`
+	End   time.Time `json:"end"`
+	Start time.Time `json:"start"`
+}
+
+// CheckResult defines model for CheckResult.
+type CheckResult struct {
+	Error     *string           `json:"error,omitempty"`
+	LatencyMs int64             `json:"latencyMs"`
+	Status    CheckResultStatus `json:"status"`
+}
+
+// CheckResultStatus defines model for CheckResult.Status.
+type CheckResultStatus string
+
+// Closure defines model for Closure.
+type Closure struct {
+	Building    *string            `json:"building,omitempty"`
+	CreatedBy   *int32             `json:"createdBy,omitempty"`
+	EndTime     time.Time          `json:"endTime"`
+	Id          *int32             `json:"id,omitempty"`
+	Reason      *string            `json:"reason,omitempty"`
+	Recurrence  *ClosureRecurrence `json:"recurrence,omitempty"`
+	RepeatUntil *time.Time         `json:"repeatUntil,omitempty"`
+	RoomId      *int32             `json:"roomId,omitempty"`
+	Site        *string            `json:"site,omitempty"`
+	StartTime   time.Time          `json:"startTime"`
+}
+
+// ClosureRecurrence defines model for Closure.Recurrence.
+type ClosureRecurrence string
+
+// ClosureRequest defines model for ClosureRequest.
+type ClosureRequest struct {
+	Building *string `json:"building,omitempty"`
+
+	// CancelReservations Cancel the reservations during the closure, rather than refuse to close if there are any
+	CancelReservations *bool                     `json:"cancelReservations,omitempty"`
+	CreatedBy          *int32                    `json:"createdBy,omitempty"`
+	EndTime            time.Time                 `json:"endTime"`
+	Id                 *int32                    `json:"id,omitempty"`
+	Reason             *string                   `json:"reason,omitempty"`
+	Recurrence         *ClosureRequestRecurrence `json:"recurrence,omitempty"`
+	RepeatUntil        *time.Time                `json:"repeatUntil,omitempty"`
+	RoomId             *int32                    `json:"roomId,omitempty"`
+	Site               *string                   `json:"site,omitempty"`
+	StartTime          time.Time                 `json:"startTime"`
+}
+
+// ClosureRequestRecurrence defines model for ClosureRequest.Recurrence.
+type ClosureRequestRecurrence string
+
+// ClosureResponse defines model for ClosureResponse.
+type ClosureResponse struct {
+	// Cancelled The reservations that were cancelled
+	Cancelled []int32 `json:"cancelled"`
+	Id        int32   `json:"id"`
+	Result    bool    `json:"result"`
+}
+
+// DecisionRequest defines model for DecisionRequest.
+type DecisionRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// Delegation defines model for Delegation.
+type Delegation struct {
+	Created    time.Time `json:"created"`
+	DelegateId int32     `json:"delegateId"`
+	UserId     int32     `json:"userId"`
+}
+
+// DelegationRequest defines model for DelegationRequest.
+type DelegationRequest struct {
+	DelegateId int32 `json:"delegateId"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Error string `json:"error"`
+}
+
+// FreeBusy defines model for FreeBusy.
+type FreeBusy struct {
+	Rooms map[string][]BusyPeriod `json:"rooms"`
+	Users map[string][]BusyPeriod `json:"users"`
+}
+
+// FreeBusyRequest defines model for FreeBusyRequest.
+type FreeBusyRequest struct {
+	From    time.Time `json:"from"`
+	RoomIds *[]int32  `json:"roomIds,omitempty"`
+	To      time.Time `json:"to"`
+	UserIds *[]int32  `json:"userIds,omitempty"`
+}
+
+// HealthResponse defines model for HealthResponse.
+type HealthResponse struct {
+	Checks *map[string]CheckResult `json:"checks,omitempty"`
+	Status HealthResponseStatus    `json:"status"`
+}
+
+// HealthResponseStatus defines model for HealthResponse.Status.
+type HealthResponseStatus string
+
+// HoldRequest defines model for HoldRequest.
+type HoldRequest struct {
+	Attendees   *[]Attendee `json:"attendees,omitempty"`
+	Description *string     `json:"description,omitempty"`
+
+	// HoldSeconds How long the hold lasts, if shorter than the server's limit
+	HoldSeconds *int `json:"holdSeconds,omitempty"`
+
+	// OnBehalfOf The user to reserve it for, who must have made the user their delegate
+	OnBehalfOf *int32 `json:"onBehalfOf,omitempty"`
+
+	// Priority 0 for normal, 1 for high and 2 for critical. Higher priorities displace lower ones
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Private Only the organizer and attendees see the details in free/busy
+	Private *bool `json:"private,omitempty"`
+
+	// ReservationLength How many minutes the reservation lasts, until it is ended if left out
+	ReservationLength *int `json:"reservationLength,omitempty"`
+
+	// StartTime When the reservation starts, now if left out
+	StartTime *time.Time `json:"startTime,omitempty"`
+	Title     *string    `json:"title,omitempty"`
+}
+
+// Holiday defines model for Holiday.
+type Holiday struct {
+	Date time.Time `json:"date"`
+	Id   int32     `json:"id"`
+	Name string    `json:"name"`
+	Site string    `json:"site"`
+}
+
+// NewHoliday defines model for NewHoliday.
+type NewHoliday struct {
+	Date openapi_types.Date `json:"date"`
+	Name string             `json:"name"`
+}
+
+// NotificationPreferences Whether the user is sent each kind of notification, by kind
+type NotificationPreferences map[string]bool
+
+// Quota Limits for a user, team or role, or for everyone if none of them is set
+type Quota struct {
+	Id *int32 `json:"id,omitempty"`
+
+	// MaxFutureReservations Reservations that have not ended yet
+	MaxFutureReservations *int32   `json:"maxFutureReservations,omitempty"`
+	MaxHoursPerWeek       *float64 `json:"maxHoursPerWeek,omitempty"`
+	MaxPerRoomPerDay      *int32   `json:"maxPerRoomPerDay,omitempty"`
+	Role                  *string  `json:"role,omitempty"`
+	Team                  *string  `json:"team,omitempty"`
+	UserId                *int32   `json:"userId,omitempty"`
+}
+
+// QuotaUsage defines model for QuotaUsage.
+type QuotaUsage struct {
+	FutureReservations int32   `json:"futureReservations"`
+	HoursThisWeek      float64 `json:"hoursThisWeek"`
+
+	// Limits Limits for a user, team or role, or for everyone if none of them is set
+	Limits                      Quota    `json:"limits"`
+	RemainingFutureReservations *int32   `json:"remainingFutureReservations,omitempty"`
+	RemainingHoursThisWeek      *float64 `json:"remainingHoursThisWeek,omitempty"`
+}
+
+// Reservation defines model for Reservation.
+type Reservation struct {
+	ApprovalExpires *time.Time  `json:"approvalExpires,omitempty"`
+	ApproverId      *int32      `json:"approverId,omitempty"`
+	Attendees       *[]Attendee `json:"attendees,omitempty"`
+
+	// CreatedBy Who made the reservation, the organizer or their delegate
+	CreatedBy      int32      `json:"createdBy"`
+	DecisionReason *string    `json:"decisionReason,omitempty"`
+	Description    *string    `json:"description,omitempty"`
+	EndTime        *time.Time `json:"endTime,omitempty"`
+	Expired        bool       `json:"expired"`
+	HoldExpires    *time.Time `json:"holdExpires,omitempty"`
+	Id             int32      `json:"id"`
+
+	// Priority 0 for normal, 1 for high and 2 for critical. Higher priorities displace lower ones
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Private Only the organizer and attendees see the details in free/busy
+	Private   *bool             `json:"private,omitempty"`
+	RoomId    int32             `json:"roomId"`
+	StartTime time.Time         `json:"startTime"`
+	Status    ReservationStatus `json:"status"`
+	Title     *string           `json:"title,omitempty"`
+
+	// UserId The organizer the room is reserved for
+	UserId int32 `json:"userId"`
+}
+
+// ReservationStatus defines model for Reservation.Status.
+type ReservationStatus string
+
+// ReservationDetails defines model for ReservationDetails.
+type ReservationDetails struct {
+	Attendees   *[]Attendee `json:"attendees,omitempty"`
+	Description *string     `json:"description,omitempty"`
+
+	// Priority 0 for normal, 1 for high and 2 for critical. Higher priorities displace lower ones
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Private Only the organizer and attendees see the details in free/busy
+	Private *bool   `json:"private,omitempty"`
+	Title   *string `json:"title,omitempty"`
+}
+
+// ReservationEvent defines model for ReservationEvent.
+type ReservationEvent struct {
+	Created       time.Time                 `json:"created"`
+	Id            int64                     `json:"id"`
+	Operation     ReservationEventOperation `json:"operation"`
+	ReservationId int32                     `json:"reservationId"`
+
+	// Reserved Whether the room is reserved after the change
+	Reserved bool  `json:"reserved"`
+	RoomId   int32 `json:"roomId"`
+}
+
+// ReservationEventOperation defines model for ReservationEvent.Operation.
+type ReservationEventOperation string
+
+// ReservationRequest defines model for ReservationRequest.
+type ReservationRequest struct {
+	Attendees   *[]Attendee `json:"attendees,omitempty"`
+	Description *string     `json:"description,omitempty"`
+
+	// OnBehalfOf The user to reserve it for, who must have made the user their delegate
+	OnBehalfOf *int32 `json:"onBehalfOf,omitempty"`
+
+	// Priority 0 for normal, 1 for high and 2 for critical. Higher priorities displace lower ones
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Private Only the organizer and attendees see the details in free/busy
+	Private *bool `json:"private,omitempty"`
+
+	// ReservationLength How many minutes the reservation lasts, until it is ended if left out
+	ReservationLength *int `json:"reservationLength,omitempty"`
+
+	// StartTime When the reservation starts, now if left out
+	StartTime *time.Time `json:"startTime,omitempty"`
+	Title     *string    `json:"title,omitempty"`
+}
+
+// ReservationResponse defines model for ReservationResponse.
+type ReservationResponse struct {
+	// Conflicts The rooms that could not be reserved
+	Conflicts *[]int32 `json:"conflicts,omitempty"`
+
+	// Ids What was created, like the reservations, or empty if nothing was
+	Ids *[]int32 `json:"ids"`
+
+	// Reason Why it was not done, or anything else worth knowing
+	Reason      string       `json:"reason"`
+	Result      bool         `json:"result"`
+	Suggestions *Suggestions `json:"suggestions,omitempty"`
+}
+
+// RolePriority defines model for RolePriority.
+type RolePriority struct {
+	MaxPriority int32  `json:"maxPriority"`
+	Role        string `json:"role"`
+}
+
+// Room defines model for Room.
+type Room struct {
+	Amenities           *[]string `json:"amenities,omitempty"`
+	ApproverId          *int32    `json:"approverId,omitempty"`
+	BufferAfterMinutes  int32     `json:"bufferAfterMinutes"`
+	BufferBeforeMinutes int32     `json:"bufferBeforeMinutes"`
+	Building            *string   `json:"building,omitempty"`
+
+	// Capacity How many people fit, no limit if left out
+	Capacity         *int32  `json:"capacity,omitempty"`
+	Floor            *int32  `json:"floor,omitempty"`
+	Id               int32   `json:"id"`
+	Name             string  `json:"name"`
+	RequiresApproval bool    `json:"requiresApproval"`
+	Site             *string `json:"site,omitempty"`
+}
+
+// RsvpRequest defines model for RsvpRequest.
+type RsvpRequest struct {
+	Rsvp RsvpRequestRsvp `json:"rsvp"`
+}
+
+// RsvpRequestRsvp defines model for RsvpRequest.Rsvp.
+type RsvpRequestRsvp string
+
+// Suggestion defines model for Suggestion.
+type Suggestion struct {
+	EndTime   *time.Time `json:"endTime,omitempty"`
+	Room      Room       `json:"room"`
+	StartTime time.Time  `json:"startTime"`
+}
+
+// Suggestions defines model for Suggestions.
+type Suggestions struct {
+	// OtherRooms Similar rooms in the same building free at the time asked for
+	OtherRooms []Suggestion `json:"otherRooms"`
+
+	// SameRoom The room asked for at the nearest free times
+	SameRoom []Suggestion `json:"sameRoom"`
+}
+
+// WaitlistEntry defines model for WaitlistEntry.
+type WaitlistEntry struct {
+	AutoAccept    bool                `json:"autoAccept"`
+	EndTime       *time.Time          `json:"endTime,omitempty"`
+	Id            int32               `json:"id"`
+	OfferExpires  *time.Time          `json:"offerExpires,omitempty"`
+	ReservationId *int32              `json:"reservationId,omitempty"`
+	RoomId        int32               `json:"roomId"`
+	StartTime     time.Time           `json:"startTime"`
+	Status        WaitlistEntryStatus `json:"status"`
+	UserId        int32               `json:"userId"`
+}
+
+// WaitlistEntryStatus defines model for WaitlistEntry.Status.
+type WaitlistEntryStatus string
+
+// WaitlistRequest defines model for WaitlistRequest.
+type WaitlistRequest struct {
+	Attendees *[]Attendee `json:"attendees,omitempty"`
+
+	// AutoAccept Reserve the slot straight away rather than offer it
+	AutoAccept  *bool   `json:"autoAccept,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	// OnBehalfOf The user to reserve it for, who must have made the user their delegate
+	OnBehalfOf *int32 `json:"onBehalfOf,omitempty"`
+
+	// Priority 0 for normal, 1 for high and 2 for critical. Higher priorities displace lower ones
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Private Only the organizer and attendees see the details in free/busy
+	Private *bool `json:"private,omitempty"`
+
+	// ReservationLength How many minutes the reservation lasts, until it is ended if left out
+	ReservationLength *int `json:"reservationLength,omitempty"`
+
+	// StartTime When the reservation starts, now if left out
+	StartTime *time.Time `json:"startTime,omitempty"`
+	Title     *string    `json:"title,omitempty"`
+}
+
+// HoldId defines model for HoldId.
+type HoldId = int32
+
+// LastEventIdHeader defines model for LastEventIdHeader.
+type LastEventIdHeader = int64
+
+// LastEventIdQuery defines model for LastEventIdQuery.
+type LastEventIdQuery = int64
+
+// ReservationId defines model for ReservationId.
+type ReservationId = int32
+
+// RoomId defines model for RoomId.
+type RoomId = int32
+
+// Site defines model for Site.
+type Site = string
+
+// WaitlistEntryId defines model for WaitlistEntryId.
+type WaitlistEntryId = int32
+
+// Health defines model for Health.
+type Health = HealthResponse
+
+// Reservations defines model for Reservations.
+type Reservations = []Reservation
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Error
+
+// GetClosuresParams defines parameters for GetClosures.
+type GetClosuresParams struct {
+	// RoomId Only the closures that apply to this room
+	RoomId *int32 `form:"roomId,omitempty" json:"roomId,omitempty"`
+}
+
+// RoomEventsParams defines parameters for RoomEvents.
+type RoomEventsParams struct {
+	// LastEventId The id of the last event seen, for clients that cannot set headers
+	LastEventId *LastEventIdQuery `form:"lastEventId,omitempty" json:"lastEventId,omitempty"`
+
+	// LastEventID The id of the last event seen
+	LastEventID *LastEventIdHeader `json:"Last-Event-ID,omitempty"`
+}
+
+// RoomEventsSocketParams defines parameters for RoomEventsSocket.
+type RoomEventsSocketParams struct {
+	// LastEventId The id of the last event seen, for clients that cannot set headers
+	LastEventId *LastEventIdQuery `form:"lastEventId,omitempty" json:"lastEventId,omitempty"`
+
+	// LastEventID The id of the last event seen
+	LastEventID *LastEventIdHeader `json:"Last-Event-ID,omitempty"`
+}
+
+// SetBusinessHoursJSONBody defines parameters for SetBusinessHours.
+type SetBusinessHoursJSONBody = []BusinessHours
+
+// AddHolidaysJSONBody defines parameters for AddHolidays.
+type AddHolidaysJSONBody = []NewHoliday
+
+// CreateClosureJSONRequestBody defines body for CreateClosure for application/json ContentType.
+type CreateClosureJSONRequestBody = ClosureRequest
+
+// GrantDelegationJSONRequestBody defines body for GrantDelegation for application/json ContentType.
+type GrantDelegationJSONRequestBody = DelegationRequest
+
+// GetFreeBusyJSONRequestBody defines body for GetFreeBusy for application/json ContentType.
+type GetFreeBusyJSONRequestBody = FreeBusyRequest
+
+// UpdateNotificationPreferencesJSONRequestBody defines body for UpdateNotificationPreferences for application/json ContentType.
+type UpdateNotificationPreferencesJSONRequestBody = NotificationPreferences
+
+// SetPriorityJSONRequestBody defines body for SetPriority for application/json ContentType.
+type SetPriorityJSONRequestBody = RolePriority
+
+// SetQuotaJSONRequestBody defines body for SetQuota for application/json ContentType.
+type SetQuotaJSONRequestBody = Quota
+
+// ReserveRoomsJSONRequestBody defines body for ReserveRooms for application/json ContentType.
+type ReserveRoomsJSONRequestBody = BatchReservationRequest
+
+// ApproveReservationJSONRequestBody defines body for ApproveReservation for application/json ContentType.
+type ApproveReservationJSONRequestBody = DecisionRequest
+
+// AddAttendeesJSONRequestBody defines body for AddAttendees for application/json ContentType.
+type AddAttendeesJSONRequestBody = AttendeesRequest
+
+// RejectReservationJSONRequestBody defines body for RejectReservation for application/json ContentType.
+type RejectReservationJSONRequestBody = DecisionRequest
+
+// RsvpJSONRequestBody defines body for Rsvp for application/json ContentType.
+type RsvpJSONRequestBody = RsvpRequest
+
+// ReserveRoomJSONRequestBody defines body for ReserveRoom for application/json ContentType.
+type ReserveRoomJSONRequestBody = ReservationRequest
+
+// PlaceHoldJSONRequestBody defines body for PlaceHold for application/json ContentType.
+type PlaceHoldJSONRequestBody = HoldRequest
+
+// JoinWaitlistJSONRequestBody defines body for JoinWaitlist for application/json ContentType.
+type JoinWaitlistJSONRequestBody = WaitlistRequest
+
+// SetBusinessHoursJSONRequestBody defines body for SetBusinessHours for application/json ContentType.
+type SetBusinessHoursJSONRequestBody = SetBusinessHoursJSONBody
+
+// AddHolidaysJSONRequestBody defines body for AddHolidays for application/json ContentType.
+type AddHolidaysJSONRequestBody = AddHolidaysJSONBody
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetClosures request
+	GetClosures(ctx context.Context, params *GetClosuresParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateClosureWithBody request with any body
+	CreateClosureWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateClosure(ctx context.Context, body CreateClosureJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteClosure request
+	DeleteClosure(ctx context.Context, id int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDelegations request
+	GetDelegations(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GrantDelegationWithBody request with any body
+	GrantDelegationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	GrantDelegation(ctx context.Context, body GrantDelegationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeDelegation request
+	RevokeDelegation(ctx context.Context, id int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Docs request
+	Docs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFreeBusyWithBody request with any body
+	GetFreeBusyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	GetFreeBusy(ctx context.Context, body GetFreeBusyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Healthz request
+	Healthz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReleaseHold request
+	ReleaseHold(ctx context.Context, id HoldId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ConfirmHold request
+	ConfirmHold(ctx context.Context, id HoldId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Metrics request
+	Metrics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetNotificationPreferences request
+	GetNotificationPreferences(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateNotificationPreferencesWithBody request with any body
+	UpdateNotificationPreferencesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateNotificationPreferences(ctx context.Context, body UpdateNotificationPreferencesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// OpenAPI request
+	OpenAPI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPriorities request
+	GetPriorities(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetPriorityWithBody request with any body
+	SetPriorityWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetPriority(ctx context.Context, body SetPriorityJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQuotas request
+	GetQuotas(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetQuotaWithBody request with any body
+	SetQuotaWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetQuota(ctx context.Context, body SetQuotaJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRemainingQuota request
+	GetRemainingQuota(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteQuota request
+	DeleteQuota(ctx context.Context, id int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Readyz request
+	Readyz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReserveRoomsWithBody request with any body
+	ReserveRoomsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReserveRooms(ctx context.Context, body ReserveRoomsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBumps request
+	GetBumps(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInvitations request
+	GetInvitations(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPendingApprovals request
+	GetPendingApprovals(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ApproveReservationWithBody request with any body
+	ApproveReservationWithBody(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ApproveReservation(ctx context.Context, id ReservationId, body ApproveReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAttendees request
+	GetAttendees(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddAttendeesWithBody request with any body
+	AddAttendeesWithBody(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddAttendees(ctx context.Context, id ReservationId, body AddAttendeesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveAttendee request
+	RemoveAttendee(ctx context.Context, id ReservationId, attendeeId int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CheckIn request
+	CheckIn(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RejectReservationWithBody request with any body
+	RejectReservationWithBody(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RejectReservation(ctx context.Context, id ReservationId, body RejectReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RsvpWithBody request with any body
+	RsvpWithBody(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Rsvp(ctx context.Context, id ReservationId, body RsvpJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CheckReservation request
+	CheckReservation(ctx context.Context, id RoomId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteReservation request
+	DeleteReservation(ctx context.Context, id RoomId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// EndReservation request
+	EndReservation(ctx context.Context, id RoomId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReserveRoomWithBody request with any body
+	ReserveRoomWithBody(ctx context.Context, id RoomId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReserveRoom(ctx context.Context, id RoomId, body ReserveRoomJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RoomEvents request
+	RoomEvents(ctx context.Context, id RoomId, params *RoomEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RoomEventsSocket request
+	RoomEventsSocket(ctx context.Context, id RoomId, params *RoomEventsSocketParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PlaceHoldWithBody request with any body
+	PlaceHoldWithBody(ctx context.Context, id RoomId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PlaceHold(ctx context.Context, id RoomId, body PlaceHoldJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// JoinWaitlistWithBody request with any body
+	JoinWaitlistWithBody(ctx context.Context, id RoomId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	JoinWaitlist(ctx context.Context, id RoomId, body JoinWaitlistJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBusinessHours request
+	GetBusinessHours(ctx context.Context, site Site, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetBusinessHoursWithBody request with any body
+	SetBusinessHoursWithBody(ctx context.Context, site Site, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetBusinessHours(ctx context.Context, site Site, body SetBusinessHoursJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHolidays request
+	GetHolidays(ctx context.Context, site Site, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddHolidaysWithBody request with any body
+	AddHolidaysWithBody(ctx context.Context, site Site, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddHolidays(ctx context.Context, site Site, body AddHolidaysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteHoliday request
+	DeleteHoliday(ctx context.Context, site Site, id int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWaitlist request
+	GetWaitlist(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LeaveWaitlist request
+	LeaveWaitlist(ctx context.Context, id WaitlistEntryId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AcceptWaitlistOffer request
+	AcceptWaitlistOffer(ctx context.Context, id WaitlistEntryId, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetClosures(ctx context.Context, params *GetClosuresParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetClosuresRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateClosureWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateClosureRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateClosure(ctx context.Context, body CreateClosureJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateClosureRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteClosure(ctx context.Context, id int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteClosureRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetDelegations(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDelegationsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GrantDelegationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGrantDelegationRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GrantDelegation(ctx context.Context, body GrantDelegationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGrantDelegationRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeDelegation(ctx context.Context, id int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeDelegationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Docs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDocsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFreeBusyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFreeBusyRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFreeBusy(ctx context.Context, body GetFreeBusyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFreeBusyRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Healthz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHealthzRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReleaseHold(ctx context.Context, id HoldId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReleaseHoldRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ConfirmHold(ctx context.Context, id HoldId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConfirmHoldRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Metrics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMetricsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetNotificationPreferences(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetNotificationPreferencesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateNotificationPreferencesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateNotificationPreferencesRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateNotificationPreferences(ctx context.Context, body UpdateNotificationPreferencesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateNotificationPreferencesRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) OpenAPI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOpenAPIRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPriorities(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPrioritiesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPriorityWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPriorityRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPriority(ctx context.Context, body SetPriorityJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPriorityRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetQuotas(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuotasRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetQuotaWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetQuotaRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetQuota(ctx context.Context, body SetQuotaJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetQuotaRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRemainingQuota(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRemainingQuotaRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteQuota(ctx context.Context, id int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteQuotaRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Readyz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReadyzRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReserveRoomsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReserveRoomsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReserveRooms(ctx context.Context, body ReserveRoomsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReserveRoomsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBumps(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBumpsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetInvitations(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInvitationsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPendingApprovals(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPendingApprovalsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ApproveReservationWithBody(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApproveReservationRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ApproveReservation(ctx context.Context, id ReservationId, body ApproveReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApproveReservationRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAttendees(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAttendeesRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddAttendeesWithBody(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddAttendeesRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddAttendees(ctx context.Context, id ReservationId, body AddAttendeesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddAttendeesRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveAttendee(ctx context.Context, id ReservationId, attendeeId int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveAttendeeRequest(c.Server, id, attendeeId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CheckIn(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCheckInRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RejectReservationWithBody(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRejectReservationRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RejectReservation(ctx context.Context, id ReservationId, body RejectReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRejectReservationRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RsvpWithBody(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRsvpRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Rsvp(ctx context.Context, id ReservationId, body RsvpJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRsvpRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CheckReservation(ctx context.Context, id RoomId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCheckReservationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteReservation(ctx context.Context, id RoomId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteReservationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EndReservation(ctx context.Context, id RoomId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEndReservationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReserveRoomWithBody(ctx context.Context, id RoomId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReserveRoomRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReserveRoom(ctx context.Context, id RoomId, body ReserveRoomJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReserveRoomRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RoomEvents(ctx context.Context, id RoomId, params *RoomEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRoomEventsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RoomEventsSocket(ctx context.Context, id RoomId, params *RoomEventsSocketParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRoomEventsSocketRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PlaceHoldWithBody(ctx context.Context, id RoomId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPlaceHoldRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PlaceHold(ctx context.Context, id RoomId, body PlaceHoldJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPlaceHoldRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) JoinWaitlistWithBody(ctx context.Context, id RoomId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewJoinWaitlistRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) JoinWaitlist(ctx context.Context, id RoomId, body JoinWaitlistJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewJoinWaitlistRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBusinessHours(ctx context.Context, site Site, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBusinessHoursRequest(c.Server, site)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetBusinessHoursWithBody(ctx context.Context, site Site, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetBusinessHoursRequestWithBody(c.Server, site, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetBusinessHours(ctx context.Context, site Site, body SetBusinessHoursJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetBusinessHoursRequest(c.Server, site, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHolidays(ctx context.Context, site Site, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHolidaysRequest(c.Server, site)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddHolidaysWithBody(ctx context.Context, site Site, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddHolidaysRequestWithBody(c.Server, site, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddHolidays(ctx context.Context, site Site, body AddHolidaysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddHolidaysRequest(c.Server, site, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteHoliday(ctx context.Context, site Site, id int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteHolidayRequest(c.Server, site, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWaitlist(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWaitlistRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LeaveWaitlist(ctx context.Context, id WaitlistEntryId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLeaveWaitlistRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AcceptWaitlistOffer(ctx context.Context, id WaitlistEntryId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcceptWaitlistOfferRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetClosuresRequest generates requests for GetClosures
+func NewGetClosuresRequest(server string, params *GetClosuresParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/closures")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.RoomId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "roomId", runtime.ParamLocationQuery, *params.RoomId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateClosureRequest calls the generic CreateClosure builder with application/json body
+func NewCreateClosureRequest(server string, body CreateClosureJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateClosureRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateClosureRequestWithBody generates requests for CreateClosure with any type of body
+func NewCreateClosureRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/closures")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteClosureRequest generates requests for DeleteClosure
+func NewDeleteClosureRequest(server string, id int32) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/closures/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetDelegationsRequest generates requests for GetDelegations
+func NewGetDelegationsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/delegations")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGrantDelegationRequest calls the generic GrantDelegation builder with application/json body
+func NewGrantDelegationRequest(server string, body GrantDelegationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewGrantDelegationRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewGrantDelegationRequestWithBody generates requests for GrantDelegation with any type of body
+func NewGrantDelegationRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/delegations")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRevokeDelegationRequest generates requests for RevokeDelegation
+func NewRevokeDelegationRequest(server string, id int32) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/delegations/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDocsRequest generates requests for Docs
+func NewDocsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/docs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetFreeBusyRequest calls the generic GetFreeBusy builder with application/json body
+func NewGetFreeBusyRequest(server string, body GetFreeBusyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewGetFreeBusyRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewGetFreeBusyRequestWithBody generates requests for GetFreeBusy with any type of body
+func NewGetFreeBusyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/freebusy")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewHealthzRequest generates requests for Healthz
+func NewHealthzRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/healthz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReleaseHoldRequest generates requests for ReleaseHold
+func NewReleaseHoldRequest(server string, id HoldId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/holds/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewConfirmHoldRequest generates requests for ConfirmHold
+func NewConfirmHoldRequest(server string, id HoldId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/holds/%s/confirm", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewMetricsRequest generates requests for Metrics
+func NewMetricsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/metrics")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetNotificationPreferencesRequest generates requests for GetNotificationPreferences
+func NewGetNotificationPreferencesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/notifications/preferences")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateNotificationPreferencesRequest calls the generic UpdateNotificationPreferences builder with application/json body
+func NewUpdateNotificationPreferencesRequest(server string, body UpdateNotificationPreferencesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateNotificationPreferencesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewUpdateNotificationPreferencesRequestWithBody generates requests for UpdateNotificationPreferences with any type of body
+func NewUpdateNotificationPreferencesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/notifications/preferences")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewOpenAPIRequest generates requests for OpenAPI
+func NewOpenAPIRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/openapi.json")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPrioritiesRequest generates requests for GetPriorities
+func NewGetPrioritiesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/priorities")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetPriorityRequest calls the generic SetPriority builder with application/json body
+func NewSetPriorityRequest(server string, body SetPriorityJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetPriorityRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSetPriorityRequestWithBody generates requests for SetPriority with any type of body
+func NewSetPriorityRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/priorities")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetQuotasRequest generates requests for GetQuotas
+func NewGetQuotasRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quotas")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetQuotaRequest calls the generic SetQuota builder with application/json body
+func NewSetQuotaRequest(server string, body SetQuotaJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetQuotaRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSetQuotaRequestWithBody generates requests for SetQuota with any type of body
+func NewSetQuotaRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quotas")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetRemainingQuotaRequest generates requests for GetRemainingQuota
+func NewGetRemainingQuotaRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quotas/remaining")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteQuotaRequest generates requests for DeleteQuota
+func NewDeleteQuotaRequest(server string, id int32) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quotas/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReadyzRequest generates requests for Readyz
+func NewReadyzRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/readyz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReserveRoomsRequest calls the generic ReserveRooms builder with application/json body
+func NewReserveRoomsRequest(server string, body ReserveRoomsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReserveRoomsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewReserveRoomsRequestWithBody generates requests for ReserveRooms with any type of body
+func NewReserveRoomsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/batch")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetBumpsRequest generates requests for GetBumps
+func NewGetBumpsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/bumped")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInvitationsRequest generates requests for GetInvitations
+func NewGetInvitationsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/invitations")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPendingApprovalsRequest generates requests for GetPendingApprovals
+func NewGetPendingApprovalsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/pending")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewApproveReservationRequest calls the generic ApproveReservation builder with application/json body
+func NewApproveReservationRequest(server string, id ReservationId, body ApproveReservationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewApproveReservationRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewApproveReservationRequestWithBody generates requests for ApproveReservation with any type of body
+func NewApproveReservationRequestWithBody(server string, id ReservationId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/%s/approve", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAttendeesRequest generates requests for GetAttendees
+func NewGetAttendeesRequest(server string, id ReservationId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/%s/attendees", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddAttendeesRequest calls the generic AddAttendees builder with application/json body
+func NewAddAttendeesRequest(server string, id ReservationId, body AddAttendeesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddAttendeesRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewAddAttendeesRequestWithBody generates requests for AddAttendees with any type of body
+func NewAddAttendeesRequestWithBody(server string, id ReservationId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/%s/attendees", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRemoveAttendeeRequest generates requests for RemoveAttendee
+func NewRemoveAttendeeRequest(server string, id ReservationId, attendeeId int32) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "attendeeId", runtime.ParamLocationPath, attendeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/%s/attendees/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCheckInRequest generates requests for CheckIn
+func NewCheckInRequest(server string, id ReservationId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/%s/check-in", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRejectReservationRequest calls the generic RejectReservation builder with application/json body
+func NewRejectReservationRequest(server string, id ReservationId, body RejectReservationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRejectReservationRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewRejectReservationRequestWithBody generates requests for RejectReservation with any type of body
+func NewRejectReservationRequestWithBody(server string, id ReservationId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/%s/reject", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRsvpRequest calls the generic Rsvp builder with application/json body
+func NewRsvpRequest(server string, id ReservationId, body RsvpJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRsvpRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewRsvpRequestWithBody generates requests for Rsvp with any type of body
+func NewRsvpRequestWithBody(server string, id ReservationId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/%s/rsvp", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCheckReservationRequest generates requests for CheckReservation
+func NewCheckReservationRequest(server string, id RoomId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/room/check-reservation/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteReservationRequest generates requests for DeleteReservation
+func NewDeleteReservationRequest(server string, id RoomId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/room/delete-reservation/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewEndReservationRequest generates requests for EndReservation
+func NewEndReservationRequest(server string, id RoomId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/room/end-reservation/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReserveRoomRequest calls the generic ReserveRoom builder with application/json body
+func NewReserveRoomRequest(server string, id RoomId, body ReserveRoomJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReserveRoomRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewReserveRoomRequestWithBody generates requests for ReserveRoom with any type of body
+func NewReserveRoomRequestWithBody(server string, id RoomId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/room/reserve/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRoomEventsRequest generates requests for RoomEvents
+func NewRoomEventsRequest(server string, id RoomId, params *RoomEventsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rooms/%s/events", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.LastEventId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lastEventId", runtime.ParamLocationQuery, *params.LastEventId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewRoomEventsSocketRequest generates requests for RoomEventsSocket
+func NewRoomEventsSocketRequest(server string, id RoomId, params *RoomEventsSocketParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rooms/%s/events/ws", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.LastEventId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lastEventId", runtime.ParamLocationQuery, *params.LastEventId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPlaceHoldRequest calls the generic PlaceHold builder with application/json body
+func NewPlaceHoldRequest(server string, id RoomId, body PlaceHoldJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPlaceHoldRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPlaceHoldRequestWithBody generates requests for PlaceHold with any type of body
+func NewPlaceHoldRequestWithBody(server string, id RoomId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rooms/%s/holds", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewJoinWaitlistRequest calls the generic JoinWaitlist builder with application/json body
+func NewJoinWaitlistRequest(server string, id RoomId, body JoinWaitlistJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewJoinWaitlistRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewJoinWaitlistRequestWithBody generates requests for JoinWaitlist with any type of body
+func NewJoinWaitlistRequestWithBody(server string, id RoomId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rooms/%s/waitlist", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetBusinessHoursRequest generates requests for GetBusinessHours
+func NewGetBusinessHoursRequest(server string, site Site) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "site", runtime.ParamLocationPath, site)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sites/%s/business-hours", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetBusinessHoursRequest calls the generic SetBusinessHours builder with application/json body
+func NewSetBusinessHoursRequest(server string, site Site, body SetBusinessHoursJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetBusinessHoursRequestWithBody(server, site, "application/json", bodyReader)
+}
+
+// NewSetBusinessHoursRequestWithBody generates requests for SetBusinessHours with any type of body
+func NewSetBusinessHoursRequestWithBody(server string, site Site, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "site", runtime.ParamLocationPath, site)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sites/%s/business-hours", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetHolidaysRequest generates requests for GetHolidays
+func NewGetHolidaysRequest(server string, site Site) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "site", runtime.ParamLocationPath, site)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sites/%s/holidays", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddHolidaysRequest calls the generic AddHolidays builder with application/json body
+func NewAddHolidaysRequest(server string, site Site, body AddHolidaysJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddHolidaysRequestWithBody(server, site, "application/json", bodyReader)
+}
+
+// NewAddHolidaysRequestWithBody generates requests for AddHolidays with any type of body
+func NewAddHolidaysRequestWithBody(server string, site Site, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "site", runtime.ParamLocationPath, site)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sites/%s/holidays", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteHolidayRequest generates requests for DeleteHoliday
+func NewDeleteHolidayRequest(server string, site Site, id int32) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "site", runtime.ParamLocationPath, site)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sites/%s/holidays/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetWaitlistRequest generates requests for GetWaitlist
+func NewGetWaitlistRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/waitlist")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLeaveWaitlistRequest generates requests for LeaveWaitlist
+func NewLeaveWaitlistRequest(server string, id WaitlistEntryId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/waitlist/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAcceptWaitlistOfferRequest generates requests for AcceptWaitlistOffer
+func NewAcceptWaitlistOfferRequest(server string, id WaitlistEntryId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/waitlist/%s/accept", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetClosuresWithResponse request
+	GetClosuresWithResponse(ctx context.Context, params *GetClosuresParams, reqEditors ...RequestEditorFn) (*GetClosuresResponse, error)
+
+	// CreateClosureWithBodyWithResponse request with any body
+	CreateClosureWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateClosureResponse, error)
+
+	CreateClosureWithResponse(ctx context.Context, body CreateClosureJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateClosureResponse, error)
+
+	// DeleteClosureWithResponse request
+	DeleteClosureWithResponse(ctx context.Context, id int32, reqEditors ...RequestEditorFn) (*DeleteClosureResponse, error)
+
+	// GetDelegationsWithResponse request
+	GetDelegationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDelegationsResponse, error)
+
+	// GrantDelegationWithBodyWithResponse request with any body
+	GrantDelegationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GrantDelegationResponse, error)
+
+	GrantDelegationWithResponse(ctx context.Context, body GrantDelegationJSONRequestBody, reqEditors ...RequestEditorFn) (*GrantDelegationResponse, error)
+
+	// RevokeDelegationWithResponse request
+	RevokeDelegationWithResponse(ctx context.Context, id int32, reqEditors ...RequestEditorFn) (*RevokeDelegationResponse, error)
+
+	// DocsWithResponse request
+	DocsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DocsResponse, error)
+
+	// GetFreeBusyWithBodyWithResponse request with any body
+	GetFreeBusyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GetFreeBusyResponse, error)
+
+	GetFreeBusyWithResponse(ctx context.Context, body GetFreeBusyJSONRequestBody, reqEditors ...RequestEditorFn) (*GetFreeBusyResponse, error)
+
+	// HealthzWithResponse request
+	HealthzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HealthzResponse, error)
+
+	// ReleaseHoldWithResponse request
+	ReleaseHoldWithResponse(ctx context.Context, id HoldId, reqEditors ...RequestEditorFn) (*ReleaseHoldResponse, error)
+
+	// ConfirmHoldWithResponse request
+	ConfirmHoldWithResponse(ctx context.Context, id HoldId, reqEditors ...RequestEditorFn) (*ConfirmHoldResponse, error)
+
+	// MetricsWithResponse request
+	MetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*MetricsResponse, error)
+
+	// GetNotificationPreferencesWithResponse request
+	GetNotificationPreferencesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetNotificationPreferencesResponse, error)
+
+	// UpdateNotificationPreferencesWithBodyWithResponse request with any body
+	UpdateNotificationPreferencesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateNotificationPreferencesResponse, error)
+
+	UpdateNotificationPreferencesWithResponse(ctx context.Context, body UpdateNotificationPreferencesJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateNotificationPreferencesResponse, error)
+
+	// OpenAPIWithResponse request
+	OpenAPIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*OpenAPIResponse, error)
+
+	// GetPrioritiesWithResponse request
+	GetPrioritiesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPrioritiesResponse, error)
+
+	// SetPriorityWithBodyWithResponse request with any body
+	SetPriorityWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPriorityResponse, error)
+
+	SetPriorityWithResponse(ctx context.Context, body SetPriorityJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPriorityResponse, error)
+
+	// GetQuotasWithResponse request
+	GetQuotasWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetQuotasResponse, error)
+
+	// SetQuotaWithBodyWithResponse request with any body
+	SetQuotaWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetQuotaResponse, error)
+
+	SetQuotaWithResponse(ctx context.Context, body SetQuotaJSONRequestBody, reqEditors ...RequestEditorFn) (*SetQuotaResponse, error)
+
+	// GetRemainingQuotaWithResponse request
+	GetRemainingQuotaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetRemainingQuotaResponse, error)
+
+	// DeleteQuotaWithResponse request
+	DeleteQuotaWithResponse(ctx context.Context, id int32, reqEditors ...RequestEditorFn) (*DeleteQuotaResponse, error)
+
+	// ReadyzWithResponse request
+	ReadyzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReadyzResponse, error)
+
+	// ReserveRoomsWithBodyWithResponse request with any body
+	ReserveRoomsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReserveRoomsResponse, error)
+
+	ReserveRoomsWithResponse(ctx context.Context, body ReserveRoomsJSONRequestBody, reqEditors ...RequestEditorFn) (*ReserveRoomsResponse, error)
+
+	// GetBumpsWithResponse request
+	GetBumpsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetBumpsResponse, error)
+
+	// GetInvitationsWithResponse request
+	GetInvitationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInvitationsResponse, error)
+
+	// GetPendingApprovalsWithResponse request
+	GetPendingApprovalsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPendingApprovalsResponse, error)
+
+	// ApproveReservationWithBodyWithResponse request with any body
+	ApproveReservationWithBodyWithResponse(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApproveReservationResponse, error)
+
+	ApproveReservationWithResponse(ctx context.Context, id ReservationId, body ApproveReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*ApproveReservationResponse, error)
+
+	// GetAttendeesWithResponse request
+	GetAttendeesWithResponse(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*GetAttendeesResponse, error)
+
+	// AddAttendeesWithBodyWithResponse request with any body
+	AddAttendeesWithBodyWithResponse(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddAttendeesResponse, error)
+
+	AddAttendeesWithResponse(ctx context.Context, id ReservationId, body AddAttendeesJSONRequestBody, reqEditors ...RequestEditorFn) (*AddAttendeesResponse, error)
+
+	// RemoveAttendeeWithResponse request
+	RemoveAttendeeWithResponse(ctx context.Context, id ReservationId, attendeeId int32, reqEditors ...RequestEditorFn) (*RemoveAttendeeResponse, error)
+
+	// CheckInWithResponse request
+	CheckInWithResponse(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*CheckInResponse, error)
+
+	// RejectReservationWithBodyWithResponse request with any body
+	RejectReservationWithBodyWithResponse(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RejectReservationResponse, error)
+
+	RejectReservationWithResponse(ctx context.Context, id ReservationId, body RejectReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*RejectReservationResponse, error)
+
+	// RsvpWithBodyWithResponse request with any body
+	RsvpWithBodyWithResponse(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RsvpResponse, error)
+
+	RsvpWithResponse(ctx context.Context, id ReservationId, body RsvpJSONRequestBody, reqEditors ...RequestEditorFn) (*RsvpResponse, error)
+
+	// CheckReservationWithResponse request
+	CheckReservationWithResponse(ctx context.Context, id RoomId, reqEditors ...RequestEditorFn) (*CheckReservationResponse, error)
+
+	// DeleteReservationWithResponse request
+	DeleteReservationWithResponse(ctx context.Context, id RoomId, reqEditors ...RequestEditorFn) (*DeleteReservationResponse, error)
+
+	// EndReservationWithResponse request
+	EndReservationWithResponse(ctx context.Context, id RoomId, reqEditors ...RequestEditorFn) (*EndReservationResponse, error)
+
+	// ReserveRoomWithBodyWithResponse request with any body
+	ReserveRoomWithBodyWithResponse(ctx context.Context, id RoomId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReserveRoomResponse, error)
+
+	ReserveRoomWithResponse(ctx context.Context, id RoomId, body ReserveRoomJSONRequestBody, reqEditors ...RequestEditorFn) (*ReserveRoomResponse, error)
+
+	// RoomEventsWithResponse request
+	RoomEventsWithResponse(ctx context.Context, id RoomId, params *RoomEventsParams, reqEditors ...RequestEditorFn) (*RoomEventsResponse, error)
+
+	// RoomEventsSocketWithResponse request
+	RoomEventsSocketWithResponse(ctx context.Context, id RoomId, params *RoomEventsSocketParams, reqEditors ...RequestEditorFn) (*RoomEventsSocketResponse, error)
+
+	// PlaceHoldWithBodyWithResponse request with any body
+	PlaceHoldWithBodyWithResponse(ctx context.Context, id RoomId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PlaceHoldResponse, error)
+
+	PlaceHoldWithResponse(ctx context.Context, id RoomId, body PlaceHoldJSONRequestBody, reqEditors ...RequestEditorFn) (*PlaceHoldResponse, error)
+
+	// JoinWaitlistWithBodyWithResponse request with any body
+	JoinWaitlistWithBodyWithResponse(ctx context.Context, id RoomId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*JoinWaitlistResponse, error)
+
+	JoinWaitlistWithResponse(ctx context.Context, id RoomId, body JoinWaitlistJSONRequestBody, reqEditors ...RequestEditorFn) (*JoinWaitlistResponse, error)
+
+	// GetBusinessHoursWithResponse request
+	GetBusinessHoursWithResponse(ctx context.Context, site Site, reqEditors ...RequestEditorFn) (*GetBusinessHoursResponse, error)
+
+	// SetBusinessHoursWithBodyWithResponse request with any body
+	SetBusinessHoursWithBodyWithResponse(ctx context.Context, site Site, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetBusinessHoursResponse, error)
+
+	SetBusinessHoursWithResponse(ctx context.Context, site Site, body SetBusinessHoursJSONRequestBody, reqEditors ...RequestEditorFn) (*SetBusinessHoursResponse, error)
+
+	// GetHolidaysWithResponse request
+	GetHolidaysWithResponse(ctx context.Context, site Site, reqEditors ...RequestEditorFn) (*GetHolidaysResponse, error)
+
+	// AddHolidaysWithBodyWithResponse request with any body
+	AddHolidaysWithBodyWithResponse(ctx context.Context, site Site, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddHolidaysResponse, error)
+
+	AddHolidaysWithResponse(ctx context.Context, site Site, body AddHolidaysJSONRequestBody, reqEditors ...RequestEditorFn) (*AddHolidaysResponse, error)
+
+	// DeleteHolidayWithResponse request
+	DeleteHolidayWithResponse(ctx context.Context, site Site, id int32, reqEditors ...RequestEditorFn) (*DeleteHolidayResponse, error)
+
+	// GetWaitlistWithResponse request
+	GetWaitlistWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWaitlistResponse, error)
+
+	// LeaveWaitlistWithResponse request
+	LeaveWaitlistWithResponse(ctx context.Context, id WaitlistEntryId, reqEditors ...RequestEditorFn) (*LeaveWaitlistResponse, error)
+
+	// AcceptWaitlistOfferWithResponse request
+	AcceptWaitlistOfferWithResponse(ctx context.Context, id WaitlistEntryId, reqEditors ...RequestEditorFn) (*AcceptWaitlistOfferResponse, error)
+}
+
+type GetClosuresResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Closure
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetClosuresResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetClosuresResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateClosureResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ClosureResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateClosureResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateClosureResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteClosureResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteClosureResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteClosureResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDelegationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Delegation
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDelegationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDelegationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GrantDelegationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GrantDelegationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GrantDelegationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeDelegationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeDelegationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeDelegationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DocsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DocsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DocsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFreeBusyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FreeBusy
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFreeBusyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFreeBusyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type HealthzResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Health
+}
+
+// Status returns HTTPResponse.Status
+func (r HealthzResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r HealthzResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReleaseHoldResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ReleaseHoldResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReleaseHoldResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ConfirmHoldResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ConfirmHoldResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ConfirmHoldResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type MetricsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r MetricsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r MetricsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetNotificationPreferencesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NotificationPreferences
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetNotificationPreferencesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetNotificationPreferencesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateNotificationPreferencesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NotificationPreferences
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateNotificationPreferencesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateNotificationPreferencesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type OpenAPIResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r OpenAPIResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r OpenAPIResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPrioritiesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RolePriority
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPrioritiesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPrioritiesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetPriorityResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SetPriorityResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetPriorityResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQuotasResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Quota
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQuotasResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQuotasResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetQuotaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SetQuotaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetQuotaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRemainingQuotaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuotaUsage
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRemainingQuotaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRemainingQuotaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteQuotaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteQuotaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteQuotaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReadyzResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Health
+	JSON503      *Health
+}
+
+// Status returns HTTPResponse.Status
+func (r ReadyzResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReadyzResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReserveRoomsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ReserveRoomsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReserveRoomsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBumpsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Bump
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBumpsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBumpsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInvitationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Reservations
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInvitationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInvitationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPendingApprovalsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Reservations
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPendingApprovalsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPendingApprovalsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ApproveReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ApproveReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ApproveReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAttendeesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Attendee
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAttendeesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAttendeesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddAttendeesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AddAttendeesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddAttendeesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveAttendeeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveAttendeeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveAttendeeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CheckInResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CheckInResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CheckInResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RejectReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RejectReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RejectReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RsvpResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RsvpResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RsvpResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CheckReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CheckReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CheckReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EndReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r EndReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EndReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReserveRoomResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ReserveRoomResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReserveRoomResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RoomEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RoomEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RoomEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RoomEventsSocketResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RoomEventsSocketResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RoomEventsSocketResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PlaceHoldResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PlaceHoldResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PlaceHoldResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type JoinWaitlistResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r JoinWaitlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r JoinWaitlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBusinessHoursResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]BusinessHours
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBusinessHoursResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBusinessHoursResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetBusinessHoursResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SetBusinessHoursResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetBusinessHoursResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHolidaysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Holiday
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHolidaysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHolidaysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddHolidaysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AddHolidaysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddHolidaysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteHolidayResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteHolidayResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteHolidayResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWaitlistResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]WaitlistEntry
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWaitlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWaitlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LeaveWaitlistResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r LeaveWaitlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LeaveWaitlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AcceptWaitlistOfferResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReservationResponse
+	JSON401      *Unauthorized
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AcceptWaitlistOfferResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AcceptWaitlistOfferResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetClosuresWithResponse request returning *GetClosuresResponse
+func (c *ClientWithResponses) GetClosuresWithResponse(ctx context.Context, params *GetClosuresParams, reqEditors ...RequestEditorFn) (*GetClosuresResponse, error) {
+	rsp, err := c.GetClosures(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetClosuresResponse(rsp)
+}
+
+// CreateClosureWithBodyWithResponse request with arbitrary body returning *CreateClosureResponse
+func (c *ClientWithResponses) CreateClosureWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateClosureResponse, error) {
+	rsp, err := c.CreateClosureWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateClosureResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateClosureWithResponse(ctx context.Context, body CreateClosureJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateClosureResponse, error) {
+	rsp, err := c.CreateClosure(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateClosureResponse(rsp)
+}
+
+// DeleteClosureWithResponse request returning *DeleteClosureResponse
+func (c *ClientWithResponses) DeleteClosureWithResponse(ctx context.Context, id int32, reqEditors ...RequestEditorFn) (*DeleteClosureResponse, error) {
+	rsp, err := c.DeleteClosure(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteClosureResponse(rsp)
+}
+
+// GetDelegationsWithResponse request returning *GetDelegationsResponse
+func (c *ClientWithResponses) GetDelegationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDelegationsResponse, error) {
+	rsp, err := c.GetDelegations(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDelegationsResponse(rsp)
+}
+
+// GrantDelegationWithBodyWithResponse request with arbitrary body returning *GrantDelegationResponse
+func (c *ClientWithResponses) GrantDelegationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GrantDelegationResponse, error) {
+	rsp, err := c.GrantDelegationWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGrantDelegationResponse(rsp)
+}
+
+func (c *ClientWithResponses) GrantDelegationWithResponse(ctx context.Context, body GrantDelegationJSONRequestBody, reqEditors ...RequestEditorFn) (*GrantDelegationResponse, error) {
+	rsp, err := c.GrantDelegation(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGrantDelegationResponse(rsp)
+}
+
+// RevokeDelegationWithResponse request returning *RevokeDelegationResponse
+func (c *ClientWithResponses) RevokeDelegationWithResponse(ctx context.Context, id int32, reqEditors ...RequestEditorFn) (*RevokeDelegationResponse, error) {
+	rsp, err := c.RevokeDelegation(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeDelegationResponse(rsp)
+}
+
+// DocsWithResponse request returning *DocsResponse
+func (c *ClientWithResponses) DocsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DocsResponse, error) {
+	rsp, err := c.Docs(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDocsResponse(rsp)
+}
+
+// GetFreeBusyWithBodyWithResponse request with arbitrary body returning *GetFreeBusyResponse
+func (c *ClientWithResponses) GetFreeBusyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GetFreeBusyResponse, error) {
+	rsp, err := c.GetFreeBusyWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFreeBusyResponse(rsp)
+}
+
+func (c *ClientWithResponses) GetFreeBusyWithResponse(ctx context.Context, body GetFreeBusyJSONRequestBody, reqEditors ...RequestEditorFn) (*GetFreeBusyResponse, error) {
+	rsp, err := c.GetFreeBusy(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFreeBusyResponse(rsp)
+}
+
+// HealthzWithResponse request returning *HealthzResponse
+func (c *ClientWithResponses) HealthzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HealthzResponse, error) {
+	rsp, err := c.Healthz(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHealthzResponse(rsp)
+}
+
+// ReleaseHoldWithResponse request returning *ReleaseHoldResponse
+func (c *ClientWithResponses) ReleaseHoldWithResponse(ctx context.Context, id HoldId, reqEditors ...RequestEditorFn) (*ReleaseHoldResponse, error) {
+	rsp, err := c.ReleaseHold(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReleaseHoldResponse(rsp)
+}
+
+// ConfirmHoldWithResponse request returning *ConfirmHoldResponse
+func (c *ClientWithResponses) ConfirmHoldWithResponse(ctx context.Context, id HoldId, reqEditors ...RequestEditorFn) (*ConfirmHoldResponse, error) {
+	rsp, err := c.ConfirmHold(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfirmHoldResponse(rsp)
+}
+
+// MetricsWithResponse request returning *MetricsResponse
+func (c *ClientWithResponses) MetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*MetricsResponse, error) {
+	rsp, err := c.Metrics(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMetricsResponse(rsp)
+}
+
+// GetNotificationPreferencesWithResponse request returning *GetNotificationPreferencesResponse
+func (c *ClientWithResponses) GetNotificationPreferencesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetNotificationPreferencesResponse, error) {
+	rsp, err := c.GetNotificationPreferences(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNotificationPreferencesResponse(rsp)
+}
+
+// UpdateNotificationPreferencesWithBodyWithResponse request with arbitrary body returning *UpdateNotificationPreferencesResponse
+func (c *ClientWithResponses) UpdateNotificationPreferencesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateNotificationPreferencesResponse, error) {
+	rsp, err := c.UpdateNotificationPreferencesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateNotificationPreferencesResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateNotificationPreferencesWithResponse(ctx context.Context, body UpdateNotificationPreferencesJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateNotificationPreferencesResponse, error) {
+	rsp, err := c.UpdateNotificationPreferences(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateNotificationPreferencesResponse(rsp)
+}
+
+// OpenAPIWithResponse request returning *OpenAPIResponse
+func (c *ClientWithResponses) OpenAPIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*OpenAPIResponse, error) {
+	rsp, err := c.OpenAPI(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOpenAPIResponse(rsp)
+}
+
+// GetPrioritiesWithResponse request returning *GetPrioritiesResponse
+func (c *ClientWithResponses) GetPrioritiesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPrioritiesResponse, error) {
+	rsp, err := c.GetPriorities(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPrioritiesResponse(rsp)
+}
+
+// SetPriorityWithBodyWithResponse request with arbitrary body returning *SetPriorityResponse
+func (c *ClientWithResponses) SetPriorityWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPriorityResponse, error) {
+	rsp, err := c.SetPriorityWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPriorityResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetPriorityWithResponse(ctx context.Context, body SetPriorityJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPriorityResponse, error) {
+	rsp, err := c.SetPriority(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPriorityResponse(rsp)
+}
+
+// GetQuotasWithResponse request returning *GetQuotasResponse
+func (c *ClientWithResponses) GetQuotasWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetQuotasResponse, error) {
+	rsp, err := c.GetQuotas(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuotasResponse(rsp)
+}
+
+// SetQuotaWithBodyWithResponse request with arbitrary body returning *SetQuotaResponse
+func (c *ClientWithResponses) SetQuotaWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetQuotaResponse, error) {
+	rsp, err := c.SetQuotaWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetQuotaResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetQuotaWithResponse(ctx context.Context, body SetQuotaJSONRequestBody, reqEditors ...RequestEditorFn) (*SetQuotaResponse, error) {
+	rsp, err := c.SetQuota(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetQuotaResponse(rsp)
+}
+
+// GetRemainingQuotaWithResponse request returning *GetRemainingQuotaResponse
+func (c *ClientWithResponses) GetRemainingQuotaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetRemainingQuotaResponse, error) {
+	rsp, err := c.GetRemainingQuota(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRemainingQuotaResponse(rsp)
+}
+
+// DeleteQuotaWithResponse request returning *DeleteQuotaResponse
+func (c *ClientWithResponses) DeleteQuotaWithResponse(ctx context.Context, id int32, reqEditors ...RequestEditorFn) (*DeleteQuotaResponse, error) {
+	rsp, err := c.DeleteQuota(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteQuotaResponse(rsp)
+}
+
+// ReadyzWithResponse request returning *ReadyzResponse
+func (c *ClientWithResponses) ReadyzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReadyzResponse, error) {
+	rsp, err := c.Readyz(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReadyzResponse(rsp)
+}
+
+// ReserveRoomsWithBodyWithResponse request with arbitrary body returning *ReserveRoomsResponse
+func (c *ClientWithResponses) ReserveRoomsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReserveRoomsResponse, error) {
+	rsp, err := c.ReserveRoomsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReserveRoomsResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReserveRoomsWithResponse(ctx context.Context, body ReserveRoomsJSONRequestBody, reqEditors ...RequestEditorFn) (*ReserveRoomsResponse, error) {
+	rsp, err := c.ReserveRooms(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReserveRoomsResponse(rsp)
+}
+
+// GetBumpsWithResponse request returning *GetBumpsResponse
+func (c *ClientWithResponses) GetBumpsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetBumpsResponse, error) {
+	rsp, err := c.GetBumps(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBumpsResponse(rsp)
+}
+
+// GetInvitationsWithResponse request returning *GetInvitationsResponse
+func (c *ClientWithResponses) GetInvitationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInvitationsResponse, error) {
+	rsp, err := c.GetInvitations(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInvitationsResponse(rsp)
+}
+
+// GetPendingApprovalsWithResponse request returning *GetPendingApprovalsResponse
+func (c *ClientWithResponses) GetPendingApprovalsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPendingApprovalsResponse, error) {
+	rsp, err := c.GetPendingApprovals(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPendingApprovalsResponse(rsp)
+}
+
+// ApproveReservationWithBodyWithResponse request with arbitrary body returning *ApproveReservationResponse
+func (c *ClientWithResponses) ApproveReservationWithBodyWithResponse(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApproveReservationResponse, error) {
+	rsp, err := c.ApproveReservationWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApproveReservationResponse(rsp)
+}
+
+func (c *ClientWithResponses) ApproveReservationWithResponse(ctx context.Context, id ReservationId, body ApproveReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*ApproveReservationResponse, error) {
+	rsp, err := c.ApproveReservation(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApproveReservationResponse(rsp)
+}
+
+// GetAttendeesWithResponse request returning *GetAttendeesResponse
+func (c *ClientWithResponses) GetAttendeesWithResponse(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*GetAttendeesResponse, error) {
+	rsp, err := c.GetAttendees(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAttendeesResponse(rsp)
+}
+
+// AddAttendeesWithBodyWithResponse request with arbitrary body returning *AddAttendeesResponse
+func (c *ClientWithResponses) AddAttendeesWithBodyWithResponse(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddAttendeesResponse, error) {
+	rsp, err := c.AddAttendeesWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddAttendeesResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddAttendeesWithResponse(ctx context.Context, id ReservationId, body AddAttendeesJSONRequestBody, reqEditors ...RequestEditorFn) (*AddAttendeesResponse, error) {
+	rsp, err := c.AddAttendees(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddAttendeesResponse(rsp)
+}
+
+// RemoveAttendeeWithResponse request returning *RemoveAttendeeResponse
+func (c *ClientWithResponses) RemoveAttendeeWithResponse(ctx context.Context, id ReservationId, attendeeId int32, reqEditors ...RequestEditorFn) (*RemoveAttendeeResponse, error) {
+	rsp, err := c.RemoveAttendee(ctx, id, attendeeId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveAttendeeResponse(rsp)
+}
+
+// CheckInWithResponse request returning *CheckInResponse
+func (c *ClientWithResponses) CheckInWithResponse(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*CheckInResponse, error) {
+	rsp, err := c.CheckIn(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCheckInResponse(rsp)
+}
+
+// RejectReservationWithBodyWithResponse request with arbitrary body returning *RejectReservationResponse
+func (c *ClientWithResponses) RejectReservationWithBodyWithResponse(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RejectReservationResponse, error) {
+	rsp, err := c.RejectReservationWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRejectReservationResponse(rsp)
+}
+
+func (c *ClientWithResponses) RejectReservationWithResponse(ctx context.Context, id ReservationId, body RejectReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*RejectReservationResponse, error) {
+	rsp, err := c.RejectReservation(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRejectReservationResponse(rsp)
+}
+
+// RsvpWithBodyWithResponse request with arbitrary body returning *RsvpResponse
+func (c *ClientWithResponses) RsvpWithBodyWithResponse(ctx context.Context, id ReservationId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RsvpResponse, error) {
+	rsp, err := c.RsvpWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRsvpResponse(rsp)
+}
+
+func (c *ClientWithResponses) RsvpWithResponse(ctx context.Context, id ReservationId, body RsvpJSONRequestBody, reqEditors ...RequestEditorFn) (*RsvpResponse, error) {
+	rsp, err := c.Rsvp(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRsvpResponse(rsp)
+}
+
+// CheckReservationWithResponse request returning *CheckReservationResponse
+func (c *ClientWithResponses) CheckReservationWithResponse(ctx context.Context, id RoomId, reqEditors ...RequestEditorFn) (*CheckReservationResponse, error) {
+	rsp, err := c.CheckReservation(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCheckReservationResponse(rsp)
+}
+
+// DeleteReservationWithResponse request returning *DeleteReservationResponse
+func (c *ClientWithResponses) DeleteReservationWithResponse(ctx context.Context, id RoomId, reqEditors ...RequestEditorFn) (*DeleteReservationResponse, error) {
+	rsp, err := c.DeleteReservation(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteReservationResponse(rsp)
+}
+
+// EndReservationWithResponse request returning *EndReservationResponse
+func (c *ClientWithResponses) EndReservationWithResponse(ctx context.Context, id RoomId, reqEditors ...RequestEditorFn) (*EndReservationResponse, error) {
+	rsp, err := c.EndReservation(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEndReservationResponse(rsp)
+}
+
+// ReserveRoomWithBodyWithResponse request with arbitrary body returning *ReserveRoomResponse
+func (c *ClientWithResponses) ReserveRoomWithBodyWithResponse(ctx context.Context, id RoomId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReserveRoomResponse, error) {
+	rsp, err := c.ReserveRoomWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReserveRoomResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReserveRoomWithResponse(ctx context.Context, id RoomId, body ReserveRoomJSONRequestBody, reqEditors ...RequestEditorFn) (*ReserveRoomResponse, error) {
+	rsp, err := c.ReserveRoom(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReserveRoomResponse(rsp)
+}
+
+// RoomEventsWithResponse request returning *RoomEventsResponse
+func (c *ClientWithResponses) RoomEventsWithResponse(ctx context.Context, id RoomId, params *RoomEventsParams, reqEditors ...RequestEditorFn) (*RoomEventsResponse, error) {
+	rsp, err := c.RoomEvents(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRoomEventsResponse(rsp)
+}
+
+// RoomEventsSocketWithResponse request returning *RoomEventsSocketResponse
+func (c *ClientWithResponses) RoomEventsSocketWithResponse(ctx context.Context, id RoomId, params *RoomEventsSocketParams, reqEditors ...RequestEditorFn) (*RoomEventsSocketResponse, error) {
+	rsp, err := c.RoomEventsSocket(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRoomEventsSocketResponse(rsp)
+}
+
+// PlaceHoldWithBodyWithResponse request with arbitrary body returning *PlaceHoldResponse
+func (c *ClientWithResponses) PlaceHoldWithBodyWithResponse(ctx context.Context, id RoomId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PlaceHoldResponse, error) {
+	rsp, err := c.PlaceHoldWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePlaceHoldResponse(rsp)
+}
+
+func (c *ClientWithResponses) PlaceHoldWithResponse(ctx context.Context, id RoomId, body PlaceHoldJSONRequestBody, reqEditors ...RequestEditorFn) (*PlaceHoldResponse, error) {
+	rsp, err := c.PlaceHold(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePlaceHoldResponse(rsp)
+}
+
+// JoinWaitlistWithBodyWithResponse request with arbitrary body returning *JoinWaitlistResponse
+func (c *ClientWithResponses) JoinWaitlistWithBodyWithResponse(ctx context.Context, id RoomId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*JoinWaitlistResponse, error) {
+	rsp, err := c.JoinWaitlistWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJoinWaitlistResponse(rsp)
+}
+
+func (c *ClientWithResponses) JoinWaitlistWithResponse(ctx context.Context, id RoomId, body JoinWaitlistJSONRequestBody, reqEditors ...RequestEditorFn) (*JoinWaitlistResponse, error) {
+	rsp, err := c.JoinWaitlist(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJoinWaitlistResponse(rsp)
+}
+
+// GetBusinessHoursWithResponse request returning *GetBusinessHoursResponse
+func (c *ClientWithResponses) GetBusinessHoursWithResponse(ctx context.Context, site Site, reqEditors ...RequestEditorFn) (*GetBusinessHoursResponse, error) {
+	rsp, err := c.GetBusinessHours(ctx, site, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBusinessHoursResponse(rsp)
+}
+
+// SetBusinessHoursWithBodyWithResponse request with arbitrary body returning *SetBusinessHoursResponse
+func (c *ClientWithResponses) SetBusinessHoursWithBodyWithResponse(ctx context.Context, site Site, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetBusinessHoursResponse, error) {
+	rsp, err := c.SetBusinessHoursWithBody(ctx, site, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetBusinessHoursResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetBusinessHoursWithResponse(ctx context.Context, site Site, body SetBusinessHoursJSONRequestBody, reqEditors ...RequestEditorFn) (*SetBusinessHoursResponse, error) {
+	rsp, err := c.SetBusinessHours(ctx, site, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetBusinessHoursResponse(rsp)
+}
+
+// GetHolidaysWithResponse request returning *GetHolidaysResponse
+func (c *ClientWithResponses) GetHolidaysWithResponse(ctx context.Context, site Site, reqEditors ...RequestEditorFn) (*GetHolidaysResponse, error) {
+	rsp, err := c.GetHolidays(ctx, site, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHolidaysResponse(rsp)
+}
+
+// AddHolidaysWithBodyWithResponse request with arbitrary body returning *AddHolidaysResponse
+func (c *ClientWithResponses) AddHolidaysWithBodyWithResponse(ctx context.Context, site Site, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddHolidaysResponse, error) {
+	rsp, err := c.AddHolidaysWithBody(ctx, site, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddHolidaysResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddHolidaysWithResponse(ctx context.Context, site Site, body AddHolidaysJSONRequestBody, reqEditors ...RequestEditorFn) (*AddHolidaysResponse, error) {
+	rsp, err := c.AddHolidays(ctx, site, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddHolidaysResponse(rsp)
+}
+
+// DeleteHolidayWithResponse request returning *DeleteHolidayResponse
+func (c *ClientWithResponses) DeleteHolidayWithResponse(ctx context.Context, site Site, id int32, reqEditors ...RequestEditorFn) (*DeleteHolidayResponse, error) {
+	rsp, err := c.DeleteHoliday(ctx, site, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteHolidayResponse(rsp)
+}
+
+// GetWaitlistWithResponse request returning *GetWaitlistResponse
+func (c *ClientWithResponses) GetWaitlistWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWaitlistResponse, error) {
+	rsp, err := c.GetWaitlist(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWaitlistResponse(rsp)
+}
+
+// LeaveWaitlistWithResponse request returning *LeaveWaitlistResponse
+func (c *ClientWithResponses) LeaveWaitlistWithResponse(ctx context.Context, id WaitlistEntryId, reqEditors ...RequestEditorFn) (*LeaveWaitlistResponse, error) {
+	rsp, err := c.LeaveWaitlist(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLeaveWaitlistResponse(rsp)
+}
+
+// AcceptWaitlistOfferWithResponse request returning *AcceptWaitlistOfferResponse
+func (c *ClientWithResponses) AcceptWaitlistOfferWithResponse(ctx context.Context, id WaitlistEntryId, reqEditors ...RequestEditorFn) (*AcceptWaitlistOfferResponse, error) {
+	rsp, err := c.AcceptWaitlistOffer(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAcceptWaitlistOfferResponse(rsp)
+}
+
+// ParseGetClosuresResponse parses an HTTP response from a GetClosuresWithResponse call
+func ParseGetClosuresResponse(rsp *http.Response) (*GetClosuresResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetClosuresResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Closure
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateClosureResponse parses an HTTP response from a CreateClosureWithResponse call
+func ParseCreateClosureResponse(rsp *http.Response) (*CreateClosureResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateClosureResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ClosureResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteClosureResponse parses an HTTP response from a DeleteClosureWithResponse call
+func ParseDeleteClosureResponse(rsp *http.Response) (*DeleteClosureResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteClosureResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDelegationsResponse parses an HTTP response from a GetDelegationsWithResponse call
+func ParseGetDelegationsResponse(rsp *http.Response) (*GetDelegationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDelegationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Delegation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGrantDelegationResponse parses an HTTP response from a GrantDelegationWithResponse call
+func ParseGrantDelegationResponse(rsp *http.Response) (*GrantDelegationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GrantDelegationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRevokeDelegationResponse parses an HTTP response from a RevokeDelegationWithResponse call
+func ParseRevokeDelegationResponse(rsp *http.Response) (*RevokeDelegationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevokeDelegationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDocsResponse parses an HTTP response from a DocsWithResponse call
+func ParseDocsResponse(rsp *http.Response) (*DocsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DocsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetFreeBusyResponse parses an HTTP response from a GetFreeBusyWithResponse call
+func ParseGetFreeBusyResponse(rsp *http.Response) (*GetFreeBusyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFreeBusyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FreeBusy
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseHealthzResponse parses an HTTP response from a HealthzWithResponse call
+func ParseHealthzResponse(rsp *http.Response) (*HealthzResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HealthzResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Health
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReleaseHoldResponse parses an HTTP response from a ReleaseHoldWithResponse call
+func ParseReleaseHoldResponse(rsp *http.Response) (*ReleaseHoldResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReleaseHoldResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseConfirmHoldResponse parses an HTTP response from a ConfirmHoldWithResponse call
+func ParseConfirmHoldResponse(rsp *http.Response) (*ConfirmHoldResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ConfirmHoldResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseMetricsResponse parses an HTTP response from a MetricsWithResponse call
+func ParseMetricsResponse(rsp *http.Response) (*MetricsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MetricsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetNotificationPreferencesResponse parses an HTTP response from a GetNotificationPreferencesWithResponse call
+func ParseGetNotificationPreferencesResponse(rsp *http.Response) (*GetNotificationPreferencesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetNotificationPreferencesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NotificationPreferences
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateNotificationPreferencesResponse parses an HTTP response from a UpdateNotificationPreferencesWithResponse call
+func ParseUpdateNotificationPreferencesResponse(rsp *http.Response) (*UpdateNotificationPreferencesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateNotificationPreferencesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NotificationPreferences
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseOpenAPIResponse parses an HTTP response from a OpenAPIWithResponse call
+func ParseOpenAPIResponse(rsp *http.Response) (*OpenAPIResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OpenAPIResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPrioritiesResponse parses an HTTP response from a GetPrioritiesWithResponse call
+func ParseGetPrioritiesResponse(rsp *http.Response) (*GetPrioritiesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPrioritiesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RolePriority
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetPriorityResponse parses an HTTP response from a SetPriorityWithResponse call
+func ParseSetPriorityResponse(rsp *http.Response) (*SetPriorityResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetPriorityResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQuotasResponse parses an HTTP response from a GetQuotasWithResponse call
+func ParseGetQuotasResponse(rsp *http.Response) (*GetQuotasResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuotasResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Quota
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetQuotaResponse parses an HTTP response from a SetQuotaWithResponse call
+func ParseSetQuotaResponse(rsp *http.Response) (*SetQuotaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetQuotaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRemainingQuotaResponse parses an HTTP response from a GetRemainingQuotaWithResponse call
+func ParseGetRemainingQuotaResponse(rsp *http.Response) (*GetRemainingQuotaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRemainingQuotaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuotaUsage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteQuotaResponse parses an HTTP response from a DeleteQuotaWithResponse call
+func ParseDeleteQuotaResponse(rsp *http.Response) (*DeleteQuotaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteQuotaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReadyzResponse parses an HTTP response from a ReadyzWithResponse call
+func ParseReadyzResponse(rsp *http.Response) (*ReadyzResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReadyzResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Health
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Health
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReserveRoomsResponse parses an HTTP response from a ReserveRoomsWithResponse call
+func ParseReserveRoomsResponse(rsp *http.Response) (*ReserveRoomsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReserveRoomsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBumpsResponse parses an HTTP response from a GetBumpsWithResponse call
+func ParseGetBumpsResponse(rsp *http.Response) (*GetBumpsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBumpsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Bump
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInvitationsResponse parses an HTTP response from a GetInvitationsWithResponse call
+func ParseGetInvitationsResponse(rsp *http.Response) (*GetInvitationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInvitationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Reservations
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPendingApprovalsResponse parses an HTTP response from a GetPendingApprovalsWithResponse call
+func ParseGetPendingApprovalsResponse(rsp *http.Response) (*GetPendingApprovalsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPendingApprovalsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Reservations
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseApproveReservationResponse parses an HTTP response from a ApproveReservationWithResponse call
+func ParseApproveReservationResponse(rsp *http.Response) (*ApproveReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ApproveReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAttendeesResponse parses an HTTP response from a GetAttendeesWithResponse call
+func ParseGetAttendeesResponse(rsp *http.Response) (*GetAttendeesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAttendeesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Attendee
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddAttendeesResponse parses an HTTP response from a AddAttendeesWithResponse call
+func ParseAddAttendeesResponse(rsp *http.Response) (*AddAttendeesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddAttendeesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveAttendeeResponse parses an HTTP response from a RemoveAttendeeWithResponse call
+func ParseRemoveAttendeeResponse(rsp *http.Response) (*RemoveAttendeeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveAttendeeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCheckInResponse parses an HTTP response from a CheckInWithResponse call
+func ParseCheckInResponse(rsp *http.Response) (*CheckInResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CheckInResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRejectReservationResponse parses an HTTP response from a RejectReservationWithResponse call
+func ParseRejectReservationResponse(rsp *http.Response) (*RejectReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RejectReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRsvpResponse parses an HTTP response from a RsvpWithResponse call
+func ParseRsvpResponse(rsp *http.Response) (*RsvpResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RsvpResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCheckReservationResponse parses an HTTP response from a CheckReservationWithResponse call
+func ParseCheckReservationResponse(rsp *http.Response) (*CheckReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CheckReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteReservationResponse parses an HTTP response from a DeleteReservationWithResponse call
+func ParseDeleteReservationResponse(rsp *http.Response) (*DeleteReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseEndReservationResponse parses an HTTP response from a EndReservationWithResponse call
+func ParseEndReservationResponse(rsp *http.Response) (*EndReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EndReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReserveRoomResponse parses an HTTP response from a ReserveRoomWithResponse call
+func ParseReserveRoomResponse(rsp *http.Response) (*ReserveRoomResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReserveRoomResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRoomEventsResponse parses an HTTP response from a RoomEventsWithResponse call
+func ParseRoomEventsResponse(rsp *http.Response) (*RoomEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RoomEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRoomEventsSocketResponse parses an HTTP response from a RoomEventsSocketWithResponse call
+func ParseRoomEventsSocketResponse(rsp *http.Response) (*RoomEventsSocketResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RoomEventsSocketResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePlaceHoldResponse parses an HTTP response from a PlaceHoldWithResponse call
+func ParsePlaceHoldResponse(rsp *http.Response) (*PlaceHoldResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PlaceHoldResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseJoinWaitlistResponse parses an HTTP response from a JoinWaitlistWithResponse call
+func ParseJoinWaitlistResponse(rsp *http.Response) (*JoinWaitlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &JoinWaitlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBusinessHoursResponse parses an HTTP response from a GetBusinessHoursWithResponse call
+func ParseGetBusinessHoursResponse(rsp *http.Response) (*GetBusinessHoursResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBusinessHoursResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []BusinessHours
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetBusinessHoursResponse parses an HTTP response from a SetBusinessHoursWithResponse call
+func ParseSetBusinessHoursResponse(rsp *http.Response) (*SetBusinessHoursResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetBusinessHoursResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHolidaysResponse parses an HTTP response from a GetHolidaysWithResponse call
+func ParseGetHolidaysResponse(rsp *http.Response) (*GetHolidaysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHolidaysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Holiday
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddHolidaysResponse parses an HTTP response from a AddHolidaysWithResponse call
+func ParseAddHolidaysResponse(rsp *http.Response) (*AddHolidaysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddHolidaysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteHolidayResponse parses an HTTP response from a DeleteHolidayWithResponse call
+func ParseDeleteHolidayResponse(rsp *http.Response) (*DeleteHolidayResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteHolidayResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWaitlistResponse parses an HTTP response from a GetWaitlistWithResponse call
+func ParseGetWaitlistResponse(rsp *http.Response) (*GetWaitlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWaitlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []WaitlistEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLeaveWaitlistResponse parses an HTTP response from a LeaveWaitlistWithResponse call
+func ParseLeaveWaitlistResponse(rsp *http.Response) (*LeaveWaitlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LeaveWaitlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAcceptWaitlistOfferResponse parses an HTTP response from a AcceptWaitlistOfferWithResponse call
+func ParseAcceptWaitlistOfferResponse(rsp *http.Response) (*AcceptWaitlistOfferResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AcceptWaitlistOfferResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReservationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
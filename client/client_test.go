@@ -0,0 +1,113 @@
+/*
+	The generated client, against the room service.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	models "avaros/models"
+	repository "avaros/repository"
+	rest "avaros/rest"
+	router "avaros/router"
+)
+
+func TestReserveRoom(t *testing.T) {
+	ctx := context.Background()
+	server := setupServer()
+	defer server.Close()
+
+	c, err := NewClientWithResponses(server.URL, WithUserId(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	length := 60
+	title := "Standup"
+	rsp, err := c.ReserveRoomWithResponse(ctx, 1, ReserveRoomJSONRequestBody{ReservationLength: &length, Title: &title})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+	if rsp.JSON200 == nil || !rsp.JSON200.Result || rsp.JSON200.Ids == nil || len(*rsp.JSON200.Ids) != 1 {
+		t.Fatalf("Room 1 should have been reserved, got %d %s", rsp.StatusCode(), rsp.Body)
+	}
+
+	// the room is taken so the time after the reservation is suggested
+	rsp, err = c.ReserveRoomWithResponse(ctx, 1, ReserveRoomJSONRequestBody{ReservationLength: &length})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+	if rsp.JSON200 == nil || rsp.JSON200.Result || rsp.JSON200.Suggestions == nil ||
+		len(rsp.JSON200.Suggestions.SameRoom) == 0 {
+		t.Fatalf("Room 1 should already be reserved, got %d %s", rsp.StatusCode(), rsp.Body)
+	}
+
+	check, err := c.CheckReservationWithResponse(ctx, 1)
+	if err != nil {
+		t.Fatalf("Error checking a reservation: %s", err.Error())
+	}
+	if check.JSON200 == nil || !check.JSON200.Result {
+		t.Errorf("Reservation should exist, got %d %s", check.StatusCode(), check.Body)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	server := setupServer()
+	defer server.Close()
+
+	// only user 1 can use the service
+	c, err := NewClientWithResponses(server.URL, WithUserId(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsp, err := c.CheckReservationWithResponse(ctx, 1)
+	if err != nil {
+		t.Fatalf("Error checking a reservation: %s", err.Error())
+	}
+	if rsp.StatusCode() != http.StatusUnauthorized || rsp.JSON401 == nil || rsp.JSON401.Error == "" {
+		t.Errorf("User 2 should not be authorized, got %d %s", rsp.StatusCode(), rsp.Body)
+	}
+
+	// user 1 is not a delegate of user 2
+	c, err = NewClientWithResponses(server.URL, WithUserId(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	onBehalfOf := int32(2)
+	reserve, err := c.ReserveRoomWithResponse(ctx, 1, ReserveRoomJSONRequestBody{OnBehalfOf: &onBehalfOf})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+	if reserve.StatusCode() != http.StatusInternalServerError || reserve.JSONDefault == nil || reserve.JSONDefault.Error == "" {
+		t.Errorf("Reservation should not be made for someone who has not delegated to you, got %d %s",
+			reserve.StatusCode(), reserve.Body)
+	}
+}
+
+// setupServer serves the room service backed by an in memory repository
+func setupServer() *httptest.Server {
+	repo := repository.NewMemory()
+	repo.AddUser(models.User{Id: 1, Name: "Conor Downey", Role: "employee"})
+	repo.AddUser(models.User{Id: 2, Name: "Jane Doe", Role: "manager"})
+	repo.AddRoom(models.Room{Name: "Meeting Room", Building: "Head Office", Site: "Dublin"})
+
+	router := router.NewRouter()
+	roomService := rest.RoomService{RestObj: rest.RestServiceObject{
+		Router:       router,
+		Rooms:        repo,
+		Reservations: repo,
+	}}
+	err := roomService.Init()
+	if err != nil {
+		panic(err)
+	}
+
+	return httptest.NewServer(router)
+}
@@ -0,0 +1,531 @@
+/*
+	Loads the settings for the server from a YAML or TOML file, the environment and command line flags.
+*/
+
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// what secrets are replaced with when the config is printed
+const redacted = "********"
+
+// Config holds every setting for the server. Each setting can be set in the config file by its
+// yaml or toml key, in the environment by its env name and on the command line by its flag name. Flags
+// override the environment, which overrides the file, which overrides the defaults
+type Config struct {
+	ListenAddr string   `yaml:"listenAddr" toml:"listenAddr" env:"LISTEN_ADDR" flag:"listen" usage:"address to listen on"`
+	LogLevel   string   `yaml:"logLevel" toml:"logLevel" env:"LOG_LEVEL" flag:"log-level" usage:"debug, info, warn or error"`
+	LogFormat  string   `yaml:"logFormat" toml:"logFormat" env:"LOG_FORMAT" flag:"log-format" usage:"json, or text to read logs in a terminal"`
+	Database   Database `yaml:"database" toml:"database"`
+	SMTP       SMTP     `yaml:"smtp" toml:"smtp"`
+	Tracing    Tracing  `yaml:"tracing" toml:"tracing"`
+	// serve only the room service, which is all the SQLite database supports
+	RoomsOnly bool `yaml:"roomsOnly" toml:"roomsOnly" env:"ROOMS_ONLY" flag:"rooms-only" usage:"serve only the room service"`
+
+	// how long a request, and each call it makes to the database, can take before it is given up on
+	RequestTimeoutSeconds int `yaml:"requestTimeoutSeconds" toml:"requestTimeoutSeconds" env:"REQUEST_TIMEOUT_SECONDS" flag:"request-timeout" usage:"seconds a request can take, 0 for no limit"`
+	QueryTimeoutSeconds   int `yaml:"queryTimeoutSeconds" toml:"queryTimeoutSeconds" env:"QUERY_TIMEOUT_SECONDS" flag:"query-timeout" usage:"seconds a database call can take, 0 for no limit"`
+	// how long to wait for requests and background work to finish when shutting down
+	ShutdownTimeoutSeconds int `yaml:"shutdownTimeoutSeconds" toml:"shutdownTimeoutSeconds" env:"SHUTDOWN_TIMEOUT_SECONDS" flag:"shutdown-timeout" usage:"seconds to wait for work to finish when shutting down"`
+
+	// how long an approver has to answer a reservation request before it is rejected
+	ApprovalHours int `yaml:"approvalHours" toml:"approvalHours" env:"APPROVAL_HOURS" flag:"approval-hours" usage:"hours an approver has to answer a reservation request"`
+	// how long a hold on a room lasts before it is released if not confirmed
+	HoldSeconds int `yaml:"holdSeconds" toml:"holdSeconds" env:"HOLD_SECONDS" flag:"hold-seconds" usage:"seconds a hold on a room lasts"`
+	// how long someone on a waitlist has to accept a room once it is offered to them
+	WaitlistOfferMinutes int `yaml:"waitlistOfferMinutes" toml:"waitlistOfferMinutes" env:"WAITLIST_OFFER_MINUTES" flag:"waitlist-offer-minutes" usage:"minutes a waitlist offer lasts"`
+	// how long before a reservation starts its reminder is sent, never if 0
+	ReminderMinutes int `yaml:"reminderMinutes" toml:"reminderMinutes" env:"REMINDER_MINUTES" flag:"reminder-minutes" usage:"minutes before a reservation its reminder is sent, 0 for none"`
+	// how long after a reservation starts it is released if nobody has checked in, never if 0
+	NoShowMinutes int `yaml:"noShowMinutes" toml:"noShowMinutes" env:"NO_SHOW_MINUTES" flag:"no-show-minutes" usage:"minutes after a reservation starts it is released if nobody checked in, 0 for never"`
+	// how long reservation events are kept for clients that reconnect to replay what they missed
+	EventRetentionHours int `yaml:"eventRetentionHours" toml:"eventRetentionHours" env:"EVENT_RETENTION_HOURS" flag:"event-retention-hours" usage:"hours reservation events are kept to replay to clients that reconnect"`
+}
+
+// Database holds the settings for connecting to the database. A DSN, either a postgres:// url or
+// key=value pairs, overrides the host, port, user, password, name and sslmode
+type Database struct {
+	Driver      string `yaml:"driver" toml:"driver" env:"DB_DRIVER" flag:"db-driver" usage:"postgres, or sqlite to run on a single node"`
+	DSN         string `yaml:"dsn" toml:"dsn" env:"DB_DSN" flag:"db-dsn" usage:"full Postgres connection string" secret:"true"`
+	Host        string `yaml:"host" toml:"host" env:"DB_HOST" flag:"db-host" usage:"Postgres host"`
+	Port        int    `yaml:"port" toml:"port" env:"DB_PORT" flag:"db-port" usage:"Postgres port"`
+	User        string `yaml:"user" toml:"user" env:"DB_USER" flag:"db-user" usage:"Postgres user"`
+	Password    string `yaml:"password" toml:"password" env:"DB_PASSWORD" flag:"db-password" usage:"Postgres password" secret:"true"`
+	Name        string `yaml:"name" toml:"name" env:"DB_NAME" flag:"db-name" usage:"Postgres database name"`
+	SSLMode     string `yaml:"sslMode" toml:"sslMode" env:"DB_SSLMODE" flag:"db-sslmode" usage:"Postgres sslmode, disable, allow, prefer, require, verify-ca or verify-full"`
+	SSLRootCert string `yaml:"sslRootCert" toml:"sslRootCert" env:"DB_SSLROOTCERT" flag:"db-sslrootcert" usage:"CA certificate to verify the Postgres server with"`
+	MaxConns    int    `yaml:"maxConns" toml:"maxConns" env:"DB_MAX_CONNS" flag:"db-max-conns" usage:"most connections in the pool, 0 for the driver default"`
+	MinConns    int    `yaml:"minConns" toml:"minConns" env:"DB_MIN_CONNS" flag:"db-min-conns" usage:"connections the pool keeps open"`
+	Path        string `yaml:"path" toml:"path" env:"DB_PATH" flag:"db-path" usage:"SQLite database file"`
+}
+
+// SMTP holds the settings for the relay emails are sent through
+type SMTP struct {
+	Host     string `yaml:"host" toml:"host" env:"SMTP_HOST" flag:"smtp-host" usage:"SMTP relay host"`
+	Port     int    `yaml:"port" toml:"port" env:"SMTP_PORT" flag:"smtp-port" usage:"SMTP relay port"`
+	Username string `yaml:"username" toml:"username" env:"SMTP_USERNAME" flag:"smtp-username" usage:"SMTP username"`
+	Password string `yaml:"password" toml:"password" env:"SMTP_PASSWORD" flag:"smtp-password" usage:"SMTP password" secret:"true"`
+	From     string `yaml:"from" toml:"from" env:"SMTP_FROM" flag:"smtp-from" usage:"address emails are sent from"`
+}
+
+// Tracing holds where the spans for requests, queries and background jobs are sent
+type Tracing struct {
+	Exporter string `yaml:"exporter" toml:"exporter" env:"TRACE_EXPORTER" flag:"trace-exporter" usage:"none, stdout to print spans, or otlp to send them to a collector"`
+	Endpoint string `yaml:"endpoint" toml:"endpoint" env:"TRACE_ENDPOINT" flag:"trace-endpoint" usage:"url of the OTLP/HTTP collector spans are sent to"`
+}
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+var logFormats = []string{"json", "text"}
+
+var traceExporters = []string{"none", "stdout", "otlp"}
+
+// matches the password in a key=value connection string
+var dsnPassword = regexp.MustCompile(`(password=)('(\\'|[^'])*'|\S*)`)
+
+// Default returns the settings used when nothing else is supplied. They point at a local
+// Postgres and MailHog
+func Default() Config {
+	return Config{
+		ListenAddr: "localhost:3000",
+		LogLevel:   "info",
+		LogFormat:  "json",
+		Database: Database{
+			Driver:   "postgres",
+			Host:     "localhost",
+			Port:     5432,
+			User:     "postgres",
+			Password: "password",
+			Name:     "postgres",
+			SSLMode:  "disable",
+			Path:     "avaros.db",
+		},
+		SMTP: SMTP{
+			Host: "localhost",
+			Port: 1025,
+			From: "avaros@avaros.local",
+		},
+		Tracing: Tracing{
+			Exporter: "none",
+			Endpoint: "http://localhost:4318",
+		},
+		RequestTimeoutSeconds:  30,
+		QueryTimeoutSeconds:    10,
+		ShutdownTimeoutSeconds: 30,
+		ApprovalHours:          24,
+		HoldSeconds:            300,
+		WaitlistOfferMinutes:   15,
+		ReminderMinutes:        15,
+		EventRetentionHours:    24,
+	}
+}
+
+// Load builds the config from the defaults, the file named by the -config flag or CONFIG_FILE,
+// the environment and then the command line arguments supplied, and validates it
+func Load(args []string) (Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("avaros", flag.ContinueOnError)
+	file := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML file to read the config from")
+	// bool settings are bool flags so they can be passed on their own, like -rooms-only
+	flags := map[string]*string{}
+	boolFlags := map[string]*bool{}
+	err := walk(&cfg, func(field reflect.StructField, value reflect.Value) error {
+		name := field.Tag.Get("flag")
+		switch {
+		case name == "":
+		case value.Kind() == reflect.Bool:
+			boolFlags[name] = fs.Bool(name, false, field.Tag.Get("usage"))
+		default:
+			flags[name] = fs.String(name, "", field.Tag.Get("usage"))
+		}
+		return nil
+	})
+	if err != nil {
+		return cfg, err
+	}
+
+	err = fs.Parse(args)
+	if err != nil {
+		return cfg, err
+	}
+	if fs.NArg() > 0 {
+		return cfg, fmt.Errorf("Unexpected argument %s", fs.Arg(0))
+	}
+
+	if *file != "" {
+		err = LoadFile(&cfg, *file)
+		if err != nil {
+			return cfg, err
+		}
+	}
+
+	err = ApplyEnv(&cfg, "")
+	if err != nil {
+		return cfg, err
+	}
+
+	// only the flags that were actually passed override what has been loaded so far
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	err = walk(&cfg, func(field reflect.StructField, value reflect.Value) error {
+		name := field.Tag.Get("flag")
+		if !set[name] {
+			return nil
+		}
+		if boolFlags[name] != nil {
+			value.SetBool(*boolFlags[name])
+			return nil
+		}
+		return setField(value, *flags[name], "-"+name)
+	})
+	if err != nil {
+		return cfg, err
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// LoadFile reads the file at the path supplied over the config, as TOML if it ends in .toml
+// and YAML otherwise. Settings missing from the file are left as they are and unknown ones are
+// an error, so typos are not ignored
+func LoadFile(cfg *Config, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		meta, err := toml.NewDecoder(f).Decode(cfg)
+		if err != nil {
+			return fmt.Errorf("Error reading config file %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("Error reading config file %s: unknown setting %s", path, undecoded[0])
+		}
+		return nil
+	}
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	err = decoder.Decode(cfg)
+	if err != nil {
+		return fmt.Errorf("Error reading config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// ApplyEnv overrides the settings in v, a pointer to a Config or one of its sections, with any
+// environment variables that are set. The prefix is put in front of each variable's name, so
+// the tests can use TEST_DB_USER and so on for their own database
+func ApplyEnv(v interface{}, prefix string) error {
+	return walk(v, func(field reflect.StructField, value reflect.Value) error {
+		name := field.Tag.Get("env")
+		if name == "" {
+			return nil
+		}
+		env, ok := os.LookupEnv(prefix + name)
+		if !ok || env == "" {
+			return nil
+		}
+		return setField(value, env, prefix+name)
+	})
+}
+
+// Validate checks the settings make sense together
+func (c Config) Validate() error {
+	if c.ListenAddr == "" {
+		return errors.New("A listen address must be set")
+	}
+	if !oneOf(strings.ToLower(c.LogLevel), logLevels) {
+		return fmt.Errorf("Unknown log level %s, it must be one of %s", c.LogLevel, strings.Join(logLevels, ", "))
+	}
+	if !oneOf(strings.ToLower(c.LogFormat), logFormats) {
+		return fmt.Errorf("Unknown log format %s, it must be one of %s", c.LogFormat, strings.Join(logFormats, ", "))
+	}
+	if c.RequestTimeoutSeconds < 0 || c.QueryTimeoutSeconds < 0 {
+		return errors.New("Timeouts cannot be negative")
+	}
+	if c.ShutdownTimeoutSeconds <= 0 {
+		return errors.New("The shutdown timeout must be at least a second")
+	}
+	if c.ApprovalHours <= 0 {
+		return errors.New("The approval window must be at least an hour")
+	}
+	if c.HoldSeconds <= 0 {
+		return errors.New("Holds must last at least a second")
+	}
+	if c.WaitlistOfferMinutes <= 0 {
+		return errors.New("Waitlist offers must last at least a minute")
+	}
+	if c.ReminderMinutes < 0 {
+		return errors.New("Reminders cannot be sent after a reservation starts")
+	}
+	if c.NoShowMinutes < 0 {
+		return errors.New("No-shows cannot be released before a reservation starts")
+	}
+	if c.EventRetentionHours <= 0 {
+		return errors.New("Reservation events must be kept for at least an hour")
+	}
+	if c.SMTP.Port <= 0 || c.SMTP.Port > 65535 {
+		return fmt.Errorf("SMTP port %d is not valid", c.SMTP.Port)
+	}
+	if !oneOf(c.Tracing.Exporter, traceExporters) {
+		return fmt.Errorf("Unknown trace exporter %s, it must be one of %s", c.Tracing.Exporter, strings.Join(traceExporters, ", "))
+	}
+	if c.Tracing.Exporter == "otlp" && c.Tracing.Endpoint == "" {
+		return errors.New("An endpoint must be set to send traces to")
+	}
+
+	err := c.Database.Validate()
+	if err != nil {
+		return err
+	}
+
+	// nothing but the room service can use SQLite yet, so it is not started without saying that
+	// is all that is wanted rather than have the other services go missing
+	if c.Database.Driver == "sqlite" && !c.RoomsOnly {
+		return errors.New("SQLite only supports the room service, set roomsOnly to run with just it")
+	}
+
+	return nil
+}
+
+// Validate checks the database settings make sense for the driver chosen
+func (d Database) Validate() error {
+	switch d.Driver {
+	case "postgres":
+	case "sqlite":
+		if d.Path == "" {
+			return errors.New("A path must be set for the SQLite database")
+		}
+		return nil
+	default:
+		return fmt.Errorf("Unknown database driver %s, it must be postgres or sqlite", d.Driver)
+	}
+
+	if d.DSN == "" {
+		if d.Host == "" || d.User == "" || d.Name == "" {
+			return errors.New("A database host, user and name, or a DSN, must be set")
+		}
+		if d.Port <= 0 || d.Port > 65535 {
+			return fmt.Errorf("Database port %d is not valid", d.Port)
+		}
+		if !oneOf(d.SSLMode, sslModes) {
+			return fmt.Errorf("Unknown sslmode %s, it must be one of %s", d.SSLMode, strings.Join(sslModes, ", "))
+		}
+		if d.SSLRootCert != "" && d.SSLMode != "verify-ca" && d.SSLMode != "verify-full" {
+			return errors.New("A root certificate is only used with sslmode verify-ca or verify-full")
+		}
+	}
+
+	if d.MaxConns < 0 || d.MinConns < 0 {
+		return errors.New("Connection pool sizes cannot be negative")
+	}
+	if d.MaxConns > 0 && d.MinConns > d.MaxConns {
+		return fmt.Errorf("The pool cannot keep %d connections open with at most %d", d.MinConns, d.MaxConns)
+	}
+
+	return nil
+}
+
+// ConnString returns the Postgres connection string, the DSN if one is set or one built from
+// the other settings. Pool sizes are left for the caller to apply so they work with either
+func (d Database) ConnString() string {
+	if d.DSN != "" {
+		return d.DSN
+	}
+
+	conn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		quote(d.Host), d.Port, quote(d.User), quote(d.Password), quote(d.Name), d.SSLMode)
+	if d.SSLRootCert != "" {
+		conn += " sslrootcert=" + quote(d.SSLRootCert)
+	}
+
+	return conn
+}
+
+// Redacted returns a copy of the config with its secrets hidden so it can be logged
+func (c Config) Redacted() Config {
+	walk(&c, func(field reflect.StructField, value reflect.Value) error {
+		if field.Tag.Get("secret") != "true" || value.String() == "" {
+			return nil
+		}
+		if field.Name == "DSN" {
+			value.SetString(redactDSN(value.String()))
+		} else {
+			value.SetString(redacted)
+		}
+		return nil
+	})
+
+	return c
+}
+
+// String prints the config with its secrets hidden
+func (c Config) String() string {
+	b, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(b)
+}
+
+// RequestTimeout is how long a request can take before it is given up on
+func (c Config) RequestTimeout() time.Duration {
+	return time.Second * time.Duration(c.RequestTimeoutSeconds)
+}
+
+// QueryTimeout is how long each call to the database can take before it is given up on
+func (c Config) QueryTimeout() time.Duration {
+	return time.Second * time.Duration(c.QueryTimeoutSeconds)
+}
+
+// ShutdownTimeout is how long to wait for requests and background work to finish when shutting down
+func (c Config) ShutdownTimeout() time.Duration {
+	return time.Second * time.Duration(c.ShutdownTimeoutSeconds)
+}
+
+// ApprovalWindow is how long an approver has to answer a reservation request
+func (c Config) ApprovalWindow() time.Duration {
+	return time.Hour * time.Duration(c.ApprovalHours)
+}
+
+// HoldLength is how long a hold on a room lasts before it is released
+func (c Config) HoldLength() time.Duration {
+	return time.Second * time.Duration(c.HoldSeconds)
+}
+
+// WaitlistOfferWindow is how long someone on a waitlist has to accept an offer
+func (c Config) WaitlistOfferWindow() time.Duration {
+	return time.Minute * time.Duration(c.WaitlistOfferMinutes)
+}
+
+// ReminderBefore is how long before a reservation starts its reminder is sent
+func (c Config) ReminderBefore() time.Duration {
+	return time.Minute * time.Duration(c.ReminderMinutes)
+}
+
+// NoShowAfter is how long after a reservation starts it is released if nobody has checked in
+func (c Config) NoShowAfter() time.Duration {
+	return time.Minute * time.Duration(c.NoShowMinutes)
+}
+
+// EventRetention is how long reservation events are kept for clients that reconnect to replay
+func (c Config) EventRetention() time.Duration {
+	return time.Hour * time.Duration(c.EventRetentionHours)
+}
+
+// redactDSN hides the password in a url or key=value connection string
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+		query := u.Query()
+		if query.Get("password") != "" {
+			query.Set("password", redacted)
+			u.RawQuery = query.Encode()
+		}
+		return u.String()
+	}
+
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+redacted)
+}
+
+// quote quotes a value for a key=value connection string if it is empty or has spaces or quotes
+func quote(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+// oneOf checks the value is one of those supplied
+func oneOf(value string, valid []string) bool {
+	for _, v := range valid {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// walk calls fn for every setting in v, a pointer to a struct, going into nested sections
+func walk(v interface{}, fn func(reflect.StructField, reflect.Value) error) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return errors.New("Config must be a pointer to a struct")
+	}
+
+	return walkStruct(value.Elem(), fn)
+}
+
+func walkStruct(value reflect.Value, fn func(reflect.StructField, reflect.Value) error) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			err := walkStruct(value.Field(i), fn)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err := fn(field, value.Field(i))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setField parses the string supplied into a setting, naming where it came from if it is not valid
+func setField(value reflect.Value, s string, source string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(s)
+	case reflect.Int:
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("Error parsing %s: %w", source, err)
+		}
+		value.SetInt(int64(i))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("Error parsing %s: %w", source, err)
+		}
+		value.SetBool(b)
+	default:
+		return fmt.Errorf("Cannot set %s, %s settings are not supported", source, value.Kind())
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "avaros.yaml")
+	err := os.WriteFile(path, []byte(`
+listenAddr: 0.0.0.0:9000
+holdSeconds: 60
+database:
+  host: file-host
+  port: 6543
+  user: file-user
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("DB_USER", "env-user")
+	os.Setenv("HOLD_SECONDS", "120")
+	os.Setenv("ROOMS_ONLY", "true")
+	defer os.Unsetenv("DB_USER")
+	defer os.Unsetenv("HOLD_SECONDS")
+	defer os.Unsetenv("ROOMS_ONLY")
+
+	cfg, err := Load([]string{"-config", path, "-hold-seconds", "180"})
+	if err != nil {
+		t.Fatalf("Error loading config: %s", err.Error())
+	}
+
+	// the file overrides the defaults
+	if cfg.ListenAddr != "0.0.0.0:9000" || cfg.Database.Host != "file-host" || cfg.Database.Port != 6543 {
+		t.Errorf("Settings should come from the file, got %v", cfg)
+	}
+
+	// the environment overrides the file, and flags override the environment
+	if cfg.Database.User != "env-user" || !cfg.RoomsOnly {
+		t.Errorf("The user and rooms only should come from the environment, got %v", cfg)
+	}
+	if cfg.HoldSeconds != 180 {
+		t.Errorf("Holds should last the 180 seconds passed as a flag, got %d", cfg.HoldSeconds)
+	}
+
+	// anything not set anywhere keeps its default
+	if cfg.Database.Name != "postgres" || cfg.ApprovalHours != 24 {
+		t.Errorf("Unset settings should keep their defaults, got %v", cfg)
+	}
+}
+
+func TestLoadBoolFlag(t *testing.T) {
+	// sqlite needs rooms only, so it fails to validate without it
+	cfg, err := Load([]string{"-db-driver", "sqlite", "-rooms-only"})
+	if err != nil {
+		t.Fatalf("Error loading config: %s", err.Error())
+	}
+	if !cfg.RoomsOnly {
+		t.Errorf("Passing -rooms-only on its own should turn it on")
+	}
+
+	os.Setenv("ROOMS_ONLY", "true")
+	defer os.Unsetenv("ROOMS_ONLY")
+
+	cfg, err = Load([]string{"-rooms-only=false"})
+	if err != nil {
+		t.Fatalf("Error loading config: %s", err.Error())
+	}
+	if cfg.RoomsOnly {
+		t.Errorf("-rooms-only=false should override the environment")
+	}
+}
+
+func TestLoadUnknownSetting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "avaros.yaml")
+	err := os.WriteFile(path, []byte("database:\n  hots: localhost\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Load([]string{"-config", path})
+	if err == nil {
+		t.Errorf("A misspelt setting should not be ignored")
+	}
+
+	_, err = Load([]string{"-rooms-only=maybe"})
+	if err == nil || !strings.Contains(err.Error(), "-rooms-only") {
+		t.Errorf("A setting that is not true or false should name the flag, got %v", err)
+	}
+
+	_, err = Load([]string{"-rooms-only", "maybe"})
+	if err == nil || !strings.Contains(err.Error(), "maybe") {
+		t.Errorf("An argument that is not a flag should not be ignored, got %v", err)
+	}
+
+	_, err = Load([]string{"-hold-seconds", "soon"})
+	if err == nil || !strings.Contains(err.Error(), "-hold-seconds") {
+		t.Errorf("A setting that is not a number should name the flag, got %v", err)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	// the example files have the same settings, one in each format
+	fromYAML, fromTOML := Default(), Default()
+	err := LoadFile(&fromYAML, "../config.example.yaml")
+	if err != nil {
+		t.Fatalf("Error loading the YAML example: %s", err.Error())
+	}
+	err = LoadFile(&fromTOML, "../config.example.toml")
+	if err != nil {
+		t.Fatalf("Error loading the TOML example: %s", err.Error())
+	}
+
+	if fromTOML != fromYAML {
+		t.Errorf("The TOML example should load the same as the YAML one, got %v and %v", fromTOML, fromYAML)
+	}
+	if fromTOML.ListenAddr != "0.0.0.0:8080" || fromTOML.Database.SSLMode != "disable" {
+		t.Errorf("Settings should come from the file, got %v", fromTOML)
+	}
+
+	path := filepath.Join(t.TempDir(), "avaros.toml")
+	err = os.WriteFile(path, []byte("[database]\nhots = \"localhost\"\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Default()
+	err = LoadFile(&cfg, path)
+	if err == nil || !strings.Contains(err.Error(), "database.hots") {
+		t.Errorf("A misspelt setting should not be ignored, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for name, change := range map[string]func(*Config){
+		"unknown driver":        func(c *Config) { c.Database.Driver = "mysql" },
+		"unknown sslmode":       func(c *Config) { c.Database.SSLMode = "always" },
+		"port out of range":     func(c *Config) { c.Database.Port = 70000 },
+		"root cert unverified":  func(c *Config) { c.Database.SSLRootCert = "ca.pem" },
+		"min over max conns":    func(c *Config) { c.Database.MaxConns = 2; c.Database.MinConns = 5 },
+		"no sqlite path":        func(c *Config) { c.Database.Driver, c.RoomsOnly, c.Database.Path = "sqlite", true, "" },
+		"sqlite every service":  func(c *Config) { c.Database.Driver = "sqlite" },
+		"no hold":               func(c *Config) { c.HoldSeconds = 0 },
+		"negative timeout":      func(c *Config) { c.QueryTimeoutSeconds = -1 },
+		"no shutdown timeout":   func(c *Config) { c.ShutdownTimeoutSeconds = 0 },
+		"unknown log level":     func(c *Config) { c.LogLevel = "verbose" },
+		"unknown log format":    func(c *Config) { c.LogFormat = "xml" },
+		"unknown exporter":      func(c *Config) { c.Tracing.Exporter = "jaeger" },
+		"otlp without endpoint": func(c *Config) { c.Tracing.Exporter, c.Tracing.Endpoint = "otlp", "" },
+	} {
+		cfg := Default()
+		change(&cfg)
+		if cfg.Validate() == nil {
+			t.Errorf("Config with %s should not be valid", name)
+		}
+	}
+
+	cfg := Default()
+	cfg.Database.SSLMode = "verify-full"
+	cfg.Database.SSLRootCert = "ca.pem"
+	cfg.Database.MaxConns = 10
+	cfg.Database.MinConns = 2
+	err := cfg.Validate()
+	if err != nil {
+		t.Errorf("Config should be valid, got %s", err.Error())
+	}
+
+	// SQLite is only used for the room service
+	cfg = Default()
+	cfg.Database.Driver = "sqlite"
+	cfg.RoomsOnly = true
+	err = cfg.Validate()
+	if err != nil {
+		t.Errorf("Config for the room service on SQLite should be valid, got %s", err.Error())
+	}
+
+	// a DSN replaces the separate connection settings
+	cfg = Default()
+	cfg.Database.DSN = "postgres://avaros@db/avaros"
+	cfg.Database.Host = ""
+	cfg.Database.SSLMode = ""
+	err = cfg.Validate()
+	if err != nil {
+		t.Errorf("Config with only a DSN should be valid, got %s", err.Error())
+	}
+}
+
+func TestConnString(t *testing.T) {
+	db := Default().Database
+	db.Password = "it's secret"
+	db.SSLMode = "verify-ca"
+	db.SSLRootCert = "/etc/ssl/ca.pem"
+
+	expected := `host=localhost port=5432 user=postgres password='it\'s secret' dbname=postgres ` +
+		`sslmode=verify-ca sslrootcert=/etc/ssl/ca.pem`
+	if db.ConnString() != expected {
+		t.Errorf("Connection string should be %s, got %s", expected, db.ConnString())
+	}
+
+	db.DSN = "postgres://avaros:secret@db:5432/avaros?sslmode=require"
+	if db.ConnString() != db.DSN {
+		t.Errorf("The DSN should be used as it is, got %s", db.ConnString())
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Password = "db-secret"
+	cfg.SMTP.Password = "smtp-secret"
+
+	for _, dsn := range []string{
+		"postgres://avaros:dsn-secret@db:5432/avaros?sslmode=require",
+		"postgres://avaros@db/avaros?password=dsn-secret",
+		"host=db user=avaros password=dsn-secret dbname=avaros",
+		"host=db user=avaros password='dsn secret' dbname=avaros",
+	} {
+		cfg.Database.DSN = dsn
+		printed := cfg.String()
+
+		for _, secret := range []string{"db-secret", "smtp-secret", "dsn-secret", "dsn secret"} {
+			if strings.Contains(printed, secret) {
+				t.Errorf("%s should not be printed, got %s", secret, printed)
+			}
+		}
+		if !strings.Contains(printed, "avaros") {
+			t.Errorf("Everything but the password should be printed, got %s", printed)
+		}
+	}
+
+	// redacting returns a copy, the config itself still has its secrets
+	if cfg.Database.Password != "db-secret" {
+		t.Errorf("Redacting should not change the config, got %s", cfg.Database.Password)
+	}
+}
@@ -0,0 +1,204 @@
+/*
+	Class that holds the data access functions for approving reservations.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"avaros/metrics"
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// how long an approver has to answer a request before it is rejected
+var approvalWindow = 24 * time.Hour
+
+// SetApprovalWindow sets how long an approver has to answer a request before it is
+// rejected. Should be called once at startup
+func SetApprovalWindow(window time.Duration) {
+	approvalWindow = window
+}
+
+// ErrNoApprover is returned when a room needs approval but has no approver, so a reservation
+// of it could never be approved
+var ErrNoApprover = errors.New("The room needs approval but has no approver")
+
+// applyApproval makes a reservation pending if its room needs approval, assigning it to
+// the room's approver. The request expires when the window runs out or the reservation
+// starts, whichever is first. Returns ErrNoApprover if the room has no approver
+func applyApproval(ctx context.Context, q querier, reservation *models.Reservation) error {
+	var requiresApproval bool
+	var approverId *int32
+	err := q.QueryRow(ctx, `
+		SELECT
+			requires_approval, approver_id
+		FROM
+			room
+		WHERE
+			id = $1
+	`, reservation.RoomId).Scan(&requiresApproval, &approverId)
+	if err != nil {
+		return err
+	}
+
+	if !requiresApproval {
+		return nil
+	}
+	if approverId == nil {
+		return ErrNoApprover
+	}
+
+	approvalExpires := time.Now().Add(approvalWindow)
+	if reservation.StartTime.After(time.Now()) && reservation.StartTime.Before(approvalExpires) {
+		approvalExpires = reservation.StartTime
+	}
+
+	reservation.Status = models.ReservationPending
+	reservation.ApproverId = approverId
+	reservation.ApprovalExpires = &approvalExpires
+	return nil
+}
+
+// GetPendingApprovals gets the reservations waiting on an approver
+func GetPendingApprovals(ctx context.Context, approverId int32, db *pgxpool.Pool) ([]models.Reservation, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			`+reservationColumns+`
+		FROM
+			reservation
+		WHERE
+			approver_id = $1
+		AND
+			status = 'pending'
+		AND
+			approval_expires > NOW()
+		ORDER BY approval_expires
+	`, approverId)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanReservations(rows)
+}
+
+// ApproveReservation confirms a pending reservation. Returns false if the reservation
+// is not waiting on the approver supplied
+func ApproveReservation(ctx context.Context, reservationId int32, approverId int32, reason string, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		UPDATE reservation
+		SET status = 'confirmed', decision_reason = $3
+		WHERE id = $1
+		AND approver_id = $2
+		AND status = 'pending'
+		AND approval_expires > NOW()
+		RETURNING `+reservationColumns+`
+	`, reservationId, approverId, reason)
+	if err != nil {
+		return false, err
+	}
+
+	approved, err := scanReservations(rows)
+	if err != nil {
+		return false, err
+	}
+
+	for _, reservation := range approved {
+		reservationCreated(ctx, reservation, db)
+	}
+
+	return len(approved) > 0, nil
+}
+
+// RejectReservation rejects a pending reservation, freeing up the time it was blocking.
+// Returns false if the reservation is not waiting on the approver supplied
+func RejectReservation(ctx context.Context, reservationId int32, approverId int32, reason string, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		UPDATE reservation
+		SET status = 'rejected', expired = true, decision_reason = $3
+		WHERE id = $1
+		AND approver_id = $2
+		AND status = 'pending'
+		RETURNING `+reservationColumns+`
+	`, reservationId, approverId, reason)
+	if err != nil {
+		return false, err
+	}
+
+	rejected, err := scanReservations(rows)
+	if err != nil {
+		return false, err
+	}
+
+	reservationsRejected(ctx, rejected, db)
+	return len(rejected) > 0, nil
+}
+
+// ExpireApprovalRequests rejects every request that was not answered in time. Run by the
+// scheduler so requests are expired even if the instance that made them has gone away
+func ExpireApprovalRequests(ctx context.Context, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		UPDATE reservation
+		SET status = 'rejected', expired = true, decision_reason = 'Not answered in time'
+		WHERE status = 'pending'
+		AND approval_expires <= NOW()
+		RETURNING `+reservationColumns+`
+	`)
+	if err != nil {
+		return err
+	}
+
+	rejected, err := scanReservations(rows)
+	if err != nil {
+		return err
+	}
+
+	reservationsRejected(ctx, rejected, db)
+	return nil
+}
+
+// reservationsRejected lets the users know their requests were rejected and gives the
+// time they were blocking to anyone waiting on it
+func reservationsRejected(ctx context.Context, rejected []models.Reservation, db *pgxpool.Pool) {
+	rooms := map[int32]bool{}
+	for _, reservation := range rejected {
+		metrics.Bookings.WithLabelValues(metrics.BookingRejected).Inc()
+		notifier.ReservationRejected(ctx, reservation)
+		if !rooms[reservation.RoomId] {
+			rooms[reservation.RoomId] = true
+			roomId := reservation.RoomId
+			background(ctx, "process waitlist", func(ctx context.Context) { ProcessWaitlist(ctx, roomId, db) })
+		}
+	}
+}
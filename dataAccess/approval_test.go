@@ -0,0 +1,110 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestApproveReservation(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	// room 4 is the boardroom, which Jane (user 2) has to approve
+	ids, conflicts, err := ReserveRooms(ctx, []int32{4}, 1, 1, time.Now(), 0, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Fatalf("Error reserving room: %s", err.Error())
+	}
+
+	if len(conflicts) > 0 || len(ids) != 1 {
+		t.Fatalf("Room 4 should have been requested")
+	}
+
+	reservationExists, err := CheckReservation(ctx, 4, db)
+	if err != nil {
+		t.Errorf("Error checking a reservation: %s", err.Error())
+	}
+
+	if !reservationExists {
+		t.Errorf("A pending request should block the room")
+	}
+
+	pending, err := GetPendingApprovals(ctx, 2, db)
+	if err != nil {
+		t.Errorf("Error getting pending approvals: %s", err.Error())
+	}
+
+	if len(pending) != 1 || pending[0].Id != ids[0] {
+		t.Errorf("Request should be waiting on user 2")
+	}
+
+	approved, err := ApproveReservation(ctx, ids[0], 3, "", db)
+	if err != nil {
+		t.Errorf("Error approving reservation: %s", err.Error())
+	}
+
+	if approved {
+		t.Errorf("Only the room's approver should be able to approve a request")
+	}
+
+	approved, err = ApproveReservation(ctx, ids[0], 2, "", db)
+	if err != nil {
+		t.Errorf("Error approving reservation: %s", err.Error())
+	}
+
+	if !approved {
+		t.Errorf("Request should have been approved")
+	}
+}
+
+func TestRejectReservation(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	ids, _, err := ReserveRooms(ctx, []int32{4}, 1, 1, time.Now(), 0, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Fatalf("Error reserving room: %s", err.Error())
+	}
+
+	rejected, err := RejectReservation(ctx, ids[0], 2, "Room is being cleaned", db)
+	if err != nil {
+		t.Errorf("Error rejecting reservation: %s", err.Error())
+	}
+
+	if !rejected {
+		t.Errorf("Request should have been rejected")
+	}
+
+	reservationExists, err := CheckReservation(ctx, 4, db)
+	if err != nil {
+		t.Errorf("Error checking a reservation: %s", err.Error())
+	}
+
+	if reservationExists {
+		t.Errorf("A rejected request should not block the room")
+	}
+}
+
+func TestApproverRequired(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	// a pending request for the boardroom could never be approved without Jane
+	_, err := db.Exec(ctx, `UPDATE room SET approver_id = NULL WHERE id = 4`)
+	if err == nil {
+		t.Errorf("A room that needs approval should not be left without an approver")
+	}
+}
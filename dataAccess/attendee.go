@@ -0,0 +1,288 @@
+/*
+	Class that holds the data access functions for the people invited to reservations.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// RoomFits checks if a room is big enough for the number of people supplied
+func RoomFits(ctx context.Context, roomId int32, people int, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	return roomFits(ctx, db, roomId, people)
+}
+
+// roomFits checks a room's capacity. Rooms without one fit any number of people
+func roomFits(ctx context.Context, q querier, roomId int32, people int) (bool, error) {
+	var fits bool
+	err := q.QueryRow(ctx, `
+		SELECT
+			capacity IS NULL OR capacity >= $2
+		FROM
+			room
+		WHERE
+			id = $1
+	`, roomId, people).Scan(&fits)
+
+	return fits, err
+}
+
+// GetAttendees gets the people invited to a reservation
+func GetAttendees(ctx context.Context, reservationId int32, db *pgxpool.Pool) ([]models.Attendee, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			id, reservation_id, user_id, COALESCE(email, ''), rsvp
+		FROM
+			attendee
+		WHERE
+			reservation_id = $1
+		ORDER BY id
+	`, reservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanAttendees(rows)
+}
+
+// AddAttendees invites more people to a reservation. Anyone already invited is left as they
+// were. Returns false, and invites no one, if the room is not big enough for everyone
+func AddAttendees(ctx context.Context, reservationId int32, attendees []models.Attendee, db *pgxpool.Pool) ([]models.Attendee, bool, error) {
+	if db == nil {
+		return nil, false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	// lock the reservation so the attendees are counted one request at a time
+	var roomId int32
+	err = tx.QueryRow(ctx, `
+		SELECT
+			room_id
+		FROM
+			reservation
+		WHERE
+			id = $1
+		FOR UPDATE
+	`, reservationId).Scan(&roomId)
+	if err != nil {
+		return nil, false, err
+	}
+
+	added, err := insertAttendees(ctx, tx, reservationId, attendees)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the organizer needs room too
+	var people int
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*) + 1 FROM attendee WHERE reservation_id = $1
+	`, reservationId).Scan(&people)
+	if err != nil {
+		return nil, false, err
+	}
+
+	fits, err := roomFits(ctx, tx, roomId, people)
+	if err != nil || !fits {
+		return nil, false, err
+	}
+
+	return added, true, tx.Commit(ctx)
+}
+
+// RemoveAttendee withdraws someone's invitation to a reservation. Returns false if they
+// were not invited to it
+func RemoveAttendee(ctx context.Context, reservationId int32, attendeeId int32, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tag, err := db.Exec(ctx, `
+		DELETE
+		FROM
+			attendee
+		WHERE
+			id = $2
+		AND
+			reservation_id = $1
+	`, reservationId, attendeeId)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// SetRsvp records a user's answer to their invitation to a reservation. Returns false if
+// they were not invited to it
+func SetRsvp(ctx context.Context, reservationId int32, userId int32, rsvp string, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tag, err := db.Exec(ctx, `
+		UPDATE attendee
+		SET rsvp = $3
+		WHERE reservation_id = $1
+		AND user_id = $2
+	`, reservationId, userId, rsvp)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetInvitations gets the reservations a user is invited to that have not ended or been
+// rejected, soonest first
+func GetInvitations(ctx context.Context, userId int32, db *pgxpool.Pool) ([]models.Reservation, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			`+reservationColumns+`
+		FROM
+			reservation
+		WHERE
+			expired = false
+		AND
+			status IN ('confirmed', 'pending')
+		AND
+			id IN (SELECT reservation_id FROM attendee WHERE user_id = $1)
+		ORDER BY start_time
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := scanReservations(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadAttendees(ctx, db, invitations)
+	return invitations, err
+}
+
+// insertAttendees invites people to a reservation as part of a transaction, skipping
+// anyone already invited. Returns the attendees that were added
+func insertAttendees(ctx context.Context, q querier, reservationId int32, attendees []models.Attendee) ([]models.Attendee, error) {
+	added := []models.Attendee{}
+	for _, attendee := range attendees {
+		attendee.ReservationId = reservationId
+		err := q.QueryRow(ctx, `
+			INSERT INTO
+				attendee (reservation_id, user_id, email)
+			VALUES
+				($1, $2, NULLIF($3, ''))
+			ON CONFLICT DO NOTHING
+			RETURNING id, rsvp
+		`, reservationId, attendee.UserId, attendee.Email).Scan(&attendee.Id, &attendee.Rsvp)
+		if err == pgx.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, attendee)
+	}
+
+	return added, nil
+}
+
+// loadAttendees fills in who is invited to each of the reservations supplied
+func loadAttendees(ctx context.Context, q querier, reservations []models.Reservation) error {
+	if len(reservations) == 0 {
+		return nil
+	}
+
+	ids := []int32{}
+	for _, reservation := range reservations {
+		ids = append(ids, reservation.Id)
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT
+			id, reservation_id, user_id, COALESCE(email, ''), rsvp
+		FROM
+			attendee
+		WHERE
+			reservation_id = ANY($1)
+		ORDER BY id
+	`, ids)
+	if err != nil {
+		return err
+	}
+
+	attendees, err := scanAttendees(rows)
+	if err != nil {
+		return err
+	}
+
+	for i := range reservations {
+		for _, attendee := range attendees {
+			if attendee.ReservationId == reservations[i].Id {
+				reservations[i].Attendees = append(reservations[i].Attendees, attendee)
+			}
+		}
+	}
+
+	return nil
+}
+
+// scanAttendees reads attendee rows and closes them
+func scanAttendees(rows pgx.Rows) ([]models.Attendee, error) {
+	defer rows.Close()
+
+	attendees := []models.Attendee{}
+	for rows.Next() {
+		var attendee models.Attendee
+		err := rows.Scan(&attendee.Id, &attendee.ReservationId, &attendee.UserId,
+			&attendee.Email, &attendee.Rsvp)
+		if err != nil {
+			return nil, err
+		}
+		attendees = append(attendees, attendee)
+	}
+
+	return attendees, rows.Err()
+}
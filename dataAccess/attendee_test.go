@@ -0,0 +1,75 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestAttendees(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	jane := int32(2)
+	details := models.ReservationDetails{
+		Title: "Planning",
+		Attendees: []models.Attendee{
+			{UserId: &jane},
+			{Email: "guest@example.com"},
+		},
+	}
+
+	// room 1 fits 8 people
+	reservationId, err := Reserve(ctx, 1, 1, 1, 0, details, db)
+	if err != nil {
+		t.Fatalf("Error reserving room: %s", err.Error())
+	}
+
+	extra := []models.Attendee{}
+	for i := 0; i < 6; i++ {
+		extra = append(extra, models.Attendee{Email: fmt.Sprintf("guest%d@example.com", i)})
+	}
+
+	_, fits, err := AddAttendees(ctx, reservationId, extra, db)
+	if err != nil {
+		t.Errorf("Error adding attendees: %s", err.Error())
+	}
+
+	if fits {
+		t.Errorf("Room 1 should not fit 9 people")
+	}
+
+	_, fits, err = AddAttendees(ctx, reservationId, extra[:5], db)
+	if err != nil {
+		t.Errorf("Error adding attendees: %s", err.Error())
+	}
+
+	if !fits {
+		t.Errorf("Room 1 should fit 8 people")
+	}
+
+	answered, err := SetRsvp(ctx, reservationId, jane, models.RsvpAccepted, db)
+	if err != nil {
+		t.Errorf("Error answering invitation: %s", err.Error())
+	}
+
+	if !answered {
+		t.Errorf("User 2 should be able to answer their invitation")
+	}
+
+	invitations, err := GetInvitations(ctx, jane, db)
+	if err != nil {
+		t.Errorf("Error getting invitations: %s", err.Error())
+	}
+
+	if len(invitations) != 1 || invitations[0].Title != "Planning" || len(invitations[0].Attendees) != 7 {
+		t.Errorf("User 2 should be invited to the planning reservation with 7 attendees")
+	}
+}
@@ -0,0 +1,86 @@
+/*
+	Keeps track of the threads the data access functions leave running in the background.
+*/
+package dataAccess
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"avaros/tracing"
+)
+
+// the threads left running once a call returns, like those that expire reservations
+var workers sync.WaitGroup
+
+// cancelled when shutting down so threads waiting on a timer give up rather than run
+var stopping, stopWorkers = context.WithCancel(context.Background())
+
+// background runs fn in a thread that Shutdown waits on. fn is given a context that keeps
+// the request id of the one supplied, so what it logs can be traced to the request that
+// started it, but that is not cancelled when the request finishes. It gets a trace of its
+// own, named after the job, linked to the request's
+func background(ctx context.Context, name string, fn func(ctx context.Context)) {
+	backgroundAt(ctx, name, time.Now(), fn)
+}
+
+// backgroundAt is background for a job that runs at the time supplied, unless shutting down
+// first. Its trace starts once the time comes rather than covering the wait. A job that
+// panics is logged rather than taking the server down with it
+func backgroundAt(ctx context.Context, name string, at time.Time, fn func(ctx context.Context)) {
+	ctx = context.WithoutCancel(ctx)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		// anything already due runs even if shutting down, as it did not have to wait
+		if at.After(time.Now()) && !waitUntil(at) {
+			return
+		}
+
+		ctx, span := tracing.Detach(ctx, name)
+		defer span.End()
+		defer func() {
+			if err := recover(); err != nil {
+				tracing.Fail(span, err)
+				slog.ErrorContext(ctx, "Error running background job", "job", name, "error", err)
+			}
+		}()
+		fn(ctx)
+	}()
+}
+
+// waitUntil waits for the time supplied to come. Returns false if shutting down first, in
+// which case whatever was waiting should not run
+func waitUntil(t time.Time) bool {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-stopping.Done():
+		return false
+	}
+}
+
+// Shutdown stops the background threads that are waiting on a timer and waits for any that
+// are running to finish, or for the context to be done. Should only be called once nothing
+// else will call the data access functions
+func Shutdown(ctx context.Context) error {
+	stopWorkers()
+
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,30 @@
+package dataAccess
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestBackgroundPanic(t *testing.T) {
+	// what the job logs is read back to know it has been recovered
+	reader, writer := io.Pipe()
+	defer reader.Close()
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(writer, nil)))
+
+	background(context.Background(), "panics", func(ctx context.Context) {
+		panic("Error reserving room: connection refused")
+	})
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		if strings.Contains(scanner.Text(), "Error running background job") {
+			return
+		}
+	}
+	t.Errorf("A job that panics should be logged")
+}
@@ -0,0 +1,274 @@
+/*
+	Class that holds the data access functions for the business hours and holidays of sites.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"avaros/calendar"
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// GetBusinessHours gets the times a site is open each week
+func GetBusinessHours(ctx context.Context, site string, db *pgxpool.Pool) ([]models.BusinessHours, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	return businessHours(ctx, db, site)
+}
+
+func businessHours(ctx context.Context, q querier, site string) ([]models.BusinessHours, error) {
+	rows, err := q.Query(ctx, `
+		SELECT
+			weekday, to_char(opens, 'HH24:MI'), to_char(closes, 'HH24:MI')
+		FROM
+			business_hours
+		WHERE
+			site = $1
+		ORDER BY weekday, opens
+	`, site)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hours := []models.BusinessHours{}
+	for rows.Next() {
+		var open models.BusinessHours
+		var weekday int
+		err = rows.Scan(&weekday, &open.Opens, &open.Closes)
+		if err != nil {
+			return nil, err
+		}
+		open.Weekday = time.Weekday(weekday)
+		hours = append(hours, open)
+	}
+
+	return hours, rows.Err()
+}
+
+// SetBusinessHours replaces the times a site is open each week. A site with no business
+// hours is open all day
+func SetBusinessHours(ctx context.Context, site string, hours []models.BusinessHours, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM business_hours WHERE site = $1
+	`, site)
+	if err != nil {
+		return err
+	}
+
+	for _, open := range hours {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO
+				business_hours (site, weekday, opens, closes)
+			VALUES
+				($1, $2, $3::time, $4::time)
+		`, site, int(open.Weekday), open.Opens, open.Closes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetHolidays gets a site's holidays from the day supplied on
+func GetHolidays(ctx context.Context, site string, from time.Time, db *pgxpool.Pool) ([]models.Holiday, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	return holidays(ctx, db, site, from, nil)
+}
+
+// holidays gets a site's holidays on the days between from and to. There is no limit
+// on the last day if to is empty
+func holidays(ctx context.Context, q querier, site string, from time.Time, to *time.Time) ([]models.Holiday, error) {
+	rows, err := q.Query(ctx, `
+		SELECT
+			id, site, date, COALESCE(name, '')
+		FROM
+			holiday
+		WHERE
+			site = $1
+		AND
+			date >= $2::date
+		AND
+			($3::date IS NULL OR date <= $3::date)
+		ORDER BY date
+	`, site, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := []models.Holiday{}
+	for rows.Next() {
+		var holiday models.Holiday
+		err = rows.Scan(&holiday.Id, &holiday.Site, &holiday.Date, &holiday.Name)
+		if err != nil {
+			return nil, err
+		}
+		// dates come back as midnight UTC, the day is what matters
+		holiday.Date = time.Date(holiday.Date.Year(), holiday.Date.Month(), holiday.Date.Day(), 0, 0, 0, 0, time.Local)
+		days = append(days, holiday)
+	}
+
+	return days, rows.Err()
+}
+
+// AddHolidays adds holidays to a site's calendar. A day that is already a holiday has its
+// name updated
+func AddHolidays(ctx context.Context, site string, days []models.Holiday, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, holiday := range days {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO
+				holiday (site, date, name)
+			VALUES
+				($1, $2::date, $3)
+			ON CONFLICT (site, date) DO UPDATE SET name = EXCLUDED.name
+		`, site, holiday.Date, holiday.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteHoliday removes a holiday from a site's calendar. Returns false if it does not exist
+func DeleteHoliday(ctx context.Context, site string, id int32, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tag, err := db.Exec(ctx, `
+		DELETE
+		FROM
+			holiday
+		WHERE
+			id = $2
+		AND
+			site = $1
+	`, site, id)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// CheckOpen checks if the site a room is on is open for the whole of the time supplied.
+// Rooms that are not on a site, or do not exist, are always open
+func CheckOpen(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	hours, days, err := roomCalendar(ctx, db, roomId, startTime, endTime)
+	if err != nil {
+		return false, err
+	}
+
+	return calendar.IsOpen(hours, days, startTime, endTime), nil
+}
+
+// GetOpenPeriods gets the times between from and to the site a room is on is open
+func GetOpenPeriods(ctx context.Context, roomId int32, from time.Time, to time.Time, db *pgxpool.Pool) ([]calendar.Period, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	hours, days, err := roomCalendar(ctx, db, roomId, from, &to)
+	if err != nil {
+		return nil, err
+	}
+
+	return calendar.OpenPeriods(hours, days, from, to), nil
+}
+
+// roomCalendar gets the business hours of the site a room is on and its holidays between
+// the times supplied
+func roomCalendar(ctx context.Context, q querier, roomId int32, from time.Time, to *time.Time) ([]models.BusinessHours, []models.Holiday, error) {
+	var site string
+	err := q.QueryRow(ctx, `
+		SELECT
+			COALESCE(site, '')
+		FROM
+			room
+		WHERE
+			id = $1
+	`, roomId).Scan(&site)
+	// whatever is reserving the room reports it does not exist
+	if err == pgx.ErrNoRows {
+		return nil, nil, nil
+	}
+	if err != nil || site == "" {
+		return nil, nil, err
+	}
+
+	hours, err := businessHours(ctx, q, site)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// an open ended reservation only has to start when the site is open
+	last := from
+	if to != nil {
+		last = *to
+	}
+	days, err := holidays(ctx, q, site, from, &last)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return hours, days, nil
+}
@@ -0,0 +1,56 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCheckOpen(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	err := SetBusinessHours(ctx, "Dublin", []models.BusinessHours{
+		{Weekday: time.Monday, Opens: "09:00", Closes: "17:00"},
+		{Weekday: time.Tuesday, Opens: "09:00", Closes: "17:00"},
+	}, db)
+	if err != nil {
+		t.Fatalf("Error setting business hours: %s", err.Error())
+	}
+
+	// 2030-01-07 is a Monday
+	monday := time.Date(2030, time.January, 7, 10, 0, 0, 0, time.Local)
+	err = AddHolidays(ctx, "Dublin", []models.Holiday{{Date: monday.AddDate(0, 0, 1), Name: "Closed"}}, db)
+	if err != nil {
+		t.Fatalf("Error adding holidays: %s", err.Error())
+	}
+
+	tests := []struct {
+		name  string
+		start time.Time
+		open  bool
+	}{
+		{"business hours", monday, true},
+		{"3 am", monday.Add(-7 * time.Hour), false},
+		{"holiday", monday.AddDate(0, 0, 1), false},
+		{"no hours that day", monday.AddDate(0, 0, 2), false},
+	}
+
+	for _, test := range tests {
+		open, err := CheckOpen(ctx, 1, test.start, EndTime(test.start, 60), db)
+		if err != nil {
+			t.Errorf("Error checking business hours: %s", err.Error())
+		}
+
+		if open != test.open {
+			t.Errorf("%s: expected open to be %t", test.name, test.open)
+		}
+	}
+}
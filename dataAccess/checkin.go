@@ -0,0 +1,90 @@
+/*
+	Class that holds the data access functions for checking in to reservations, and releasing
+	the ones nobody turned up to.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"avaros/metrics"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// CheckIn records that someone has turned up to a reservation, so it is not released as a
+// no-show. The organizer, their delegates and the users invited can check in. Returns false
+// if the user cannot, or the reservation is not confirmed or has ended
+func CheckIn(ctx context.Context, reservationId int32, userId int32, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tag, err := db.Exec(ctx, `
+		UPDATE reservation
+		SET checked_in = COALESCE(checked_in, NOW())
+		WHERE id = $1
+		AND (`+actsForUser("$2")+` OR EXISTS(
+			SELECT 1 FROM attendee
+			WHERE attendee.reservation_id = reservation.id AND attendee.user_id = $2
+		))
+		AND status = 'confirmed'
+		AND expired = false
+		AND (end_time IS NULL OR end_time > NOW())
+	`, reservationId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ReleaseNoShows ends every reservation nobody has checked in to by the time supplied after
+// it started, and gives the rest of the time to anyone waiting on the room. Run by the
+// scheduler when no-shows are released
+func ReleaseNoShows(ctx context.Context, after time.Duration, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	now := time.Now()
+	rows, err := db.Query(ctx, `
+		UPDATE reservation
+		SET expired = true, end_time = $1
+		WHERE status = 'confirmed'
+		AND expired = false
+		AND checked_in IS NULL
+		AND start_time <= $2
+		AND (end_time IS NULL OR end_time > $1)
+		RETURNING `+reservationColumns+`
+	`, now, now.Add(-after))
+	if err != nil {
+		return err
+	}
+
+	released, err := scanReservations(rows)
+	if err != nil {
+		return err
+	}
+
+	rooms := map[int32]bool{}
+	for _, reservation := range released {
+		metrics.Bookings.WithLabelValues(metrics.BookingNoShow).Inc()
+		notifier.ReservationExpired(ctx, reservation)
+		if !rooms[reservation.RoomId] {
+			rooms[reservation.RoomId] = true
+			// the waitlist is not held to the time limit on the query that released the reservations
+			ProcessWaitlist(context.WithoutCancel(ctx), reservation.RoomId, db)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	metrics "avaros/metrics"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestReleaseNoShows(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	_, err := Reserve(ctx, 1, 1, 1, 0, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+	reservationId, err := Reserve(ctx, 2, 1, 1, 0, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+
+	checkedIn, err := CheckIn(ctx, reservationId, 3, db)
+	if err != nil {
+		t.Errorf("Error checking in: %s", err.Error())
+	}
+	if checkedIn {
+		t.Errorf("User 3 is not invited so should not check in")
+	}
+
+	checkedIn, err = CheckIn(ctx, reservationId, 1, db)
+	if err != nil {
+		t.Errorf("Error checking in: %s", err.Error())
+	}
+	if !checkedIn {
+		t.Errorf("The organizer should have checked in")
+	}
+
+	noShows := metrics.Bookings.WithLabelValues(metrics.BookingNoShow)
+	before := testutil.ToFloat64(noShows)
+	err = ReleaseNoShows(ctx, 0, db)
+	if err != nil {
+		t.Errorf("Error releasing no-shows: %s", err.Error())
+	}
+
+	if testutil.ToFloat64(noShows)-before != 1 {
+		t.Errorf("The no-show should be counted")
+	}
+
+	for roomId, want := range map[int32]bool{1: false, 2: true} {
+		exists, err := CheckReservation(ctx, roomId, db)
+		if err != nil {
+			t.Errorf("Error checking a reservation: %s", err.Error())
+		}
+
+		if exists != want {
+			t.Errorf("Reservation for room %d existing should be %t", roomId, want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+/*
+	Class that holds the data access functions for times rooms are closed.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"avaros/metrics"
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// closureOverlaps matches rooms, joined as room, with an occurrence of a closure meeting the
+// condition supplied that overlaps the start and end supplied
+func closureOverlaps(condition string, start string, end string) string {
+	return `EXISTS(
+			SELECT 1
+			FROM closure
+			` + closureOccurrences(start, end) + `
+			WHERE ` + condition + `
+			AND (closure.room_id = room.id OR closure.building = room.building OR closure.site = room.site)
+			AND occurrence.start_time < COALESCE(` + end + `, 'infinity'::timestamp)
+			AND occurrence.start_time + (closure.end_time - closure.start_time) > ` + start + `
+		)`
+}
+
+// closureOccurrences expands each closure, selected as closure, into the times it starts, as
+// occurrence.start_time, up to the end supplied. An empty end runs forever, so the occurrences
+// of a closure that repeats forever are only worked out for a year past the start
+func closureOccurrences(start string, end string) string {
+	return `CROSS JOIN LATERAL generate_series(
+				closure.start_time,
+				CASE
+					WHEN closure.recurrence = 'none' THEN closure.start_time
+					ELSE COALESCE(closure.repeat_until, GREATEST(closure.start_time,
+						COALESCE(` + end + `, ` + start + ` + INTERVAL '1 year')))
+				END,
+				CASE closure.recurrence
+					WHEN 'weekly' THEN INTERVAL '1 week'
+					WHEN 'yearly' THEN INTERVAL '1 year'
+					ELSE INTERVAL '1 day'
+				END
+			) AS occurrence(start_time)`
+}
+
+// CheckClosed checks if a room is closed for any of the time supplied
+func CheckClosed(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	return closed(ctx, db, roomId, startTime, endTime)
+}
+
+// closed checks if a room is closed for any of the time supplied
+func closed(ctx context.Context, q querier, roomId int32, startTime time.Time, endTime *time.Time) (bool, error) {
+	var isClosed bool
+	err := q.QueryRow(ctx, `
+		SELECT
+			`+closureOverlaps("TRUE", "$2::timestamp", "$3::timestamp")+`
+		FROM
+			room
+		WHERE
+			id = $1
+	`, roomId, startTime, endTime).Scan(&isClosed)
+
+	return isClosed, err
+}
+
+// CreateClosure closes a room, building or site. If cancel is true any reservations that
+// fall inside the closure are cancelled and their users told. Returns the ids of the
+// cancelled reservations
+func CreateClosure(ctx context.Context, closure *models.Closure, cancel bool, db *pgxpool.Pool) ([]int32, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancelQuery := queryContext(ctx)
+	defer cancelQuery()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO
+			closure (room_id, building, site, start_time, end_time, recurrence, repeat_until,
+				reason, created_by)
+		VALUES
+			($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`, closure.RoomId, closure.Building, closure.Site, closure.StartTime, closure.EndTime,
+		closure.Recurrence, closure.RepeatUntil, closure.Reason, closure.CreatedBy).Scan(&closure.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled := []models.Reservation{}
+	if cancel {
+		rows, err := tx.Query(ctx, `
+			DELETE
+			FROM
+				reservation
+			WHERE
+				expired = false
+			AND
+				status IN ('confirmed', 'pending', 'hold')
+			AND
+				EXISTS(
+					SELECT 1 FROM room
+					WHERE room.id = reservation.room_id
+					AND `+closureOverlaps("closure.id = $1", "reservation.start_time", "reservation.end_time")+`
+				)
+			RETURNING `+reservationColumns+`
+		`, closure.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		cancelled, err = scanReservations(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int32{}
+	for _, reservation := range cancelled {
+		if reservation.Status != models.ReservationHold {
+			metrics.Bookings.WithLabelValues(metrics.BookingCancelled).Inc()
+			notifier.ReservationCancelled(ctx, reservation)
+		}
+		ids = append(ids, reservation.Id)
+	}
+
+	return ids, nil
+}
+
+// GetClosures gets the closures that apply to a room, or every closure if no room is supplied
+func GetClosures(ctx context.Context, roomId int32, db *pgxpool.Pool) ([]models.Closure, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			closure.id, closure.room_id, COALESCE(closure.building, ''), COALESCE(closure.site, ''),
+			closure.start_time, closure.end_time, closure.recurrence, closure.repeat_until,
+			COALESCE(closure.reason, ''), closure.created_by
+		FROM
+			closure
+		WHERE
+			$1 = 0
+		OR
+			EXISTS(
+				SELECT 1 FROM room
+				WHERE room.id = $1
+				AND (closure.room_id = room.id OR closure.building = room.building OR closure.site = room.site)
+			)
+		ORDER BY closure.start_time
+	`, roomId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	closures := []models.Closure{}
+	for rows.Next() {
+		var closure models.Closure
+		err = rows.Scan(&closure.Id, &closure.RoomId, &closure.Building, &closure.Site,
+			&closure.StartTime, &closure.EndTime, &closure.Recurrence, &closure.RepeatUntil,
+			&closure.Reason, &closure.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		closures = append(closures, closure)
+	}
+
+	return closures, rows.Err()
+}
+
+// DeleteClosure reopens whatever a closure closed. Returns false if it does not exist
+func DeleteClosure(ctx context.Context, id int32, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tag, err := db.Exec(ctx, `
+		DELETE
+		FROM
+			closure
+		WHERE
+			id = $1
+	`, id)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,69 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClosure(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	reservationId, err := Reserve(ctx, 1, 1, 1, 30, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	// close the whole building every day for an hour, starting now
+	start := time.Now()
+	closure := models.Closure{
+		Building:   "Head Office",
+		StartTime:  start,
+		EndTime:    start.Add(time.Hour),
+		Recurrence: models.RecurrenceDaily,
+		CreatedBy:  1,
+	}
+	cancelled, err := CreateClosure(ctx, &closure, true, db)
+	if err != nil {
+		t.Fatalf("Error creating closure: %s", err.Error())
+	}
+
+	if len(cancelled) != 1 || cancelled[0] != reservationId {
+		t.Errorf("Reservation in room 1 should have been cancelled")
+	}
+
+	isClosed, err := CheckClosed(ctx, 2, start.Add(48*time.Hour+time.Minute), nil, db)
+	if err != nil {
+		t.Errorf("Error checking closures: %s", err.Error())
+	}
+
+	if !isClosed {
+		t.Errorf("Room 2 should be closed two days from now")
+	}
+
+	overlaps, err := CheckReservationOverlap(ctx, 2, start.Add(2*time.Hour), EndTime(start.Add(2*time.Hour), 30), db)
+	if err != nil {
+		t.Errorf("Error checking for an overlap: %s", err.Error())
+	}
+
+	if overlaps {
+		t.Errorf("Room 2 should be open between closures")
+	}
+
+	deleted, err := DeleteClosure(ctx, closure.Id, db)
+	if err != nil {
+		t.Errorf("Error deleting closure: %s", err.Error())
+	}
+
+	if !deleted {
+		t.Errorf("Closure should have been deleted")
+	}
+}
@@ -0,0 +1,162 @@
+/*
+	Class that holds the data access functions for delegating reservations to other users.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// actsForUser matches reservations organized by the user in the parameter supplied, or by
+// someone who has made that user their delegate
+func actsForUser(param string) string {
+	return actsFor("reservation", param)
+}
+
+// actsFor matches the rows of a table with a user_id that belong to the user in the parameter
+// supplied, or to someone who has made that user their delegate
+func actsFor(table string, param string) string {
+	return `(` + table + `.user_id = ` + param + ` OR EXISTS(
+			SELECT 1 FROM delegation
+			WHERE delegation.user_id = ` + table + `.user_id AND delegation.delegate_id = ` + param + `
+		))`
+}
+
+// CanActFor checks if a user may book, change and cancel reservations for another user.
+// Users can always act for themselves
+func CanActFor(ctx context.Context, delegateId int32, userId int32, db *pgxpool.Pool) (bool, error) {
+	if delegateId == userId {
+		return true, nil
+	}
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	var granted bool
+	err := db.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM delegation
+			WHERE user_id = $1 AND delegate_id = $2
+		)
+	`, userId, delegateId).Scan(&granted)
+
+	return granted, err
+}
+
+// CanManageReservation checks if a user may change or cancel a room's current reservation,
+// which they can if they or someone they are a delegate of organized it
+func CanManageReservation(ctx context.Context, roomId int32, userId int32, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	var allowed bool
+	err := db.QueryRow(ctx, `
+		SELECT NOT EXISTS(
+			SELECT 1 FROM reservation
+			WHERE room_id = $1
+			AND expired = false
+			AND start_time <= NOW()
+			AND (end_time IS NULL OR end_time > NOW())
+			AND (status != 'hold' OR hold_expires > NOW())
+			AND NOT `+actsForUser("$2")+`
+		)
+	`, roomId, userId).Scan(&allowed)
+
+	return allowed, err
+}
+
+// GrantDelegation lets the delegate act for the user. Granting it again does nothing
+func GrantDelegation(ctx context.Context, userId int32, delegateId int32, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	_, err := db.Exec(ctx, `
+		INSERT INTO
+			delegation (user_id, delegate_id)
+		VALUES
+			($1, $2)
+		ON CONFLICT DO NOTHING
+	`, userId, delegateId)
+
+	return err
+}
+
+// RevokeDelegation stops the delegate acting for the user. Returns false if there was
+// nothing to revoke
+func RevokeDelegation(ctx context.Context, userId int32, delegateId int32, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tag, err := db.Exec(ctx, `
+		DELETE
+		FROM
+			delegation
+		WHERE
+			user_id = $1
+		AND
+			delegate_id = $2
+	`, userId, delegateId)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetDelegations gets the delegations a user has granted and the ones granted to them
+func GetDelegations(ctx context.Context, userId int32, db *pgxpool.Pool) ([]models.Delegation, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			user_id, delegate_id, created
+		FROM
+			delegation
+		WHERE
+			user_id = $1
+		OR
+			delegate_id = $1
+		ORDER BY created
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	delegations := []models.Delegation{}
+	for rows.Next() {
+		var delegation models.Delegation
+		err = rows.Scan(&delegation.UserId, &delegation.DelegateId, &delegation.Created)
+		if err != nil {
+			return nil, err
+		}
+		delegations = append(delegations, delegation)
+	}
+
+	return delegations, rows.Err()
+}
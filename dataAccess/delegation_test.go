@@ -0,0 +1,84 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDelegation(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	allowed, err := CanActFor(ctx, 2, 1, db)
+	if err != nil {
+		t.Errorf("Error checking delegation: %s", err.Error())
+	}
+
+	if allowed {
+		t.Errorf("User 2 should not be able to act for user 1 without a grant")
+	}
+
+	err = GrantDelegation(ctx, 1, 2, db)
+	if err != nil {
+		t.Fatalf("Error granting delegation: %s", err.Error())
+	}
+
+	allowed, err = CanActFor(ctx, 2, 1, db)
+	if err != nil {
+		t.Errorf("Error checking delegation: %s", err.Error())
+	}
+
+	if !allowed {
+		t.Errorf("User 2 should be able to act for user 1")
+	}
+
+	// user 2 books and cancels a hold for user 1
+	holdId, _, err := PlaceHold(ctx, 1, 1, 2, time.Now(), nil, time.Now().Add(time.Minute), models.ReservationDetails{}, db)
+	if err != nil {
+		t.Fatalf("Error placing hold: %s", err.Error())
+	}
+
+	allowed, err = CanManageReservation(ctx, 1, 3, db)
+	if err != nil {
+		t.Errorf("Error checking delegation: %s", err.Error())
+	}
+
+	if allowed {
+		t.Errorf("User 3 should not be able to change user 1's reservation")
+	}
+
+	released, err := ReleaseHold(ctx, holdId, 2, db)
+	if err != nil {
+		t.Errorf("Error releasing hold: %s", err.Error())
+	}
+
+	if !released {
+		t.Errorf("User 2 should be able to release the hold for user 1")
+	}
+
+	revoked, err := RevokeDelegation(ctx, 1, 2, db)
+	if err != nil {
+		t.Errorf("Error revoking delegation: %s", err.Error())
+	}
+
+	if !revoked {
+		t.Errorf("Delegation should have been revoked")
+	}
+
+	allowed, err = CanActFor(ctx, 2, 1, db)
+	if err != nil {
+		t.Errorf("Error checking delegation: %s", err.Error())
+	}
+
+	if allowed {
+		t.Errorf("User 2 should no longer be able to act for user 1")
+	}
+}
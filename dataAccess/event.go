@@ -0,0 +1,126 @@
+/*
+	Class that holds the data access functions for reservation events.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// GetReservationEventsSince gets the events for a room that happened after the event
+// id supplied, oldest first. Used to replay anything a client missed while disconnected
+func GetReservationEventsSince(ctx context.Context, roomId int32, lastEventId int64, db *pgxpool.Pool) ([]models.ReservationEvent, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			id, room_id, reservation_id, operation, reserved, created
+		FROM
+			reservation_event
+		WHERE
+			room_id = $1
+		AND
+			id > $2
+		ORDER BY id
+	`, roomId, lastEventId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.ReservationEvent{}
+	for rows.Next() {
+		var event models.ReservationEvent
+		err = rows.Scan(&event.Id, &event.RoomId, &event.ReservationId, &event.Operation,
+			&event.Reserved, &event.Created)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// GetRoomState gets the current reservation state of a room as an event. The id is
+// the latest event recorded so a client can resume from that point if it reconnects
+func GetRoomState(ctx context.Context, roomId int32, db *pgxpool.Pool) (models.ReservationEvent, error) {
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	state := models.ReservationEvent{
+		RoomId:    roomId,
+		Operation: "STATE",
+	}
+
+	reserved, err := CheckReservation(ctx, roomId, db)
+	if err != nil {
+		return state, err
+	}
+	state.Reserved = reserved
+
+	err = db.QueryRow(ctx, `
+		SELECT
+			COALESCE(MAX(id), 0), NOW()
+		FROM
+			reservation_event
+	`).Scan(&state.Id, &state.Created)
+	if err != nil {
+		return state, err
+	}
+
+	return state, nil
+}
+
+// GetOldestReservationEventId gets the id of the oldest event still kept, or 0 if there are none.
+// A client that last saw an event before it may have missed events that were deleted
+func GetOldestReservationEventId(ctx context.Context, db *pgxpool.Pool) (int64, error) {
+	if db == nil {
+		return 0, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	var oldest int64
+	err := db.QueryRow(ctx, `
+		SELECT
+			COALESCE(MIN(id), 0)
+		FROM
+			reservation_event
+	`).Scan(&oldest)
+
+	return oldest, err
+}
+
+// DeleteReservationEvents deletes the events that happened before the time supplied, so
+// the table only holds what clients reconnecting could still need to replay. Run by the scheduler
+func DeleteReservationEvents(ctx context.Context, before time.Time, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	_, err := db.Exec(ctx, `
+		DELETE
+		FROM
+			reservation_event
+		WHERE
+			created < $1
+	`, before)
+
+	return err
+}
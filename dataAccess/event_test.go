@@ -0,0 +1,57 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDeleteReservationEvents(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	_, err := Reserve(ctx, 1, 1, 1, 0, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+
+	oldest, err := GetOldestReservationEventId(ctx, db)
+	if err != nil {
+		t.Errorf("Error getting the oldest event: %s", err.Error())
+	}
+	if oldest == 0 {
+		t.Fatalf("Reserving should have recorded an event")
+	}
+
+	// the event is kept when only the ones over an hour old are deleted
+	err = DeleteReservationEvents(ctx, time.Now().Add(-time.Hour), db)
+	if err != nil {
+		t.Errorf("Error deleting reservation events: %s", err.Error())
+	}
+	events, err := GetReservationEventsSince(ctx, 1, 0, db)
+	if err != nil {
+		t.Errorf("Error getting reservation events: %s", err.Error())
+	}
+	if len(events) == 0 {
+		t.Errorf("The event should have been kept")
+	}
+
+	err = DeleteReservationEvents(ctx, time.Now().Add(time.Minute), db)
+	if err != nil {
+		t.Errorf("Error deleting reservation events: %s", err.Error())
+	}
+	oldest, err = GetOldestReservationEventId(ctx, db)
+	if err != nil {
+		t.Errorf("Error getting the oldest event: %s", err.Error())
+	}
+	if oldest != 0 {
+		t.Errorf("Every event should have been deleted")
+	}
+}
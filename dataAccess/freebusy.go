@@ -0,0 +1,178 @@
+/*
+	Class that holds the data access functions for working out when rooms and users are busy.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// busyBlock is one reservation or closure occurrence read by GetFreeBusy before it is merged
+type busyBlock struct {
+	start time.Time
+	end   *time.Time
+	busy  models.Busy
+}
+
+// GetFreeBusy gets when each of the rooms and users supplied is busy between from and to in a
+// single query. A room is busy while it is reserved, including its setup and teardown buffers,
+// and while it is closed. A user is busy during the reservations they organize or are invited
+// to and have not declined. The details of private reservations are hidden unless the viewer
+// is their organizer or an attendee
+func GetFreeBusy(ctx context.Context, roomIds []int32, userIds []int32, from time.Time, to time.Time, viewerId int32, db *pgxpool.Pool) (models.FreeBusy, error) {
+	freeBusy := models.FreeBusy{
+		Rooms: map[int32][]models.BusyPeriod{},
+		Users: map[int32][]models.BusyPeriod{},
+	}
+	if db == nil {
+		return freeBusy, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	// a reservation's details can be seen if it is not private or the viewer is part of it
+	visible := `(NOT reservation.private OR reservation.user_id = $5 OR EXISTS(
+			SELECT 1 FROM attendee viewer
+			WHERE viewer.reservation_id = reservation.id AND viewer.user_id = $5
+		))`
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			'room', room.id, reservation.id, room.id, COALESCE(reservation.title, ''), `+visible+`, false,
+			reservation.start_time - room.buffer_before * INTERVAL '1 minute',
+			reservation.end_time + room.buffer_after * INTERVAL '1 minute'
+		FROM
+			reservation
+		JOIN
+			room ON room.id = reservation.room_id
+		WHERE
+			room.id = ANY($1)
+		AND
+			`+counted+`
+		AND
+			reservation.start_time - room.buffer_before * INTERVAL '1 minute' < $4
+		AND
+			(reservation.end_time IS NULL OR reservation.end_time + room.buffer_after * INTERVAL '1 minute' > $3)
+
+		UNION ALL
+
+		SELECT
+			'room', room.id, 0, room.id, COALESCE(closure.reason, ''), true, true,
+			occurrence.start_time, occurrence.start_time + (closure.end_time - closure.start_time)
+		FROM
+			room
+		JOIN
+			closure ON closure.room_id = room.id OR closure.building = room.building OR closure.site = room.site
+		`+closureOccurrences("$3::timestamp", "$4::timestamp")+`
+		WHERE
+			room.id = ANY($1)
+		AND
+			occurrence.start_time < $4
+		AND
+			occurrence.start_time + (closure.end_time - closure.start_time) > $3
+
+		UNION ALL
+
+		SELECT
+			'user', people.user_id, reservation.id, reservation.room_id, COALESCE(reservation.title, ''),
+			`+visible+`, false, reservation.start_time, reservation.end_time
+		FROM
+			reservation
+		JOIN (
+			SELECT id AS reservation_id, user_id FROM reservation WHERE user_id = ANY($2)
+			UNION
+			SELECT reservation_id, user_id FROM attendee WHERE user_id = ANY($2) AND rsvp != 'declined'
+		) people ON people.reservation_id = reservation.id
+		WHERE
+			`+counted+`
+		AND
+			reservation.start_time < $4
+		AND
+			(reservation.end_time IS NULL OR reservation.end_time > $3)
+	`, roomIds, userIds, from, to, viewerId)
+	if err != nil {
+		return freeBusy, err
+	}
+	defer rows.Close()
+
+	roomBlocks := map[int32][]busyBlock{}
+	userBlocks := map[int32][]busyBlock{}
+	for rows.Next() {
+		var kind string
+		var id int32
+		var visible bool
+		var block busyBlock
+		err = rows.Scan(&kind, &id, &block.busy.ReservationId, &block.busy.RoomId, &block.busy.Title,
+			&visible, &block.busy.Closed, &block.start, &block.end)
+		if err != nil {
+			return freeBusy, err
+		}
+
+		if !visible {
+			block.busy = models.Busy{Private: true}
+		}
+
+		if kind == "room" {
+			roomBlocks[id] = append(roomBlocks[id], block)
+		} else {
+			userBlocks[id] = append(userBlocks[id], block)
+		}
+	}
+	if rows.Err() != nil {
+		return freeBusy, rows.Err()
+	}
+
+	for _, roomId := range roomIds {
+		freeBusy.Rooms[roomId] = mergeBusy(roomBlocks[roomId], from, to)
+	}
+	for _, userId := range userIds {
+		freeBusy.Users[userId] = mergeBusy(userBlocks[userId], from, to)
+	}
+
+	return freeBusy, nil
+}
+
+// mergeBusy joins up the blocks that overlap or run into each other, in order, cutting them
+// down to between from and to. Anything without an end is busy until to
+func mergeBusy(blocks []busyBlock, from time.Time, to time.Time) []models.BusyPeriod {
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].start.Before(blocks[j].start)
+	})
+
+	periods := []models.BusyPeriod{}
+	for _, block := range blocks {
+		start := block.start.Local()
+		if start.Before(from) {
+			start = from
+		}
+		end := to
+		if block.end != nil && block.end.Before(to) {
+			end = block.end.Local()
+		}
+
+		last := len(periods) - 1
+		if last >= 0 && !start.After(periods[last].End) {
+			if end.After(periods[last].End) {
+				periods[last].End = end
+			}
+			periods[last].Busy = append(periods[last].Busy, block.busy)
+			continue
+		}
+
+		periods = append(periods, models.BusyPeriod{
+			Start: start,
+			End:   end,
+			Busy:  []models.Busy{block.busy},
+		})
+	}
+
+	return periods
+}
@@ -0,0 +1,58 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFreeBusy(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	john := int32(3)
+	start := time.Now().Add(time.Hour).Truncate(time.Minute)
+	_, _, err := ReserveRooms(ctx, []int32{1}, 1, 1, start, 60, models.ReservationDetails{
+		Title:     "One to one",
+		Private:   true,
+		Attendees: []models.Attendee{{UserId: &john}},
+	}, db)
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+	_, _, err = ReserveRooms(ctx, []int32{1}, 1, 1, start.Add(time.Hour), 30, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	from := start.Add(-time.Hour)
+	to := start.Add(24 * time.Hour)
+	freeBusy, err := GetFreeBusy(ctx, []int32{1, 2}, []int32{3}, from, to, 2, db)
+	if err != nil {
+		t.Fatalf("Error getting free/busy: %s", err.Error())
+	}
+
+	// the reservations run into each other so are joined up
+	room := freeBusy.Rooms[1]
+	if len(room) != 1 || !room[0].Start.Equal(start) || !room[0].End.Equal(start.Add(90*time.Minute)) {
+		t.Errorf("Room 1 should be busy for 90 minutes from %s, got %v", start, room)
+	}
+	if len(room) == 1 && (!room[0].Busy[0].Private || room[0].Busy[0].Title != "") {
+		t.Errorf("Details of the private reservation should be hidden")
+	}
+
+	if len(freeBusy.Rooms[2]) != 0 {
+		t.Errorf("Room 2 should be free")
+	}
+
+	if len(freeBusy.Users[3]) != 1 {
+		t.Errorf("User 3 should be busy with the reservation they are invited to")
+	}
+}
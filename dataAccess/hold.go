@@ -0,0 +1,227 @@
+/*
+	Class that holds the data access functions for holds on a room.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"avaros/metrics"
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// PlaceHold blocks a room for a time slot for the user, placed by createdBy, until the hold
+// expires, giving them time to confirm it. Returns false if something already overlaps the slot
+// or the room is closed then, and a QuotaError if it would take the user over their quota
+func PlaceHold(ctx context.Context, roomId int32, userId int32, createdBy int32, startTime time.Time, endTime *time.Time, holdExpires time.Time, details models.ReservationDetails, db *pgxpool.Pool) (int32, bool, error) {
+	if db == nil {
+		return -1, false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return -1, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	// lock the room so nothing else can reserve it until this is done
+	found, err := lockRooms(ctx, tx, []int32{roomId})
+	if err != nil {
+		return -1, false, err
+	}
+	if !found[roomId] {
+		return -1, false, fmt.Errorf("Room with id %d does not exist", roomId)
+	}
+
+	taken, err := slotTaken(ctx, tx, roomId, startTime, endTime)
+	if err != nil {
+		return -1, false, err
+	}
+	if taken {
+		return -1, false, nil
+	}
+
+	hold := models.Reservation{
+		RoomId:             roomId,
+		UserId:             userId,
+		CreatedBy:          createdBy,
+		StartTime:          startTime,
+		EndTime:            endTime,
+		Status:             models.ReservationHold,
+		HoldExpires:        &holdExpires,
+		ReservationDetails: details,
+	}
+	err = checkQuota(ctx, tx, userId, []models.Reservation{hold})
+	if err != nil {
+		return -1, false, err
+	}
+
+	err = insertReservation(ctx, tx, &hold)
+	if err != nil {
+		return -1, false, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return -1, false, err
+	}
+
+	return hold.Id, true, nil
+}
+
+// ConfirmHold turns a user's hold into a reservation, or a request for one if the room
+// needs approval. Returns false if the hold is not theirs or their delegator's, or has expired,
+// and ErrNoApprover if the room needs approval but has no approver
+func ConfirmHold(ctx context.Context, holdId int32, userId int32, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT
+			`+reservationColumns+`
+		FROM
+			reservation
+		WHERE
+			id = $1
+		AND
+			`+actsForUser("$2")+`
+		AND
+			status = 'hold'
+		AND
+			hold_expires > NOW()
+		FOR UPDATE
+	`, holdId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	holds, err := scanReservations(rows)
+	if err != nil {
+		return false, err
+	}
+	if len(holds) == 0 {
+		return false, nil
+	}
+
+	reservation := holds[0]
+	reservation.Status = models.ReservationConfirmed
+	reservation.HoldExpires = nil
+	err = applyApproval(ctx, tx, &reservation)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE reservation
+		SET status = $2, hold_expires = NULL, approver_id = $3, approval_expires = $4
+		WHERE id = $1
+	`, reservation.Id, reservation.Status, reservation.ApproverId, reservation.ApprovalExpires)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	reservationCreated(ctx, reservation, db)
+	return true, nil
+}
+
+// ReleaseHold releases a user's hold before it expires. Returns false if the hold is not
+// theirs or their delegator's, or has already been confirmed or released
+func ReleaseHold(ctx context.Context, holdId int32, userId int32, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	var roomId int32
+	err := db.QueryRow(ctx, `
+		DELETE
+		FROM
+			reservation
+		WHERE
+			id = $1
+		AND
+			`+actsForUser("$2")+`
+		AND
+			status = 'hold'
+		RETURNING room_id
+	`, holdId, userId).Scan(&roomId)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// give the freed up time to anyone waiting on it
+	background(ctx, "process waitlist", func(ctx context.Context) { ProcessWaitlist(ctx, roomId, db) })
+	return true, nil
+}
+
+// ReleaseExpiredHolds releases every hold that was not confirmed in time. Run by the
+// scheduler so holds are released even if the instance that placed them has gone away
+func ReleaseExpiredHolds(ctx context.Context, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		DELETE
+		FROM
+			reservation
+		WHERE
+			status = 'hold'
+		AND
+			hold_expires <= NOW()
+		RETURNING `+reservationColumns+`
+	`)
+	if err != nil {
+		return err
+	}
+
+	released, err := scanReservations(rows)
+	if err != nil {
+		return err
+	}
+
+	// give the freed up time to anyone waiting on it
+	rooms := map[int32]bool{}
+	for _, hold := range released {
+		metrics.Bookings.WithLabelValues(metrics.HoldReleased).Inc()
+		if !rooms[hold.RoomId] {
+			rooms[hold.RoomId] = true
+			// the waitlist is not held to the time limit on the query that released the hold
+			ProcessWaitlist(context.WithoutCancel(ctx), hold.RoomId, db)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestConfirmHold(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	holdId, held, err := PlaceHold(ctx, 1, 1, 1, time.Now(), nil, time.Now().Add(time.Minute), models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error placing hold: %s", err.Error())
+	}
+
+	if !held {
+		t.Fatalf("Room 1 should have been held")
+	}
+
+	_, held, err = PlaceHold(ctx, 1, 2, 2, time.Now(), nil, time.Now().Add(time.Minute), models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error placing hold: %s", err.Error())
+	}
+
+	if held {
+		t.Errorf("Room 1 should not be held twice")
+	}
+
+	confirmed, err := ConfirmHold(ctx, holdId, 2, db)
+	if err != nil {
+		t.Errorf("Error confirming hold: %s", err.Error())
+	}
+
+	if confirmed {
+		t.Errorf("Only the user who placed the hold should be able to confirm it")
+	}
+
+	confirmed, err = ConfirmHold(ctx, holdId, 1, db)
+	if err != nil {
+		t.Errorf("Error confirming hold: %s", err.Error())
+	}
+
+	if !confirmed {
+		t.Errorf("Hold should have been confirmed")
+	}
+
+	reservationExists, err := CheckReservation(ctx, 1, db)
+	if err != nil {
+		t.Errorf("Error checking a reservation: %s", err.Error())
+	}
+
+	if !reservationExists {
+		t.Errorf("Reservation for room 1 should exist")
+	}
+}
+
+func TestReleaseExpiredHolds(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	holdId, _, err := PlaceHold(ctx, 1, 1, 1, time.Now(), nil, time.Now().Add(time.Second), models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error placing hold: %s", err.Error())
+	}
+
+	time.Sleep(2 * time.Second)
+
+	err = ReleaseExpiredHolds(ctx, db)
+	if err != nil {
+		t.Errorf("Error releasing expired holds: %s", err.Error())
+	}
+
+	reservationExists, err := CheckReservation(ctx, 1, db)
+	if err != nil {
+		t.Errorf("Error checking a reservation: %s", err.Error())
+	}
+
+	if reservationExists {
+		t.Errorf("Hold on room 1 should have been released")
+	}
+
+	confirmed, err := ConfirmHold(ctx, holdId, 1, db)
+	if err != nil {
+		t.Errorf("Error confirming hold: %s", err.Error())
+	}
+
+	if confirmed {
+		t.Errorf("An expired hold should not be confirmed")
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Hook for letting users know about changes to their reservations.
+*/
+package dataAccess
+
+import (
+	"context"
+
+	"avaros/models"
+)
+
+// ReservationNotifier is told when a reservation is booked, expires, is cancelled, is
+// waiting on or rejected by an approver or is displaced by a higher priority one so the
+// users involved can be let know, and when a slot is offered from the waitlist
+type ReservationNotifier interface {
+	ReservationBooked(ctx context.Context, reservation models.Reservation)
+	ReservationExpired(ctx context.Context, reservation models.Reservation)
+	ReservationCancelled(ctx context.Context, reservation models.Reservation)
+	ApprovalRequested(ctx context.Context, reservation models.Reservation)
+	ReservationRejected(ctx context.Context, reservation models.Reservation)
+	ReservationBumped(ctx context.Context, reservation models.Reservation, alternatives []models.Room)
+	WaitlistOffered(ctx context.Context, entry models.WaitlistEntry)
+}
+
+// noNotifier is used until a notifier is set so nothing is sent
+type noNotifier struct{}
+
+func (noNotifier) ReservationBooked(context.Context, models.Reservation)                {}
+func (noNotifier) ReservationExpired(context.Context, models.Reservation)               {}
+func (noNotifier) ReservationCancelled(context.Context, models.Reservation)             {}
+func (noNotifier) ApprovalRequested(context.Context, models.Reservation)                {}
+func (noNotifier) ReservationRejected(context.Context, models.Reservation)              {}
+func (noNotifier) ReservationBumped(context.Context, models.Reservation, []models.Room) {}
+func (noNotifier) WaitlistOffered(context.Context, models.WaitlistEntry)                {}
+
+var notifier ReservationNotifier = noNotifier{}
+
+// SetNotifier sets what is told about changes to reservations. Should be called
+// once at startup before any reservations are made
+func SetNotifier(n ReservationNotifier) {
+	if n == nil {
+		n = noNotifier{}
+	}
+	notifier = n
+}
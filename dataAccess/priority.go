@@ -0,0 +1,297 @@
+/*
+	Class that holds the data access functions for reservation priorities and the reservations
+	displaced by higher priority ones.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// how many other rooms are suggested to someone whose reservation is displaced
+const alternativesSuggested = 3
+
+// bumped is a reservation that was displaced and the rooms its organizer could use instead
+type bumped struct {
+	reservation  models.Reservation
+	alternatives []models.Room
+}
+
+// GetRolePriorities gets the highest priority each role can reserve rooms at
+func GetRolePriorities(ctx context.Context, db *pgxpool.Pool) ([]models.RolePriority, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			role, max_priority
+		FROM
+			role_priority
+		ORDER BY role
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	priorities := []models.RolePriority{}
+	for rows.Next() {
+		var priority models.RolePriority
+		err = rows.Scan(&priority.Role, &priority.MaxPriority)
+		if err != nil {
+			return nil, err
+		}
+		priorities = append(priorities, priority)
+	}
+
+	return priorities, rows.Err()
+}
+
+// SetRolePriority sets the highest priority users with a role can reserve rooms at
+func SetRolePriority(ctx context.Context, priority models.RolePriority, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	_, err := db.Exec(ctx, `
+		INSERT INTO
+			role_priority (role, max_priority)
+		VALUES
+			($1, $2)
+		ON CONFLICT (role) DO UPDATE SET max_priority = EXCLUDED.max_priority
+	`, priority.Role, priority.MaxPriority)
+
+	return err
+}
+
+// MaxPriority gets the highest priority a user can reserve rooms at. Users whose role
+// has no priority set can only make normal reservations
+func MaxPriority(ctx context.Context, userId int32, db *pgxpool.Pool) (int32, error) {
+	if db == nil {
+		return models.PriorityNormal, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	var maxPriority int32
+	err := db.QueryRow(ctx, `
+		SELECT
+			COALESCE(role_priority.max_priority, 0)
+		FROM
+			users
+		LEFT JOIN
+			role_priority ON role_priority.role = users.role
+		WHERE
+			users.id = $1
+	`, userId).Scan(&maxPriority)
+
+	return maxPriority, err
+}
+
+// GetBumps gets the records of the user's reservations being displaced, newest first
+func GetBumps(ctx context.Context, userId int32, db *pgxpool.Pool) ([]models.Bump, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			bump.id, bump.reservation_id, bump.by_reservation_id, displacing.created_by,
+			COALESCE(bump.reason, ''), bump.created
+		FROM
+			bump
+		JOIN
+			reservation ON reservation.id = bump.reservation_id
+		JOIN
+			reservation displacing ON displacing.id = bump.by_reservation_id
+		WHERE
+			reservation.user_id = $1
+		ORDER BY bump.created DESC
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bumps := []models.Bump{}
+	for rows.Next() {
+		var b models.Bump
+		err = rows.Scan(&b.Id, &b.ReservationId, &b.ByReservationId, &b.ByUserId, &b.Reason, &b.Created)
+		if err != nil {
+			return nil, err
+		}
+		b.Created = b.Created.Local()
+		bumps = append(bumps, b)
+	}
+
+	return bumps, rows.Err()
+}
+
+// preemptable checks if a room can be reserved at the priority supplied for the time supplied.
+// It can if it is open and everything overlapping has a lower priority, in which case the ids
+// of those reservations are returned so they can be displaced
+func preemptable(ctx context.Context, q querier, roomId int32, startTime time.Time, endTime *time.Time, priority int32) ([]int32, bool, error) {
+	isClosed, err := closed(ctx, q, roomId, startTime, endTime)
+	if err != nil || isClosed {
+		return nil, false, err
+	}
+
+	ids, err := overlappingReservations(ctx, q, roomId, startTime, endTime)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(ids) == 0 {
+		return nil, true, nil
+	}
+	if priority <= models.PriorityNormal {
+		return nil, false, nil
+	}
+
+	var highest int32
+	err = q.QueryRow(ctx, `
+		SELECT
+			MAX(priority)
+		FROM
+			reservation
+		WHERE
+			id = ANY($1)
+	`, ids).Scan(&highest)
+	if err != nil {
+		return nil, false, err
+	}
+	if highest >= priority {
+		return nil, false, nil
+	}
+
+	return ids, true, nil
+}
+
+// bump displaces the reservations supplied with the one that took their time, recording why,
+// and finds some other rooms their organizers could use. The organizers should be let know
+// once the transaction is committed
+func bump(ctx context.Context, tx pgx.Tx, reservationIds []int32, by models.Reservation) ([]bumped, error) {
+	if len(reservationIds) == 0 {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		UPDATE reservation
+		SET status = 'bumped', expired = true
+		WHERE id = ANY($1)
+		RETURNING `+reservationColumns+`
+	`, reservationIds)
+	if err != nil {
+		return nil, err
+	}
+
+	displaced, err := scanReservations(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := fmt.Sprintf("Displaced by a priority %d reservation", by.Priority)
+	if by.Title != "" {
+		reason += ": " + by.Title
+	}
+
+	bumps := []bumped{}
+	for _, reservation := range displaced {
+		reservation.DecisionReason = reason
+		err = tx.QueryRow(ctx, `
+			INSERT INTO
+				bump (reservation_id, by_reservation_id, reason)
+			VALUES
+				($1, $2, $3)
+			RETURNING id
+		`, reservation.Id, by.Id, reason).Scan(new(int32))
+		if err != nil {
+			return nil, err
+		}
+
+		alternatives, err := alternativeRooms(ctx, tx, reservation)
+		if err != nil {
+			return nil, err
+		}
+
+		bumps = append(bumps, bumped{reservation, alternatives})
+	}
+
+	return bumps, nil
+}
+
+// alternativeRooms finds other rooms on the same site that are free for all of a reservation's
+// time, fit everyone invited to it and do not need approval
+func alternativeRooms(ctx context.Context, q querier, reservation models.Reservation) ([]models.Room, error) {
+	rows, err := q.Query(ctx, `
+		SELECT
+			`+roomColumns+`
+		FROM
+			room
+		JOIN
+			room booked ON booked.site IS NOT DISTINCT FROM room.site
+		WHERE
+			booked.id = $1
+		AND
+			room.id != $1
+		AND
+			room.requires_approval = false
+		AND
+			(room.capacity IS NULL OR room.capacity > (
+				SELECT COUNT(*) FROM attendee WHERE reservation_id = $2
+			))
+		ORDER BY room.id
+	`, reservation.RoomId, reservation.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []models.Room{}
+	for rows.Next() {
+		var room models.Room
+		err = scanRoom(rows, &room)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		candidates = append(candidates, room)
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	alternatives := []models.Room{}
+	for _, room := range candidates {
+		taken, err := slotTaken(ctx, q, room.Id, reservation.StartTime, reservation.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		if !taken {
+			alternatives = append(alternatives, room)
+		}
+		if len(alternatives) == alternativesSuggested {
+			break
+		}
+	}
+
+	return alternatives, nil
+}
@@ -0,0 +1,69 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBump(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	start := time.Now().Add(time.Hour)
+	ids, _, err := ReserveRooms(ctx, []int32{1}, 1, 1, start, 60, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	// the same priority cannot displace it
+	_, conflicts, err := ReserveRooms(ctx, []int32{1}, 3, 3, start, 60, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+	if len(conflicts) != 1 {
+		t.Errorf("Room 1 should already be reserved")
+	}
+
+	maxPriority, err := MaxPriority(ctx, 2, db)
+	if err != nil {
+		t.Fatalf("Error getting max priority: %s", err.Error())
+	}
+	if maxPriority != models.PriorityCritical {
+		t.Errorf("Managers should be able to make critical reservations")
+	}
+
+	byIds, conflicts, err := ReserveRooms(ctx, []int32{1}, 2, 2, start, 60, models.ReservationDetails{
+		Title:    "Board meeting",
+		Priority: models.PriorityHigh,
+	}, db)
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+	if len(conflicts) > 0 {
+		t.Fatalf("Reservation should have displaced the normal one")
+	}
+
+	bumped, err := GetReservation(ctx, ids[0], db)
+	if err != nil {
+		t.Fatalf("Error getting reservation: %s", err.Error())
+	}
+	if bumped.Status != models.ReservationBumped {
+		t.Errorf("Reservation should be bumped, got %s", bumped.Status)
+	}
+
+	bumps, err := GetBumps(ctx, 1, db)
+	if err != nil {
+		t.Fatalf("Error getting bumps: %s", err.Error())
+	}
+	if len(bumps) != 1 || bumps[0].ByReservationId != byIds[0] || bumps[0].ByUserId != 2 {
+		t.Errorf("Bump by reservation %d should be recorded, got %v", byIds[0], bumps)
+	}
+}
@@ -0,0 +1,406 @@
+/*
+	Class that holds the data access functions for the quotas that limit how much users reserve.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// QuotaError is returned when a reservation would take a user over their quota. The reason
+// says which limit it breaks
+type QuotaError struct {
+	Reason string
+}
+
+func (e *QuotaError) Error() string {
+	return e.Reason
+}
+
+// counted matches the reservations that count towards a user's quota
+const counted = `(status IN ('confirmed', 'pending') OR (status = 'hold' AND hold_expires > NOW()))`
+
+// GetQuotas gets every quota
+func GetQuotas(ctx context.Context, db *pgxpool.Pool) ([]models.Quota, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			id, user_id, COALESCE(team, ''), COALESCE(role, ''), max_future_reservations,
+			max_hours_per_week, max_per_room_per_day
+		FROM
+			quota
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanQuotas(rows)
+}
+
+// SetQuota sets the quota for a user, team, role or, if none of them are set, everyone,
+// replacing any quota they already had
+func SetQuota(ctx context.Context, quota *models.Quota, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		DELETE
+		FROM
+			quota
+		WHERE
+			user_id IS NOT DISTINCT FROM $1
+		AND
+			team IS NOT DISTINCT FROM NULLIF($2, '')
+		AND
+			role IS NOT DISTINCT FROM NULLIF($3, '')
+	`, quota.UserId, quota.Team, quota.Role)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO
+			quota (user_id, team, role, max_future_reservations, max_hours_per_week, max_per_room_per_day)
+		VALUES
+			($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6)
+		RETURNING id
+	`, quota.UserId, quota.Team, quota.Role, quota.MaxFutureReservations, quota.MaxHoursPerWeek,
+		quota.MaxPerRoomPerDay).Scan(&quota.Id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteQuota removes a quota. Returns false if it does not exist
+func DeleteQuota(ctx context.Context, id int32, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tag, err := db.Exec(ctx, `
+		DELETE FROM quota WHERE id = $1
+	`, id)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetQuotaUsage gets a user's quota and how much of it they have used
+func GetQuotaUsage(ctx context.Context, userId int32, db *pgxpool.Pool) (models.QuotaUsage, error) {
+	usage := models.QuotaUsage{}
+	if db == nil {
+		return usage, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	var err error
+	usage.Limits, err = effectiveQuota(ctx, db, userId)
+	if err != nil {
+		return usage, err
+	}
+
+	usage.FutureReservations, err = futureReservations(ctx, db, userId)
+	if err != nil {
+		return usage, err
+	}
+	if usage.Limits.MaxFutureReservations != nil {
+		remaining := *usage.Limits.MaxFutureReservations - usage.FutureReservations
+		if remaining < 0 {
+			remaining = 0
+		}
+		usage.RemainingFutureReservations = &remaining
+	}
+
+	usage.HoursThisWeek, err = hoursInWeek(ctx, db, userId, weekStart(time.Now()))
+	if err != nil {
+		return usage, err
+	}
+	if usage.Limits.MaxHoursPerWeek != nil {
+		remaining := *usage.Limits.MaxHoursPerWeek - usage.HoursThisWeek
+		if remaining < 0 {
+			remaining = 0
+		}
+		usage.RemainingHoursThisWeek = &remaining
+	}
+
+	return usage, nil
+}
+
+// CheckQuota checks the reservations supplied would not take the user over their quota
+// without making them. Returns a QuotaError if they would
+func CheckQuota(ctx context.Context, userId int32, reservations []models.Reservation, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	return checkQuota(ctx, tx, userId, reservations)
+}
+
+// checkQuota checks the reservations supplied would not take the user over their quota as
+// part of the transaction making them. The user is locked until the transaction ends so
+// their reservations are checked one transaction at a time. Returns a QuotaError if the
+// quota would be broken
+func checkQuota(ctx context.Context, tx pgx.Tx, userId int32, reservations []models.Reservation) error {
+	_, err := tx.Exec(ctx, `
+		SELECT id FROM users WHERE id = $1 FOR UPDATE
+	`, userId)
+	if err != nil {
+		return err
+	}
+
+	quota, err := effectiveQuota(ctx, tx, userId)
+	if err != nil {
+		return err
+	}
+
+	if quota.MaxFutureReservations != nil {
+		current, err := futureReservations(ctx, tx, userId)
+		if err != nil {
+			return err
+		}
+		if current+int32(len(reservations)) > *quota.MaxFutureReservations {
+			return &QuotaError{Reason: fmt.Sprintf(
+				"You already have %d upcoming reservations, the most allowed is %d.", current, *quota.MaxFutureReservations)}
+		}
+	}
+
+	if quota.MaxHoursPerWeek != nil {
+		weeks := map[time.Time]float64{}
+		for _, reservation := range reservations {
+			week := weekStart(reservation.StartTime)
+			weeks[week] += hoursWithin(reservation.StartTime, reservation.EndTime, week, week.AddDate(0, 0, 7))
+		}
+		for week, hours := range weeks {
+			booked, err := hoursInWeek(ctx, tx, userId, week)
+			if err != nil {
+				return err
+			}
+			if booked+hours > *quota.MaxHoursPerWeek {
+				return &QuotaError{Reason: fmt.Sprintf(
+					"You have %.1f hours reserved the week of %s, the most allowed is %.1f.",
+					booked, week.Format("2 Jan"), *quota.MaxHoursPerWeek)}
+			}
+		}
+	}
+
+	if quota.MaxPerRoomPerDay != nil {
+		type roomDay struct {
+			roomId int32
+			day    time.Time
+		}
+		days := map[roomDay]int32{}
+		for _, reservation := range reservations {
+			start := reservation.StartTime
+			days[roomDay{reservation.RoomId, time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())}]++
+		}
+		for key, count := range days {
+			var booked int32
+			err = tx.QueryRow(ctx, `
+				SELECT
+					COUNT(*)
+				FROM
+					reservation
+				WHERE
+					user_id = $1
+				AND
+					room_id = $2
+				AND
+					start_time >= $3
+				AND
+					start_time < $4
+				AND
+					`+counted+`
+			`, userId, key.roomId, key.day, key.day.AddDate(0, 0, 1)).Scan(&booked)
+			if err != nil {
+				return err
+			}
+			if booked+count > *quota.MaxPerRoomPerDay {
+				return &QuotaError{Reason: fmt.Sprintf(
+					"You already have %d reservations of room %d on %s, the most allowed is %d.",
+					booked, key.roomId, key.day.Format("2 Jan"), *quota.MaxPerRoomPerDay)}
+			}
+		}
+	}
+
+	return nil
+}
+
+// effectiveQuota gets the limits that apply to a user. Each limit is taken from the most
+// specific quota that sets it: the user's own, then their team's, then their role's, then
+// the one for everyone
+func effectiveQuota(ctx context.Context, q querier, userId int32) (models.Quota, error) {
+	rows, err := q.Query(ctx, `
+		SELECT
+			quota.id, quota.user_id, COALESCE(quota.team, ''), COALESCE(quota.role, ''),
+			quota.max_future_reservations, quota.max_hours_per_week, quota.max_per_room_per_day
+		FROM
+			quota
+		JOIN
+			users ON users.id = $1
+		WHERE
+			quota.user_id = users.id
+		OR
+			quota.team = users.team
+		OR
+			quota.role = users.role
+		OR
+			(quota.user_id IS NULL AND quota.team IS NULL AND quota.role IS NULL)
+		ORDER BY
+			CASE
+				WHEN quota.user_id IS NOT NULL THEN 0
+				WHEN quota.team IS NOT NULL THEN 1
+				WHEN quota.role IS NOT NULL THEN 2
+				ELSE 3
+			END
+	`, userId)
+	if err != nil {
+		return models.Quota{}, err
+	}
+
+	quotas, err := scanQuotas(rows)
+	if err != nil {
+		return models.Quota{}, err
+	}
+
+	effective := models.Quota{UserId: &userId}
+	for _, quota := range quotas {
+		if effective.MaxFutureReservations == nil {
+			effective.MaxFutureReservations = quota.MaxFutureReservations
+		}
+		if effective.MaxHoursPerWeek == nil {
+			effective.MaxHoursPerWeek = quota.MaxHoursPerWeek
+		}
+		if effective.MaxPerRoomPerDay == nil {
+			effective.MaxPerRoomPerDay = quota.MaxPerRoomPerDay
+		}
+	}
+
+	return effective, nil
+}
+
+// futureReservations counts a user's reservations that have not ended yet
+func futureReservations(ctx context.Context, q querier, userId int32) (int32, error) {
+	var count int32
+	err := q.QueryRow(ctx, `
+		SELECT
+			COUNT(*)
+		FROM
+			reservation
+		WHERE
+			user_id = $1
+		AND
+			expired = false
+		AND
+			(end_time IS NULL OR end_time > NOW())
+		AND
+			`+counted+`
+	`, userId).Scan(&count)
+
+	return count, err
+}
+
+// hoursInWeek gets how many hours a user has reserved in the week starting at the time
+// supplied. Reservations without an end count until the end of the week
+func hoursInWeek(ctx context.Context, q querier, userId int32, week time.Time) (float64, error) {
+	var hours float64
+	err := q.QueryRow(ctx, `
+		SELECT
+			COALESCE(SUM(EXTRACT(EPOCH FROM LEAST(COALESCE(end_time, $3), $3) - GREATEST(start_time, $2))), 0)::float8 / 3600
+		FROM
+			reservation
+		WHERE
+			user_id = $1
+		AND
+			start_time < $3
+		AND
+			(end_time IS NULL OR end_time > $2)
+		AND
+			`+counted+`
+	`, userId, week, week.AddDate(0, 0, 7)).Scan(&hours)
+
+	return hours, err
+}
+
+// hoursWithin gets how many hours of a reservation fall between from and to. A reservation
+// without an end runs until to
+func hoursWithin(start time.Time, end *time.Time, from time.Time, to time.Time) float64 {
+	finish := to
+	if end != nil && end.Before(to) {
+		finish = *end
+	}
+	if start.Before(from) {
+		start = from
+	}
+	if !finish.After(start) {
+		return 0
+	}
+
+	return finish.Sub(start).Hours()
+}
+
+// weekStart gets midnight on the Monday of the week the time supplied is in
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// scanQuotas reads quota rows and closes them
+func scanQuotas(rows pgx.Rows) ([]models.Quota, error) {
+	defer rows.Close()
+
+	quotas := []models.Quota{}
+	for rows.Next() {
+		var quota models.Quota
+		err := rows.Scan(&quota.Id, &quota.UserId, &quota.Team, &quota.Role,
+			&quota.MaxFutureReservations, &quota.MaxHoursPerWeek, &quota.MaxPerRoomPerDay)
+		if err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, quota)
+	}
+
+	return quotas, rows.Err()
+}
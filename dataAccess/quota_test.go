@@ -0,0 +1,56 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQuota(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	userId := int32(1)
+	maxFuture := int32(1)
+	err := SetQuota(ctx, &models.Quota{UserId: &userId, MaxFutureReservations: &maxFuture}, db)
+	if err != nil {
+		t.Fatalf("Error setting quota: %s", err.Error())
+	}
+
+	start := time.Now().Add(24 * time.Hour)
+	_, conflicts, err := ReserveRooms(ctx, []int32{1}, 1, 1, start, 60, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+	if len(conflicts) > 0 {
+		t.Fatalf("Room 1 should be free")
+	}
+
+	usage, err := GetQuotaUsage(ctx, 1, db)
+	if err != nil {
+		t.Fatalf("Error getting quota usage: %s", err.Error())
+	}
+	if usage.RemainingFutureReservations == nil || *usage.RemainingFutureReservations != 0 {
+		t.Errorf("User 1 should have no future reservations left")
+	}
+
+	_, _, err = ReserveRooms(ctx, []int32{2}, 1, 1, start, 60, models.ReservationDetails{}, db)
+	var quotaErr *QuotaError
+	if !errors.As(err, &quotaErr) {
+		t.Errorf("Reservation should have gone over the quota, got %v", err)
+	}
+
+	// the quota is only for user 1
+	_, _, err = ReserveRooms(ctx, []int32{2}, 3, 3, start, 60, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+}
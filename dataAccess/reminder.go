@@ -0,0 +1,66 @@
+/*
+	Class that holds the data access functions for reminding users about their reservations.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// AddReminder stores when to remind the user about a reservation. It is kept in the database
+// rather than in memory so the reminder is still sent if the service restarts before then
+func AddReminder(ctx context.Context, reservationId int32, remindAt time.Time, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	_, err := db.Exec(ctx, `
+		INSERT INTO reminder (reservation_id, remind_at)
+		VALUES ($1, $2)
+		ON CONFLICT (reservation_id) DO UPDATE SET remind_at = EXCLUDED.remind_at
+	`, reservationId, remindAt)
+	return err
+}
+
+// DueReminders removes the reminders that are due and gets the reservations they are for.
+// Reservations that were cancelled, ended, displaced or have already started are left out
+// so no one is reminded about a reservation they no longer have
+func DueReminders(ctx context.Context, db *pgxpool.Pool) ([]models.Reservation, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		WITH due AS (
+			DELETE FROM reminder
+			WHERE remind_at <= NOW()
+			RETURNING reservation_id
+		)
+		SELECT
+			`+reservationColumns+`
+		FROM
+			reservation
+		WHERE
+			id IN (SELECT reservation_id FROM due)
+			AND status = 'confirmed'
+			AND expired = false
+			AND start_time > NOW()
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanReservations(rows)
+}
@@ -0,0 +1,71 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDueReminders(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	// reservations in an hour in rooms 1 and 2, with reminders that are due
+	var reservationIds []int32
+	for _, roomId := range []int32{1, 2} {
+		holdId, held, err := PlaceHold(ctx, roomId, 1, 1, time.Now().Add(time.Hour), nil, time.Now().Add(time.Minute), models.ReservationDetails{}, db)
+		if err != nil || !held {
+			t.Fatalf("Room %d should have been held, got %v", roomId, err)
+		}
+
+		confirmed, err := ConfirmHold(ctx, holdId, 1, db)
+		if err != nil || !confirmed {
+			t.Fatalf("Hold on room %d should have been confirmed, got %v", roomId, err)
+		}
+
+		err = AddReminder(ctx, holdId, time.Now().Add(-time.Second), db)
+		if err != nil {
+			t.Fatalf("Error adding reminder: %s", err.Error())
+		}
+		reservationIds = append(reservationIds, holdId)
+	}
+
+	// a reminder that is not due yet
+	err := AddReminder(ctx, reservationIds[0], time.Now().Add(time.Hour), db)
+	if err != nil {
+		t.Fatalf("Error adding reminder: %s", err.Error())
+	}
+
+	due, err := DueReminders(ctx, db)
+	if err != nil {
+		t.Fatalf("Error getting due reminders: %s", err.Error())
+	}
+	if len(due) != 1 || due[0].Id != reservationIds[1] {
+		t.Fatalf("Only the reminder for room 2 should be due, got %v", due)
+	}
+
+	// the reservation in room 1 is cancelled before its reminder is due
+	err = DeleteReservation(ctx, 1, db)
+	if err != nil {
+		t.Fatalf("Error deleting reservation: %s", err.Error())
+	}
+	err = AddReminder(ctx, reservationIds[0], time.Now().Add(-time.Second), db)
+	if err == nil {
+		t.Errorf("A reminder should not be added for a reservation that no longer exists")
+	}
+
+	due, err = DueReminders(ctx, db)
+	if err != nil {
+		t.Fatalf("Error getting due reminders: %s", err.Error())
+	}
+	if len(due) != 0 {
+		t.Errorf("Reminders should only be sent once, and not for cancelled reservations, got %v", due)
+	}
+}
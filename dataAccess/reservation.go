@@ -7,19 +7,42 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
+	"avaros/metrics"
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
+// querier runs queries on either the pool or a transaction
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// reservationColumns are the columns scanReservations reads, in order
+const reservationColumns = `id, room_id, user_id, created_by, start_time, end_time, expired, status, hold_expires,
+	approver_id, approval_expires, COALESCE(decision_reason, ''), COALESCE(title, ''),
+	COALESCE(description, ''), private, priority`
+
+// bufferGap is the time the room being queried needs between one reservation ending and the next starting
+const bufferGap = `(room.buffer_before + room.buffer_after) * INTERVAL '1 minute'`
+
 //CheckReservation checks that a reservation for a given room exists
-func CheckReservation(roomId int32, db *pgxpool.Pool) (bool, error) {
+func CheckReservation(ctx context.Context, roomId int32, db *pgxpool.Pool) (bool, error) {
 	if db == nil {
 		return false, errors.New("Database instance empty")
 	}
 
-	//query for reservations on the room
-	rows, err := db.Query(context.Background(), `
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	//query for reservations on the room. Reservations that have not
+	//started yet or are past their end time do not count
+	rows, err := db.Query(ctx, `
 		SELECT 
 			id 
 		FROM
@@ -28,6 +51,12 @@ func CheckReservation(roomId int32, db *pgxpool.Pool) (bool, error) {
 			room_id = $1 
 		AND 
 			expired = false
+		AND
+			start_time <= NOW()
+		AND
+			(end_time IS NULL OR end_time > NOW())
+		AND
+			(status != 'hold' OR hold_expires > NOW())
 	`, roomId)
 	if err != nil {
 		return false, err
@@ -39,100 +68,568 @@ func CheckReservation(roomId int32, db *pgxpool.Pool) (bool, error) {
 	return isNext, nil
 }
 
-// Reserve creates a reservation for a room. If an expiry time is supplied,
-// a thread opens that will count down the time from the reservation
-func Reserve(roomId int32, expiryTime int, db *pgxpool.Pool) (int32, error) {
-	id := -1
-	// set the start time of the reservation
-	startTime := time.Now()
-	err := db.QueryRow(context.Background(), `
-	INSERT INTO 
-		reservation (room_id, start_time)
-	VALUES 
-		($1, $2)
-	RETURNING id
-	`, roomId, startTime).Scan(&id)
+// CheckReservationOverlap checks if a room has a reservation that overlaps the time supplied,
+// or is closed for any of it. If there is no end time the reservation runs until it is deleted,
+// so anything after the start time overlaps
+func CheckReservationOverlap(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	return slotTaken(ctx, db, roomId, startTime, endTime)
+}
+
+// slotTaken checks if a room cannot be reserved for the time supplied, either because it is
+// closed or something else overlaps it
+func slotTaken(ctx context.Context, q querier, roomId int32, startTime time.Time, endTime *time.Time) (bool, error) {
+	isClosed, err := closed(ctx, q, roomId, startTime, endTime)
+	if err != nil || isClosed {
+		return isClosed, err
+	}
+
+	ids, err := overlappingReservations(ctx, q, roomId, startTime, endTime)
+	if err != nil {
+		return false, err
+	}
+
+	return len(ids) > 0, nil
+}
+
+// overlappingReservations gets the ids of the reservations on a room that overlap the time supplied.
+// The room's buffers are kept free between reservations, so the teardown after one and the setup
+// before the next cannot overlap. A reservation that has ended still blocks its teardown time
+func overlappingReservations(ctx context.Context, q querier, roomId int32, startTime time.Time, endTime *time.Time) ([]int32, error) {
+	rows, err := q.Query(ctx, `
+		SELECT
+			reservation.id
+		FROM
+			reservation
+		JOIN
+			room ON room.id = reservation.room_id
+		WHERE
+			reservation.room_id = $1
+		AND
+			(expired = false OR (status = 'confirmed' AND end_time + `+bufferGap+` > $2))
+		AND
+			($3::timestamp IS NULL OR start_time < $3 + `+bufferGap+`)
+		AND
+			(end_time IS NULL OR end_time + `+bufferGap+` > $2)
+		AND
+			(status != 'hold' OR hold_expires > NOW())
+	`, roomId, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int32{}
+	for rows.Next() {
+		var id int32
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// GetReservation gets a reservation and who is invited to it
+func GetReservation(ctx context.Context, id int32, db *pgxpool.Pool) (models.Reservation, error) {
+	if db == nil {
+		return models.Reservation{}, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			`+reservationColumns+`
+		FROM
+			reservation
+		WHERE
+			id = $1
+	`, id)
+	if err != nil {
+		return models.Reservation{}, err
+	}
 
+	reservations, err := scanReservations(rows)
 	if err != nil {
-		panic("Error reserving room: " + err.Error())
+		return models.Reservation{}, err
 	}
+	if len(reservations) == 0 {
+		return models.Reservation{}, pgx.ErrNoRows
+	}
+
+	err = loadAttendees(ctx, db, reservations)
+	return reservations[0], err
+}
 
-	// if the expiry time is provided then fire off a thread that
-	// will handle expiring the reservation
-	if expiryTime > 0 {
-		go expireReservation(expiryTime, int32(id), db)
+// ErrSlotTaken is returned when a room was reserved, or closed, for some of the time asked
+// for after it was checked
+var ErrSlotTaken = errors.New("The room is already reserved or closed for that time")
+
+// Reserve creates a reservation for a room for the user, made by createdBy. If an expiry
+// time is supplied, a thread opens that will count down the time from the reservation.
+// Returns ErrSlotTaken if the room was reserved or closed since it was checked
+func Reserve(ctx context.Context, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails, db *pgxpool.Pool) (int32, error) {
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	reservation, err := reserve(ctx, roomId, userId, createdBy, expiryTime, details, db)
+	if err != nil {
+		return -1, err
 	}
+
+	reservationCreated(ctx, reservation, db)
 	// return the id of the reservation
-	return int32(id), nil
+	return reservation.Id, nil
+}
+
+// reserve inserts the reservation for Reserve without letting the user know or
+// scheduling it. Returns ErrSlotTaken if something overlaps it and a QuotaError if it
+// would take the user over their quota
+func reserve(ctx context.Context, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails, db *pgxpool.Pool) (models.Reservation, error) {
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	reservation := models.Reservation{
+		RoomId:    roomId,
+		UserId:    userId,
+		CreatedBy: createdBy,
+		// set the start time of the reservation
+		StartTime:          time.Now(),
+		Status:             models.ReservationConfirmed,
+		ReservationDetails: details,
+	}
+	reservation.EndTime = EndTime(reservation.StartTime, expiryTime)
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return reservation, err
+	}
+	defer tx.Rollback(ctx)
+
+	// lock the room so nothing else can reserve it until this is done, then check nothing
+	// was reserved since the caller checked
+	found, err := lockRooms(ctx, tx, []int32{roomId})
+	if err != nil {
+		return reservation, err
+	}
+	if !found[roomId] {
+		return reservation, fmt.Errorf("Room with id %d does not exist", roomId)
+	}
+
+	taken, err := slotTaken(ctx, tx, roomId, reservation.StartTime, reservation.EndTime)
+	if err != nil {
+		return reservation, err
+	}
+	if taken {
+		return reservation, ErrSlotTaken
+	}
+
+	err = checkQuota(ctx, tx, userId, []models.Reservation{reservation})
+	if err != nil {
+		return reservation, err
+	}
+
+	err = insertReservation(ctx, tx, &reservation)
+	if err != nil {
+		return reservation, err
+	}
+
+	return reservation, tx.Commit(ctx)
 }
 
 // DeleteReservation deletes a reservation for a room
-func DeleteReservation(roomId int32, db *pgxpool.Pool) error {
+func DeleteReservation(ctx context.Context, roomId int32, db *pgxpool.Pool) error {
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
 
-	_, err := db.Exec(context.Background(), `
+	rows, err := db.Query(ctx, `
 	DELETE 
 	FROM 
 		reservation
 	WHERE 
 		room_id = $1
+	RETURNING `+reservationColumns+`
 	`, roomId)
 
 	if err != nil {
 		return err
 	}
 
+	cancelled, err := scanReservations(rows)
+	if err != nil {
+		return err
+	}
+
+	// let anyone whose reservation was still going know it has been cancelled
+	released := false
+	for _, reservation := range cancelled {
+		if !reservation.Expired && reservation.Status == models.ReservationConfirmed {
+			metrics.Bookings.WithLabelValues(metrics.BookingCancelled).Inc()
+			notifier.ReservationCancelled(ctx, reservation)
+			released = true
+		}
+	}
+
+	// give the freed up time to anyone waiting on it
+	if released {
+		background(ctx, "process waitlist", func(ctx context.Context) { ProcessWaitlist(ctx, roomId, db) })
+	}
+
 	return nil
 }
 
-// expireReservation starts a timer to expire a reservation after the supplied
-// number of minutes. Should only be opened in a thread
-func expireReservation(numMins int, reservationId int32, db *pgxpool.Pool) {
-	for {
-		select {
-		// thread will run and after the number of minutes provided, will
-		// expire the reservation
-		case <-time.After(time.Minute * time.Duration(numMins)):
-			endTime := time.Now()
-			_, err := db.Exec(context.Background(), `
-				UPDATE reservation
-				SET expired = true, end_time = $2
-				WHERE id = $1
-			`, reservationId, endTime)
-			if err != nil {
-				panic("Error updating the reservations expiry: " + err.Error())
-			}
-			return
+// EndReservation ends the reservation a room currently has early, freeing the room up
+// for anyone waiting on it. Returns false if the room has no current reservation
+func EndReservation(ctx context.Context, roomId int32, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		UPDATE reservation
+		SET expired = true, end_time = $2
+		WHERE room_id = $1
+		AND expired = false
+		AND status = 'confirmed'
+		AND start_time <= $2
+		AND (end_time IS NULL OR end_time > $2)
+		RETURNING `+reservationColumns+`
+	`, roomId, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	ended, err := scanReservations(rows)
+	if err != nil {
+		return false, err
+	}
+
+	for _, reservation := range ended {
+		metrics.Bookings.WithLabelValues(metrics.BookingEnded).Inc()
+		notifier.ReservationExpired(ctx, reservation)
+	}
+
+	if len(ended) > 0 {
+		background(ctx, "process waitlist", func(ctx context.Context) { ProcessWaitlist(ctx, roomId, db) })
+	}
+
+	return len(ended) > 0, nil
+}
+
+// ReserveRooms reserves every room supplied for the same time in a single transaction.
+// Either every room is reserved and the ids of the reservations are returned, or none
+// are and the ids of the rooms that already have a reservation or are closed then are returned.
+// Reservations with a lower priority than the details supplied are displaced rather than
+// conflicting, and their organizers are let know along with some other rooms they could use.
+// Returns a QuotaError if the reservations would take the user over their quota, and
+// ErrNoApprover if a room needs approval but has no approver
+func ReserveRooms(ctx context.Context, roomIds []int32, userId int32, createdBy int32, startTime time.Time, expiryTime int, details models.ReservationDetails, db *pgxpool.Pool) ([]int32, []int32, error) {
+	if db == nil {
+		return nil, nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// lock the rooms so nothing else can reserve them until this is done
+	found, err := lockRooms(ctx, tx, roomIds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endTime := EndTime(startTime, expiryTime)
+
+	conflicts := []int32{}
+	displaced := map[int32][]int32{}
+	for _, roomId := range roomIds {
+		if !found[roomId] {
+			return nil, nil, fmt.Errorf("Room with id %d does not exist", roomId)
+		}
+
+		bumpable, ok, err := preemptable(ctx, tx, roomId, startTime, endTime, details.Priority)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			conflicts = append(conflicts, roomId)
+		}
+		displaced[roomId] = bumpable
+	}
+
+	if len(conflicts) > 0 {
+		return nil, conflicts, nil
+	}
+
+	reservations := []models.Reservation{}
+	for _, roomId := range roomIds {
+		reservations = append(reservations, models.Reservation{
+			RoomId:             roomId,
+			UserId:             userId,
+			CreatedBy:          createdBy,
+			StartTime:          startTime,
+			EndTime:            endTime,
+			Status:             models.ReservationConfirmed,
+			ReservationDetails: details,
+		})
+	}
+
+	err = checkQuota(ctx, tx, userId, reservations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bumps := []bumped{}
+	for i := range reservations {
+		err = insertReservation(ctx, tx, &reservations[i])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		roomBumps, err := bump(ctx, tx, displaced[reservations[i].RoomId], reservations[i])
+		if err != nil {
+			return nil, nil, err
 		}
+		bumps = append(bumps, roomBumps...)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := []int32{}
+	for _, reservation := range reservations {
+		reservationCreated(ctx, reservation, db)
+		ids = append(ids, reservation.Id)
 	}
+
+	for _, b := range bumps {
+		metrics.Bookings.WithLabelValues(metrics.BookingBumped).Inc()
+		notifier.ReservationBumped(ctx, b.reservation, b.alternatives)
+	}
+
+	return ids, nil, nil
 }
 
-// CreateFutureReservation starts a timer to create a reservation after the supplied
-// number of minutes. Should only be opened in a thread. Calls Reserve, which handles
-// the rest of the reserve workflow
-func CreateFutureReservation(timeInFuture float64, roomId int32, expiryTime int, db *pgxpool.Pool) {
-	fmt.Println(time.Duration(timeInFuture))
-	for {
-		select {
-		// Create the reservation in the future
-		case <-time.After(time.Minute * time.Duration(timeInFuture)):
-			exists, err := CheckReservation(roomId, db)
-			if exists {
-				fmt.Println("Reservation already exists")
-				return
-			}
-			_, err = Reserve(roomId, expiryTime, db)
-			if err != nil {
-				panic("Error creating reservation: " + err.Error())
-			}
+// lockRooms locks the rows of the rooms supplied until the transaction ends so only one
+// transaction at a time can reserve them. Returns which of the rooms exist
+func lockRooms(ctx context.Context, tx pgx.Tx, roomIds []int32) (map[int32]bool, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT
+			id
+		FROM
+			room
+		WHERE
+			id = ANY($1)
+		ORDER BY id
+		FOR UPDATE
+	`, roomIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := map[int32]bool{}
+	for rows.Next() {
+		var id int32
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		found[id] = true
+	}
+
+	return found, rows.Err()
+}
+
+// insertReservation inserts a reservation and sets its id. A confirmed reservation on a
+// room that needs approval is made pending instead. A reservation with no creator was made
+// by its organizer. reservationCreated should be called for it once any transaction it is
+// part of is committed
+func insertReservation(ctx context.Context, q querier, reservation *models.Reservation) error {
+	if reservation.CreatedBy == 0 {
+		reservation.CreatedBy = reservation.UserId
+	}
+
+	if reservation.Status == models.ReservationConfirmed {
+		err := applyApproval(ctx, q, reservation)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := q.QueryRow(ctx, `
+		INSERT INTO
+			reservation (room_id, user_id, created_by, start_time, end_time, status, hold_expires,
+				approver_id, approval_expires, title, description, private, priority)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id
+	`, reservation.RoomId, reservation.UserId, reservation.CreatedBy, reservation.StartTime, reservation.EndTime,
+		reservation.Status, reservation.HoldExpires, reservation.ApproverId,
+		reservation.ApprovalExpires, reservation.Title, reservation.Description,
+		reservation.Private, reservation.Priority).Scan(&reservation.Id)
+	if err != nil {
+		return err
+	}
+
+	reservation.Attendees, err = insertAttendees(ctx, q, reservation.Id, reservation.Attendees)
+	return err
+}
+
+// reservationCreated schedules a new reservation and lets the user know about it, or if
+// it needs approval, lets the approver know about it
+func reservationCreated(ctx context.Context, reservation models.Reservation, db *pgxpool.Pool) {
+	switch reservation.Status {
+	case models.ReservationConfirmed:
+		metrics.Bookings.WithLabelValues(metrics.BookingCreated).Inc()
+		scheduleReservation(ctx, reservation, db)
+		notifier.ReservationBooked(ctx, reservation)
+	case models.ReservationPending:
+		metrics.Bookings.WithLabelValues(metrics.BookingCreated).Inc()
+		notifier.ApprovalRequested(ctx, reservation)
+	}
+}
+
+// scheduleReservation fires off the threads that start and expire a reservation at its
+// start and end times
+func scheduleReservation(ctx context.Context, reservation models.Reservation, db *pgxpool.Pool) {
+	if reservation.StartTime.After(time.Now()) {
+		backgroundAt(ctx, "start reservation", reservation.StartTime, func(ctx context.Context) { startReservation(ctx, reservation.Id, db) })
+	}
+	if reservation.EndTime != nil {
+		backgroundAt(ctx, "expire reservation", *reservation.EndTime, func(ctx context.Context) { expireReservation(ctx, reservation.Id, db) })
+	}
+}
+
+// startReservation touches a reservation when its start time comes so the change is
+// recorded and anyone watching the room sees it become reserved. Should only be run by backgroundAt
+func startReservation(ctx context.Context, reservationId int32, db *pgxpool.Pool) {
+	_, err := db.Exec(ctx, `
+		UPDATE reservation
+		SET start_time = start_time
+		WHERE id = $1
+		AND expired = false
+	`, reservationId)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error starting reservation", "reservationId", reservationId, "error", err)
+	}
+}
+
+// expireReservation expires a reservation once its end time comes. Should only be run by backgroundAt
+func expireReservation(ctx context.Context, reservationId int32, db *pgxpool.Pool) {
+	endTime := time.Now()
+	rows, err := db.Query(ctx, `
+		UPDATE reservation
+		SET expired = true, end_time = $2
+		WHERE id = $1
+		AND expired = false
+		RETURNING `+reservationColumns+`
+	`, reservationId, endTime)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error expiring reservation", "reservationId", reservationId, "error", err)
+		return
+	}
+
+	expired, err := scanReservations(rows)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error expiring reservation", "reservationId", reservationId, "error", err)
+		return
+	}
+
+	// nothing is returned if the reservation was deleted in the meantime
+	for _, reservation := range expired {
+		metrics.Bookings.WithLabelValues(metrics.BookingExpired).Inc()
+		notifier.ReservationExpired(ctx, reservation)
+		ProcessWaitlist(ctx, reservation.RoomId, db)
+	}
+}
+
+// CreateFutureReservation starts a thread to create a reservation after the supplied
+// number of minutes, unless shutting down first. Calls reserve, which handles the rest
+// of the reserve workflow. The user is only let know about the booking once it is created,
+// as the room may be taken or their quota used up by then. The context is only used for
+// the request id to log with
+func CreateFutureReservation(ctx context.Context, timeInFuture float64, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails, db *pgxpool.Pool) {
+	startTime := time.Now().Add(time.Minute * time.Duration(timeInFuture))
+	backgroundAt(ctx, "future reservation", startTime, func(ctx context.Context) {
+		reservation, err := reserve(ctx, roomId, userId, createdBy, expiryTime, details, db)
+		if errors.Is(err, ErrSlotTaken) {
+			slog.InfoContext(ctx, "Future reservation not created, the room is already reserved", "roomId", roomId)
+			return
+		}
+		var quotaErr *QuotaError
+		if errors.As(err, &quotaErr) {
+			slog.InfoContext(ctx, "Future reservation not created", "roomId", roomId, "reason", quotaErr.Reason)
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Error creating future reservation", "roomId", roomId, "error", err)
 			return
 		}
+
+		reservationCreated(ctx, reservation, db)
+	})
+}
+
+// EndTime gets when a reservation starting at the time supplied ends. Reservations
+// without an expiry time have no end time
+func EndTime(startTime time.Time, expiryTime int) *time.Time {
+	if expiryTime <= 0 {
+		return nil
 	}
+
+	endTime := startTime.Add(time.Minute * time.Duration(expiryTime))
+	return &endTime
+}
+
+// scanReservations reads reservation rows in the order of reservationColumns and closes them
+func scanReservations(rows pgx.Rows) ([]models.Reservation, error) {
+	defer rows.Close()
+
+	reservations := []models.Reservation{}
+	for rows.Next() {
+		var reservation models.Reservation
+		err := rows.Scan(&reservation.Id, &reservation.RoomId, &reservation.UserId,
+			&reservation.CreatedBy, &reservation.StartTime, &reservation.EndTime, &reservation.Expired,
+			&reservation.Status, &reservation.HoldExpires, &reservation.ApproverId,
+			&reservation.ApprovalExpires, &reservation.DecisionReason, &reservation.Title,
+			&reservation.Description, &reservation.Private, &reservation.Priority)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations, rows.Err()
 }
 
 // CheckRoomExists checks if a room id supplied is in the database to reserve
-func CheckRoomExists(id int32, db *pgxpool.Pool) (bool, error) {
+func CheckRoomExists(ctx context.Context, id int32, db *pgxpool.Pool) (bool, error) {
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
 	//query for reservations on the room
-	rows, err := db.Query(context.Background(), `
+	rows, err := db.Query(ctx, `
 		SELECT 
 			id 
 		FROM
@@ -5,19 +5,24 @@ package dataAccess
 
 import (
 	database "avaros/database"
+	models "avaros/models"
 	test "avaros/test"
 
+	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 )
 
 func TestReservation(t *testing.T) {
+	ctx := context.Background()
 	db := test.NewDatabase()
 	defer test.CloseDb(db)
 
 	database.Seed(db)
 
-	reservationExists, err := CheckReservation(1, db)
+	reservationExists, err := CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a room: %s", err.Error())
 	}
@@ -26,12 +31,12 @@ func TestReservation(t *testing.T) {
 		t.Errorf("No reservations should exist")
 	}
 
-	_, err = Reserve(1, 0, db)
+	_, err = Reserve(ctx, 1, 1, 1, 0, models.ReservationDetails{}, db)
 	if err != nil {
 		t.Errorf("Error reserving a room: %s", err.Error())
 	}
 
-	reservationExists, err = CheckReservation(1, db)
+	reservationExists, err = CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a room: %s", err.Error())
 	}
@@ -41,18 +46,53 @@ func TestReservation(t *testing.T) {
 	}
 }
 
+func TestConcurrentReservations(t *testing.T) {
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	// only one of the reservations made at the same time gets the room
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := Reserve(context.Background(), 1, 1, 1, 60, models.ReservationDetails{}, db)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	reserved := 0
+	for err := range errs {
+		if err == nil {
+			reserved++
+		} else if !errors.Is(err, ErrSlotTaken) {
+			t.Errorf("Error reserving a room: %s", err.Error())
+		}
+	}
+
+	if reserved != 1 {
+		t.Errorf("Room 1 should be reserved once, got %d", reserved)
+	}
+}
+
 func TestDeleteReservation(t *testing.T) {
+	ctx := context.Background()
 	db := test.NewDatabase()
 	defer test.CloseDb(db)
 
 	database.Seed(db)
 
-	_, err := Reserve(1, 0, db)
+	_, err := Reserve(ctx, 1, 1, 1, 0, models.ReservationDetails{}, db)
 	if err != nil {
 		t.Errorf("Error reserving a room: %s", err.Error())
 	}
 
-	reservationExists, err := CheckReservation(1, db)
+	reservationExists, err := CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a reservation: %s", err.Error())
 	}
@@ -61,12 +101,12 @@ func TestDeleteReservation(t *testing.T) {
 		t.Errorf("Reservation for room 1 should exist")
 	}
 
-	err = DeleteReservation(1, db)
+	err = DeleteReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error deleting a reservation: %s", err.Error())
 	}
 
-	reservationExists, err = CheckReservation(1, db)
+	reservationExists, err = CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a reservation: %s", err.Error())
 	}
@@ -77,12 +117,13 @@ func TestDeleteReservation(t *testing.T) {
 }
 
 func TestCheckRoomExists(t *testing.T) {
+	ctx := context.Background()
 	db := test.NewDatabase()
 	defer test.CloseDb(db)
 
 	database.Seed(db)
 
-	exists, err := CheckRoomExists(1, db)
+	exists, err := CheckRoomExists(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a room: %s", err.Error())
 	}
@@ -93,14 +134,15 @@ func TestCheckRoomExists(t *testing.T) {
 }
 
 func TestCreateFutureReservation(t *testing.T) {
+	ctx := context.Background()
 	db := test.NewDatabase()
 	defer test.CloseDb(db)
 
 	database.Seed(db)
 
-	go CreateFutureReservation(1, 1, 0, db)
+	CreateFutureReservation(ctx, 1, 1, 1, 1, 0, models.ReservationDetails{}, db)
 
-	reservationExists, err := CheckReservation(1, db)
+	reservationExists, err := CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a reservation: %s", err.Error())
 	}
@@ -111,7 +153,7 @@ func TestCreateFutureReservation(t *testing.T) {
 
 	time.Sleep(70 * time.Minute)
 
-	reservationExists, err = CheckReservation(1, db)
+	reservationExists, err = CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a reservation: %s", err.Error())
 	}
@@ -122,17 +164,18 @@ func TestCreateFutureReservation(t *testing.T) {
 }
 
 func TestExpireReservation(t *testing.T) {
+	ctx := context.Background()
 	db := test.NewDatabase()
 	defer test.CloseDb(db)
 
 	database.Seed(db)
 
-	_, err := Reserve(1, 1, db)
+	_, err := Reserve(ctx, 1, 1, 1, 1, models.ReservationDetails{}, db)
 	if err != nil {
 		t.Errorf("Error reserving a room: %s", err.Error())
 	}
 
-	reservationExists, err := CheckReservation(1, db)
+	reservationExists, err := CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a reservation: %s", err.Error())
 	}
@@ -143,7 +186,7 @@ func TestExpireReservation(t *testing.T) {
 
 	time.Sleep(80 * time.Second)
 
-	reservationExists, err = CheckReservation(1, db)
+	reservationExists, err = CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a reservation: %s", err.Error())
 	}
@@ -152,3 +195,36 @@ func TestExpireReservation(t *testing.T) {
 		t.Errorf("Reservation for room 1 should have expired")
 	}
 }
+
+func TestReservationBuffer(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	// room 3 needs 15 minutes after each reservation
+	startTime := time.Now()
+	_, err := Reserve(ctx, 3, 1, 1, 30, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+
+	overlaps, err := CheckReservationOverlap(ctx, 3, startTime.Add(40*time.Minute), nil, db)
+	if err != nil {
+		t.Errorf("Error checking for an overlap: %s", err.Error())
+	}
+
+	if !overlaps {
+		t.Errorf("Reservation should not start during the buffer after another")
+	}
+
+	overlaps, err = CheckReservationOverlap(ctx, 3, startTime.Add(46*time.Minute), nil, db)
+	if err != nil {
+		t.Errorf("Error checking for an overlap: %s", err.Error())
+	}
+
+	if overlaps {
+		t.Errorf("Reservation should be able to start once the buffer has passed")
+	}
+}
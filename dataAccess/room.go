@@ -0,0 +1,46 @@
+/*
+	Class that holds the data access functions for rooms.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// roomColumns are the columns scanRoom reads, in order
+const roomColumns = `room.id, COALESCE(room.name, ''), COALESCE(room.building, ''), COALESCE(room.site, ''),
+	room.floor, room.amenities, room.requires_approval, room.approver_id, room.capacity, room.buffer_before, room.buffer_after`
+
+// GetRoom gets a room by its id
+func GetRoom(ctx context.Context, id int32, db *pgxpool.Pool) (models.Room, error) {
+	room := models.Room{}
+	if db == nil {
+		return room, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	err := scanRoom(db.QueryRow(ctx, `
+		SELECT
+			`+roomColumns+`
+		FROM
+			room
+		WHERE
+			id = $1
+	`, id), &room)
+
+	return room, err
+}
+
+// scanRoom reads a room row in the order of roomColumns
+func scanRoom(row pgx.Row, room *models.Room) error {
+	return row.Scan(&room.Id, &room.Name, &room.Building, &room.Site, &room.Floor, &room.Amenities,
+		&room.RequiresApproval, &room.ApproverId, &room.Capacity, &room.BufferBefore, &room.BufferAfter)
+}
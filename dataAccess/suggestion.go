@@ -0,0 +1,223 @@
+/*
+	Class that holds the data access functions for suggesting alternatives to a reservation
+	that could not be made.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"avaros/calendar"
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// how many of each kind of alternative are suggested
+const suggestionsPerKind = 3
+
+// how far either side of the time asked for the room's other free times are looked for
+const suggestionWindow = 24 * time.Hour
+
+// SuggestAlternatives suggests what could be reserved instead of a room that is taken for the
+// time supplied: the same room at the nearest times it is free for as long, and rooms in the same
+// building that fit everyone and have the same amenities that are free at that time. Rooms on
+// the same floor and closest in size come first. A reservation with no end time can only
+// be moved to another room
+func SuggestAlternatives(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time, people int, db *pgxpool.Pool) (models.Suggestions, error) {
+	suggestions := models.Suggestions{
+		SameRoom:   []models.Suggestion{},
+		OtherRooms: []models.Suggestion{},
+	}
+	if db == nil {
+		return suggestions, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	room, err := GetRoom(ctx, roomId, db)
+	if err != nil {
+		return suggestions, err
+	}
+	// a room without amenities matches every other room
+	if room.Amenities == nil {
+		room.Amenities = []string{}
+	}
+
+	if endTime != nil {
+		suggestions.SameRoom, err = sameRoomTimes(ctx, db, room, startTime, *endTime)
+		if err != nil {
+			return suggestions, err
+		}
+	}
+
+	suggestions.OtherRooms, err = similarRooms(ctx, db, room, startTime, endTime, people)
+	return suggestions, err
+}
+
+// sameRoomTimes finds the nearest times to the one asked for that the room is free for as long.
+// The room becomes free right after its buffers following a reservation ends, and can be used
+// until its buffers before the next one starts, so only those times are tried
+func sameRoomTimes(ctx context.Context, q querier, room models.Room, startTime time.Time, endTime time.Time) ([]models.Suggestion, error) {
+	length := endTime.Sub(startTime)
+	gap := time.Minute * time.Duration(room.BufferBefore+room.BufferAfter)
+
+	windowEnd := endTime.Add(suggestionWindow)
+	ids, err := overlappingReservations(ctx, q, room.Id, startTime.Add(-suggestionWindow), &windowEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT
+			start_time, end_time
+		FROM
+			reservation
+		WHERE
+			id = ANY($1)
+	`, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	candidates := []time.Time{}
+	for rows.Next() {
+		var start time.Time
+		var end *time.Time
+		err = rows.Scan(&start, &end)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		candidates = append(candidates, start.Add(-gap-length))
+		if end != nil {
+			candidates = append(candidates, end.Add(gap))
+		}
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	distance := func(t time.Time) time.Duration {
+		if t.Before(startTime) {
+			return startTime.Sub(t)
+		}
+		return t.Sub(startTime)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return distance(candidates[i]) < distance(candidates[j])
+	})
+
+	suggestions := []models.Suggestion{}
+	for i, candidate := range candidates {
+		if candidate.Before(now) || candidate.Equal(startTime) || (i > 0 && candidate.Equal(candidates[i-1])) {
+			continue
+		}
+
+		candidateEnd := candidate.Add(length)
+		free, err := slotFreeAndOpen(ctx, q, room.Id, candidate, &candidateEnd)
+		if err != nil {
+			return nil, err
+		}
+		if !free {
+			continue
+		}
+
+		suggestions = append(suggestions, models.Suggestion{
+			Room:      room,
+			StartTime: candidate.Local(),
+			EndTime:   &candidateEnd,
+		})
+		if len(suggestions) == suggestionsPerKind {
+			break
+		}
+	}
+
+	return suggestions, nil
+}
+
+// similarRooms finds other rooms in the same building as the room supplied that fit everyone
+// and have at least its amenities that are free for the time supplied
+func similarRooms(ctx context.Context, q querier, room models.Room, startTime time.Time, endTime *time.Time, people int) ([]models.Suggestion, error) {
+	rows, err := q.Query(ctx, `
+		SELECT
+			`+roomColumns+`
+		FROM
+			room
+		WHERE
+			room.id != $1
+		AND
+			room.building IS NOT DISTINCT FROM NULLIF($2, '')
+		AND
+			room.amenities @> $3
+		AND
+			(room.capacity IS NULL OR room.capacity >= $4)
+		ORDER BY
+			room.floor IS NOT DISTINCT FROM $5 DESC,
+			ABS(COALESCE(room.capacity, 0) - COALESCE($6, 0)),
+			room.id
+	`, room.Id, room.Building, room.Amenities, people, room.Floor, room.Capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []models.Room{}
+	for rows.Next() {
+		var candidate models.Room
+		err = scanRoom(rows, &candidate)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	suggestions := []models.Suggestion{}
+	for _, candidate := range candidates {
+		free, err := slotFreeAndOpen(ctx, q, candidate.Id, startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+		if !free {
+			continue
+		}
+
+		suggestions = append(suggestions, models.Suggestion{
+			Room:      candidate,
+			StartTime: startTime,
+			EndTime:   endTime,
+		})
+		if len(suggestions) == suggestionsPerKind {
+			break
+		}
+	}
+
+	return suggestions, nil
+}
+
+// slotFreeAndOpen checks a room could be reserved for the time supplied, both that nothing
+// else has it and that its site is open
+func slotFreeAndOpen(ctx context.Context, q querier, roomId int32, startTime time.Time, endTime *time.Time) (bool, error) {
+	taken, err := slotTaken(ctx, q, roomId, startTime, endTime)
+	if err != nil || taken {
+		return false, err
+	}
+
+	hours, days, err := roomCalendar(ctx, q, roomId, startTime, endTime)
+	if err != nil {
+		return false, err
+	}
+
+	return calendar.IsOpen(hours, days, startTime, endTime), nil
+}
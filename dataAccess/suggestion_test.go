@@ -0,0 +1,45 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSuggestAlternatives(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	start := time.Now().Add(time.Hour).Truncate(time.Minute)
+	_, _, err := ReserveRooms(ctx, []int32{1}, 1, 1, start, 60, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	end := start.Add(time.Hour)
+	suggestions, err := SuggestAlternatives(ctx, 1, start, &end, 1, db)
+	if err != nil {
+		t.Fatalf("Error suggesting alternatives: %s", err.Error())
+	}
+
+	if len(suggestions.SameRoom) == 0 || !suggestions.SameRoom[0].StartTime.Equal(end) {
+		t.Errorf("Room 1 should be suggested for when the reservation ends, got %v", suggestions.SameRoom)
+	}
+
+	// the conference room is on the same floor and has a screen too
+	if len(suggestions.OtherRooms) == 0 || suggestions.OtherRooms[0].Room.Id != 2 {
+		t.Errorf("Room 2 should be suggested first, got %v", suggestions.OtherRooms)
+	}
+	for _, suggestion := range suggestions.OtherRooms {
+		if suggestion.Room.Id == 3 {
+			t.Errorf("Room 3 does not have a screen so should not be suggested")
+		}
+	}
+}
@@ -0,0 +1,28 @@
+/*
+	How long the data access functions wait on the database.
+*/
+package dataAccess
+
+import (
+	"context"
+	"time"
+)
+
+// how long each data access call can take before it is given up on, no limit if zero
+var queryTimeout time.Duration
+
+// SetQueryTimeout sets how long each data access call can take before it is given up on and
+// its connection is returned to the pool
+func SetQueryTimeout(timeout time.Duration) {
+	queryTimeout = timeout
+}
+
+// queryContext limits the context supplied to the query timeout. The context must be
+// cancelled once the call is finished
+func queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, queryTimeout)
+}
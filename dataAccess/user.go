@@ -0,0 +1,128 @@
+/*
+	Class that holds the data access functions for users.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// GetUser gets a user by their id
+func GetUser(ctx context.Context, id int32, db *pgxpool.Pool) (models.User, error) {
+	user := models.User{}
+	if db == nil {
+		return user, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	err := db.QueryRow(ctx, `
+		SELECT
+			id, COALESCE(name, ''), COALESCE(email, ''), COALESCE(team, ''), COALESCE(role, '')
+		FROM
+			users
+		WHERE
+			id = $1
+	`, id).Scan(&user.Id, &user.Name, &user.Email, &user.Team, &user.Role)
+
+	return user, err
+}
+
+// UserExists checks if a user id supplied is one of the users
+func UserExists(ctx context.Context, id int32, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	var exists bool
+	err := db.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)
+	`, id).Scan(&exists)
+
+	return exists, err
+}
+
+// GetNotificationOptOuts gets the kinds of notification a user does not want
+func GetNotificationOptOuts(ctx context.Context, userId int32, db *pgxpool.Pool) ([]string, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			notification
+		FROM
+			notification_opt_out
+		WHERE
+			user_id = $1
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	optOuts := []string{}
+	for rows.Next() {
+		var notification string
+		err = rows.Scan(&notification)
+		if err != nil {
+			return nil, err
+		}
+		optOuts = append(optOuts, notification)
+	}
+
+	return optOuts, rows.Err()
+}
+
+// SetNotificationOptOuts replaces the kinds of notification a user does not want
+func SetNotificationOptOuts(ctx context.Context, userId int32, optOuts []string, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		DELETE
+		FROM
+			notification_opt_out
+		WHERE
+			user_id = $1
+	`, userId)
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range optOuts {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO
+				notification_opt_out (user_id, notification)
+			VALUES
+				($1, $2)
+		`, userId, notification)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
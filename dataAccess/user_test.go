@@ -0,0 +1,54 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	test "avaros/test"
+
+	"context"
+	"testing"
+)
+
+func TestNotificationOptOuts(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	optOuts, err := GetNotificationOptOuts(ctx, 1, db)
+	if err != nil {
+		t.Errorf("Error getting notification opt outs: %s", err.Error())
+	}
+
+	if len(optOuts) != 0 {
+		t.Errorf("User 1 should not have opted out of any notifications")
+	}
+
+	err = SetNotificationOptOuts(ctx, 1, []string{"reminder", "expired"}, db)
+	if err != nil {
+		t.Errorf("Error setting notification opt outs: %s", err.Error())
+	}
+
+	optOuts, err = GetNotificationOptOuts(ctx, 1, db)
+	if err != nil {
+		t.Errorf("Error getting notification opt outs: %s", err.Error())
+	}
+
+	if len(optOuts) != 2 {
+		t.Errorf("User 1 should have opted out of 2 notifications")
+	}
+
+	err = SetNotificationOptOuts(ctx, 1, []string{}, db)
+	if err != nil {
+		t.Errorf("Error setting notification opt outs: %s", err.Error())
+	}
+
+	optOuts, err = GetNotificationOptOuts(ctx, 1, db)
+	if err != nil {
+		t.Errorf("Error getting notification opt outs: %s", err.Error())
+	}
+
+	if len(optOuts) != 0 {
+		t.Errorf("User 1 should have opted back in to all notifications")
+	}
+}
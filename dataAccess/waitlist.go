@@ -0,0 +1,465 @@
+/*
+	Class that holds the data access functions for the waitlist.
+*/
+package dataAccess
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// errSlotTaken is returned when a waitlist slot has been reserved by someone else
+var errSlotTaken = errors.New("Slot has been reserved in the meantime")
+
+// how long a user has to accept a slot offered to them from the waitlist
+var waitlistOfferWindow = 15 * time.Minute
+
+// SetWaitlistOfferWindow sets how long a user has to accept a slot offered to them
+// before it is offered to the next user waiting. Should be called once at startup
+func SetWaitlistOfferWindow(window time.Duration) {
+	waitlistOfferWindow = window
+}
+
+// JoinWaitlist adds a user to the waitlist for a room and time slot
+func JoinWaitlist(ctx context.Context, roomId int32, userId int32, startTime time.Time, endTime *time.Time, autoAccept bool, db *pgxpool.Pool) (models.WaitlistEntry, error) {
+	entry := models.WaitlistEntry{
+		RoomId:     roomId,
+		UserId:     userId,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		AutoAccept: autoAccept,
+		Status:     models.WaitlistWaiting,
+	}
+	if db == nil {
+		return entry, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	err := db.QueryRow(ctx, `
+		INSERT INTO
+			waitlist (room_id, user_id, start_time, end_time, auto_accept, status)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`, roomId, userId, startTime, endTime, autoAccept, entry.Status).Scan(&entry.Id)
+
+	return entry, err
+}
+
+// GetWaitlistEntries gets a user's waitlist entries that are still waiting or have been offered
+func GetWaitlistEntries(ctx context.Context, userId int32, db *pgxpool.Pool) ([]models.WaitlistEntry, error) {
+	if db == nil {
+		return nil, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		SELECT
+			id, room_id, user_id, start_time, end_time, auto_accept, status, offer_expires, reservation_id
+		FROM
+			waitlist
+		WHERE
+			user_id = $1
+		AND
+			status IN ($2, $3)
+		ORDER BY id
+	`, userId, models.WaitlistWaiting, models.WaitlistOffered)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanWaitlistEntries(rows)
+}
+
+// LeaveWaitlist takes a user off the waitlist. If they had been offered the slot it is offered
+// to the next user waiting. Returns false if the entry is not theirs or their delegator's, or
+// is no longer waiting
+func LeaveWaitlist(ctx context.Context, entryId int32, userId int32, db *pgxpool.Pool) (bool, error) {
+	if db == nil {
+		return false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	var roomId int32
+	err := db.QueryRow(ctx, `
+		UPDATE waitlist
+		SET status = $3
+		WHERE id = $1
+		AND `+actsFor("waitlist", "$2")+`
+		AND status IN ($4, $5)
+		RETURNING room_id
+	`, entryId, userId, models.WaitlistCancelled, models.WaitlistWaiting, models.WaitlistOffered).Scan(&roomId)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	background(ctx, "process waitlist", func(ctx context.Context) { ProcessWaitlist(ctx, roomId, db) })
+	return true, nil
+}
+
+// AcceptWaitlistOffer reserves the slot a user was offered from the waitlist. Returns
+// false if the entry is not theirs or their delegator's, the offer has expired or the slot has been taken, and a
+// QuotaError if it would take them over their quota
+func AcceptWaitlistOffer(ctx context.Context, entryId int32, userId int32, db *pgxpool.Pool) (int32, bool, error) {
+	if db == nil {
+		return -1, false, errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return -1, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT
+			id, room_id, user_id, start_time, end_time, auto_accept, status, offer_expires, reservation_id
+		FROM
+			waitlist
+		WHERE
+			id = $1
+		AND
+			`+actsFor("waitlist", "$2")+`
+		AND
+			status = $3
+		AND
+			offer_expires > NOW()
+		FOR UPDATE
+	`, entryId, userId, models.WaitlistOffered)
+	if err != nil {
+		return -1, false, err
+	}
+
+	entries, err := scanWaitlistEntries(rows)
+	if err != nil {
+		return -1, false, err
+	}
+	if len(entries) == 0 {
+		return -1, false, nil
+	}
+
+	reservation, err := giveSlot(ctx, tx, entries[0])
+	if err == errSlotTaken {
+		return -1, false, nil
+	}
+	if err != nil {
+		return -1, false, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return -1, false, err
+	}
+
+	reservationCreated(ctx, reservation, db)
+	return reservation.Id, true, nil
+}
+
+// ProcessWaitlist goes through the users waiting on a room, first come first served, and
+// gives or offers them their slot if it is now free. Called whenever a reservation on the
+// room is released. Errors are only logged as nothing is waiting on the result
+func ProcessWaitlist(ctx context.Context, roomId int32, db *pgxpool.Pool) {
+	err := processWaitlist(ctx, roomId, db)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error processing waitlist", "roomId", roomId, "error", err)
+	}
+}
+
+func processWaitlist(ctx context.Context, roomId int32, db *pgxpool.Pool) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// only one instance can hand out the room's slots at a time
+	_, err = lockRooms(ctx, tx, []int32{roomId})
+	if err != nil {
+		return err
+	}
+
+	// slots that are over can no longer be given to anyone
+	_, err = tx.Exec(ctx, `
+		UPDATE waitlist
+		SET status = $2
+		WHERE room_id = $1
+		AND status = $3
+		AND end_time IS NOT NULL
+		AND end_time <= NOW()
+	`, roomId, models.WaitlistExpired, models.WaitlistWaiting)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT
+			id, room_id, user_id, start_time, end_time, auto_accept, status, offer_expires, reservation_id
+		FROM
+			waitlist
+		WHERE
+			room_id = $1
+		AND
+			status = $2
+		ORDER BY id
+	`, roomId, models.WaitlistWaiting)
+	if err != nil {
+		return err
+	}
+
+	waiting, err := scanWaitlistEntries(rows)
+	if err != nil {
+		return err
+	}
+
+	reservations := []models.Reservation{}
+	offers := []models.WaitlistEntry{}
+	for _, entry := range waiting {
+		// a slot that has already started can only be given from now
+		if entry.StartTime.Before(time.Now()) {
+			entry.StartTime = time.Now()
+		}
+
+		free, err := slotFree(ctx, tx, entry)
+		if err != nil {
+			return err
+		}
+		if !free {
+			continue
+		}
+
+		// someone the slot would take over their quota is passed over for the next in line
+		err = checkQuota(ctx, tx, entry.UserId, []models.Reservation{slotReservation(entry)})
+		var quotaErr *QuotaError
+		if errors.As(err, &quotaErr) {
+			slog.InfoContext(ctx, "Waitlist slot passed over", "entryId", entry.Id, "reason", quotaErr.Reason)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if entry.AutoAccept {
+			reservation, err := giveSlot(ctx, tx, entry)
+			if err != nil {
+				return err
+			}
+			reservations = append(reservations, reservation)
+		} else {
+			offerExpires := time.Now().Add(waitlistOfferWindow)
+			entry.Status = models.WaitlistOffered
+			entry.OfferExpires = &offerExpires
+			_, err = tx.Exec(ctx, `
+				UPDATE waitlist
+				SET status = $2, offer_expires = $3
+				WHERE id = $1
+			`, entry.Id, entry.Status, entry.OfferExpires)
+			if err != nil {
+				return err
+			}
+			offers = append(offers, entry)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		reservationCreated(ctx, reservation, db)
+	}
+
+	for _, entry := range offers {
+		offerExpires, entryId := *entry.OfferExpires, entry.Id
+		backgroundAt(ctx, "expire waitlist offer", offerExpires, func(ctx context.Context) { expireWaitlistOffer(ctx, entryId, db) })
+		notifier.WaitlistOffered(ctx, entry)
+	}
+
+	return nil
+}
+
+// slotFree checks that nothing is reserved or offered to someone else for an entry's slot
+func slotFree(ctx context.Context, tx pgx.Tx, entry models.WaitlistEntry) (bool, error) {
+	taken, err := slotTaken(ctx, tx, entry.RoomId, entry.StartTime, entry.EndTime)
+	if err != nil || taken {
+		return false, err
+	}
+
+	var offered bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT
+				1
+			FROM
+				waitlist
+			WHERE
+				room_id = $1
+			AND
+				status = $4
+			AND
+				id != $5
+			AND
+				($3::timestamp IS NULL OR start_time < $3)
+			AND
+				(end_time IS NULL OR end_time > $2)
+		)
+	`, entry.RoomId, entry.StartTime, entry.EndTime, models.WaitlistOffered, entry.Id).Scan(&offered)
+
+	return !offered, err
+}
+
+// slotReservation gets the reservation an entry's slot would be given as. A slot that has
+// already started can only be given from now
+func slotReservation(entry models.WaitlistEntry) models.Reservation {
+	reservation := models.Reservation{
+		RoomId:    entry.RoomId,
+		UserId:    entry.UserId,
+		StartTime: entry.StartTime,
+		EndTime:   entry.EndTime,
+		Status:    models.ReservationConfirmed,
+	}
+	if reservation.StartTime.Before(time.Now()) {
+		reservation.StartTime = time.Now()
+	}
+
+	return reservation
+}
+
+// giveSlot reserves an entry's slot for the user waiting on it as part of a transaction.
+// Returns a QuotaError if it would take the user over their quota. reservationCreated
+// should be called for the reservation once the transaction is committed
+func giveSlot(ctx context.Context, tx pgx.Tx, entry models.WaitlistEntry) (models.Reservation, error) {
+	reservation := slotReservation(entry)
+
+	_, err := lockRooms(ctx, tx, []int32{entry.RoomId})
+	if err != nil {
+		return reservation, err
+	}
+
+	taken, err := slotTaken(ctx, tx, reservation.RoomId, reservation.StartTime, reservation.EndTime)
+	if err != nil {
+		return reservation, err
+	}
+	if taken {
+		return reservation, errSlotTaken
+	}
+
+	err = checkQuota(ctx, tx, entry.UserId, []models.Reservation{reservation})
+	if err != nil {
+		return reservation, err
+	}
+
+	err = insertReservation(ctx, tx, &reservation)
+	if err != nil {
+		return reservation, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE waitlist
+		SET status = $2, reservation_id = $3
+		WHERE id = $1
+	`, entry.Id, models.WaitlistAccepted, reservation.Id)
+
+	return reservation, err
+}
+
+// expireWaitlistOffer withdraws an offer that has not been accepted in time and offers the
+// slot to the next user waiting. Should only be run by backgroundAt once the offer expires,
+// ExpireWaitlistOffers catches the offers whose timer was lost with the instance that set it
+func expireWaitlistOffer(ctx context.Context, entryId int32, db *pgxpool.Pool) {
+	var roomId int32
+	err := db.QueryRow(ctx, `
+		UPDATE waitlist
+		SET status = $2
+		WHERE id = $1
+		AND status = $3
+		RETURNING room_id
+	`, entryId, models.WaitlistExpired, models.WaitlistOffered).Scan(&roomId)
+	// nothing to do if the offer was accepted or withdrawn in the meantime
+	if err == pgx.ErrNoRows {
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Error expiring waitlist offer", "entryId", entryId, "error", err)
+		return
+	}
+
+	ProcessWaitlist(ctx, roomId, db)
+}
+
+// ExpireWaitlistOffers withdraws every offer that was not accepted in time and offers the
+// slots to the next users waiting. Run by the scheduler so offers still expire if the
+// instance that made them has gone away
+func ExpireWaitlistOffers(ctx context.Context, db *pgxpool.Pool) error {
+	if db == nil {
+		return errors.New("Database instance empty")
+	}
+
+	ctx, cancel := queryContext(ctx)
+	defer cancel()
+
+	rows, err := db.Query(ctx, `
+		UPDATE waitlist
+		SET status = $1
+		WHERE status = $2
+		AND offer_expires <= NOW()
+		RETURNING id, room_id, user_id, start_time, end_time, auto_accept, status, offer_expires, reservation_id
+	`, models.WaitlistExpired, models.WaitlistOffered)
+	if err != nil {
+		return err
+	}
+
+	expired, err := scanWaitlistEntries(rows)
+	if err != nil {
+		return err
+	}
+
+	rooms := map[int32]bool{}
+	for _, entry := range expired {
+		if !rooms[entry.RoomId] {
+			rooms[entry.RoomId] = true
+			// the waitlist is not held to the time limit on the query that expired the offers
+			ProcessWaitlist(context.WithoutCancel(ctx), entry.RoomId, db)
+		}
+	}
+
+	return nil
+}
+
+// scanWaitlistEntries reads waitlist rows in the order id, room_id, user_id, start_time,
+// end_time, auto_accept, status, offer_expires, reservation_id and closes them
+func scanWaitlistEntries(rows pgx.Rows) ([]models.WaitlistEntry, error) {
+	defer rows.Close()
+
+	entries := []models.WaitlistEntry{}
+	for rows.Next() {
+		var entry models.WaitlistEntry
+		err := rows.Scan(&entry.Id, &entry.RoomId, &entry.UserId, &entry.StartTime, &entry.EndTime,
+			&entry.AutoAccept, &entry.Status, &entry.OfferExpires, &entry.ReservationId)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
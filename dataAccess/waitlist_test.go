@@ -0,0 +1,282 @@
+package dataAccess
+
+import (
+	database "avaros/database"
+	models "avaros/models"
+	test "avaros/test"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWaitlistAutoAccept(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	_, err := Reserve(ctx, 1, 1, 1, 0, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+
+	_, err = JoinWaitlist(ctx, 1, 2, time.Now(), nil, true, db)
+	if err != nil {
+		t.Errorf("Error joining waitlist: %s", err.Error())
+	}
+
+	err = DeleteReservation(ctx, 1, db)
+	if err != nil {
+		t.Errorf("Error deleting a reservation: %s", err.Error())
+	}
+
+	// deleting processes the waitlist in a thread, so wait on it
+	time.Sleep(time.Second)
+
+	reservationExists, err := CheckReservation(ctx, 1, db)
+	if err != nil {
+		t.Errorf("Error checking a reservation: %s", err.Error())
+	}
+
+	if !reservationExists {
+		t.Errorf("Room 1 should have been given to the user waiting on it")
+	}
+}
+
+func TestWaitlistOffer(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	_, err := Reserve(ctx, 1, 1, 1, 0, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+
+	entry, err := JoinWaitlist(ctx, 1, 2, time.Now(), nil, false, db)
+	if err != nil {
+		t.Errorf("Error joining waitlist: %s", err.Error())
+	}
+
+	_, accepted, err := AcceptWaitlistOffer(ctx, entry.Id, 2, db)
+	if err != nil {
+		t.Errorf("Error accepting waitlist offer: %s", err.Error())
+	}
+
+	if accepted {
+		t.Errorf("Offer should not be accepted before it is made")
+	}
+
+	ended, err := EndReservation(ctx, 1, db)
+	if err != nil {
+		t.Errorf("Error ending a reservation: %s", err.Error())
+	}
+
+	if !ended {
+		t.Errorf("Reservation for room 1 should have ended")
+	}
+
+	// ending processes the waitlist in a thread, so wait on it
+	time.Sleep(time.Second)
+
+	entries, err := GetWaitlistEntries(ctx, 2, db)
+	if err != nil {
+		t.Errorf("Error getting waitlist: %s", err.Error())
+	}
+
+	if len(entries) != 1 || entries[0].Status != models.WaitlistOffered {
+		t.Fatalf("Room 1 should have been offered to the user waiting on it")
+	}
+
+	_, accepted, err = AcceptWaitlistOffer(ctx, entry.Id, 2, db)
+	if err != nil {
+		t.Errorf("Error accepting waitlist offer: %s", err.Error())
+	}
+
+	if !accepted {
+		t.Errorf("Offer should have been accepted")
+	}
+
+	reservationExists, err := CheckReservation(ctx, 1, db)
+	if err != nil {
+		t.Errorf("Error checking a reservation: %s", err.Error())
+	}
+
+	if !reservationExists {
+		t.Errorf("Reservation for room 1 should exist")
+	}
+}
+
+func TestExpireWaitlistOffers(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	_, err := Reserve(ctx, 1, 1, 1, 0, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+
+	_, err = JoinWaitlist(ctx, 1, 2, time.Now(), nil, false, db)
+	if err != nil {
+		t.Errorf("Error joining waitlist: %s", err.Error())
+	}
+
+	_, err = EndReservation(ctx, 1, db)
+	if err != nil {
+		t.Errorf("Error ending a reservation: %s", err.Error())
+	}
+
+	// ending processes the waitlist in a thread, so wait on it
+	time.Sleep(time.Second)
+
+	// the offer runs out as if the instance that made it went away before its timer fired
+	_, err = db.Exec(ctx, `UPDATE waitlist SET offer_expires = NOW() - INTERVAL '1 minute' WHERE status = 'offered'`)
+	if err != nil {
+		t.Fatalf("Error backdating the offer: %s", err.Error())
+	}
+
+	err = ExpireWaitlistOffers(ctx, db)
+	if err != nil {
+		t.Errorf("Error expiring waitlist offers: %s", err.Error())
+	}
+
+	entries, err := GetWaitlistEntries(ctx, 2, db)
+	if err != nil {
+		t.Errorf("Error getting waitlist: %s", err.Error())
+	}
+
+	if len(entries) != 1 || entries[0].Status != models.WaitlistExpired {
+		t.Errorf("The offer should have expired, got %+v", entries)
+	}
+}
+
+func TestWaitlistQuota(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	// user 2 can only have one reservation that has not ended, and already has it
+	userId := int32(2)
+	maxFuture := int32(1)
+	err := SetQuota(ctx, &models.Quota{UserId: &userId, MaxFutureReservations: &maxFuture}, db)
+	if err != nil {
+		t.Fatalf("Error setting quota: %s", err.Error())
+	}
+	_, _, err = ReserveRooms(ctx, []int32{2}, 2, 2, time.Now().Add(24*time.Hour), 60, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	_, err = Reserve(ctx, 1, 1, 1, 0, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+
+	_, err = JoinWaitlist(ctx, 1, 2, time.Now(), nil, true, db)
+	if err != nil {
+		t.Errorf("Error joining waitlist: %s", err.Error())
+	}
+	_, err = JoinWaitlist(ctx, 1, 3, time.Now(), nil, true, db)
+	if err != nil {
+		t.Errorf("Error joining waitlist: %s", err.Error())
+	}
+
+	err = DeleteReservation(ctx, 1, db)
+	if err != nil {
+		t.Errorf("Error deleting a reservation: %s", err.Error())
+	}
+
+	// deleting processes the waitlist in a thread, so wait on it
+	time.Sleep(time.Second)
+
+	entries, err := GetWaitlistEntries(ctx, 2, db)
+	if err != nil {
+		t.Errorf("Error getting waitlist: %s", err.Error())
+	}
+	if len(entries) != 1 || entries[0].Status != models.WaitlistWaiting {
+		t.Errorf("User 2 is at their quota so should still be waiting, got %+v", entries)
+	}
+
+	entries, err = GetWaitlistEntries(ctx, 3, db)
+	if err != nil {
+		t.Errorf("Error getting waitlist: %s", err.Error())
+	}
+	if len(entries) != 1 || entries[0].Status != models.WaitlistAccepted {
+		t.Errorf("Room 1 should have been given to user 3, next in line, got %+v", entries)
+	}
+}
+
+func TestWaitlistDelegate(t *testing.T) {
+	ctx := context.Background()
+	db := test.NewDatabase()
+	defer test.CloseDb(db)
+
+	database.Seed(db)
+
+	// user 3 waits on rooms for user 2
+	err := GrantDelegation(ctx, 2, 3, db)
+	if err != nil {
+		t.Fatalf("Error granting delegation: %s", err.Error())
+	}
+
+	_, err = Reserve(ctx, 1, 1, 1, 0, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+
+	entry, err := JoinWaitlist(ctx, 1, 2, time.Now(), nil, false, db)
+	if err != nil {
+		t.Errorf("Error joining waitlist: %s", err.Error())
+	}
+
+	_, err = EndReservation(ctx, 1, db)
+	if err != nil {
+		t.Errorf("Error ending a reservation: %s", err.Error())
+	}
+
+	// ending processes the waitlist in a thread, so wait on it
+	time.Sleep(time.Second)
+
+	_, accepted, err := AcceptWaitlistOffer(ctx, entry.Id, 1, db)
+	if err != nil {
+		t.Errorf("Error accepting waitlist offer: %s", err.Error())
+	}
+	if accepted {
+		t.Errorf("User 1 is not a delegate of user 2 so should not accept their offer")
+	}
+
+	_, accepted, err = AcceptWaitlistOffer(ctx, entry.Id, 3, db)
+	if err != nil {
+		t.Errorf("Error accepting waitlist offer: %s", err.Error())
+	}
+	if !accepted {
+		t.Errorf("User 3 should have accepted the offer for user 2")
+	}
+
+	_, err = Reserve(ctx, 2, 1, 1, 0, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+
+	entry, err = JoinWaitlist(ctx, 2, 2, time.Now(), nil, false, db)
+	if err != nil {
+		t.Errorf("Error joining waitlist: %s", err.Error())
+	}
+
+	left, err := LeaveWaitlist(ctx, entry.Id, 3, db)
+	if err != nil {
+		t.Errorf("Error leaving waitlist: %s", err.Error())
+	}
+	if !left {
+		t.Errorf("User 3 should have taken user 2 off the waitlist")
+	}
+}
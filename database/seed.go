@@ -6,26 +6,180 @@ package database
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
+// the tables Seed creates
+var tables = []string{"users", "room", "quota", "role_priority", "delegation", "reservation", "attendee",
+	"bump", "reminder", "business_hours", "holiday", "closure", "waitlist", "reservation_event", "notification_opt_out"}
+
 func Seed(db *pgxpool.Pool) {
 
 	createTables(db)
+	createUserData(db)
 	createRoomData(db)
 }
 
-// create the room and reservation tables
+// CheckTables checks every table Seed creates is there
+func CheckTables(ctx context.Context, db *pgxpool.Pool) error {
+	var missing []string
+	err := db.QueryRow(ctx, `
+		SELECT
+			COALESCE(array_agg(name), '{}')
+		FROM
+			unnest($1::text[]) AS name
+		WHERE
+			to_regclass(name) IS NULL
+	`, tables).Scan(&missing)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("Tables %s are missing", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// create the room, user, quota, priority, delegation, reservation, attendee, bump, calendar and closure tables
 func createTables(db *pgxpool.Pool) {
 	_, err := db.Exec(context.Background(), `
+		DROP TABLE if exists users cascade;
+		CREATE TABLE users
+		(
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(80),
+			email VARCHAR(254),
+			team VARCHAR(80),
+			role VARCHAR(40),
+			last_modified TIMESTAMP,
+			created TIMESTAMP
+		)
+
+		TABLESPACE pg_default;
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	// limits on how much a user, team, role or, if none are set, everyone can reserve
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists quota cascade;
+		CREATE TABLE quota
+		(
+			id SERIAL PRIMARY KEY,
+			user_id INTEGER,
+			team VARCHAR(80),
+			role VARCHAR(40),
+			max_future_reservations INTEGER,
+			max_hours_per_week DOUBLE PRECISION,
+			max_per_room_per_day INTEGER,
+			CHECK (num_nonnulls(user_id, team, role) <= 1),
+			CONSTRAINT user_id FOREIGN KEY (user_id)
+				REFERENCES public.users (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE CASCADE
+		)
+
+		TABLESPACE pg_default;
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	// the highest priority users with each role can reserve rooms at
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists role_priority cascade;
+		CREATE TABLE role_priority
+		(
+			role VARCHAR(40) PRIMARY KEY,
+			max_priority INTEGER NOT NULL DEFAULT 0
+		)
+
+		TABLESPACE pg_default;
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	_, err = db.Exec(context.Background(), `
 		DROP TABLE if exists room cascade;
 		CREATE TABLE room
 		(
 			id SERIAL PRIMARY KEY,
 			name VARCHAR(80),
+			building VARCHAR(80),
+			site VARCHAR(80),
+			floor INTEGER,
+			amenities TEXT[] NOT NULL DEFAULT '{}',
+			requires_approval BOOLEAN DEFAULT false,
+			approver_id INTEGER,
+			capacity INTEGER,
+			buffer_before INTEGER NOT NULL DEFAULT 0,
+			buffer_after INTEGER NOT NULL DEFAULT 0,
 			last_modified TIMESTAMP,
-			created TIMESTAMP
+			created TIMESTAMP,
+			CONSTRAINT approver_id FOREIGN KEY (approver_id)
+				REFERENCES public.users (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE NO ACTION
+				NOT VALID,
+			-- reservations of a room that needs approval have to have someone to approve them
+			CONSTRAINT approver_required CHECK (NOT requires_approval OR approver_id IS NOT NULL)
+		)
+
+		TABLESPACE pg_default;
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	// a row means the user does not want that kind of notification
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists notification_opt_out cascade;
+		CREATE TABLE notification_opt_out
+		(
+			user_id INTEGER NOT NULL,
+			notification VARCHAR(20) NOT NULL,
+			PRIMARY KEY (user_id, notification),
+			CONSTRAINT user_id FOREIGN KEY (user_id)
+				REFERENCES public.users (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE CASCADE
+		)
+
+		TABLESPACE pg_default;
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	// a row lets the delegate book, change and cancel reservations for the user
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists delegation cascade;
+		CREATE TABLE delegation
+		(
+			user_id INTEGER NOT NULL,
+			delegate_id INTEGER NOT NULL,
+			created TIMESTAMP DEFAULT NOW(),
+			PRIMARY KEY (user_id, delegate_id),
+			CONSTRAINT user_id FOREIGN KEY (user_id)
+				REFERENCES public.users (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE CASCADE,
+			CONSTRAINT delegate_id FOREIGN KEY (delegate_id)
+				REFERENCES public.users (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE CASCADE
 		)
 
 		TABLESPACE pg_default;
@@ -41,15 +195,37 @@ func createTables(db *pgxpool.Pool) {
 		(
 			id SERIAL PRIMARY KEY,
 			room_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			created_by INTEGER NOT NULL,
 			start_time TIMESTAMP,
 			end_time TIMESTAMP,
 			expired BOOLEAN DEFAULT false,
+			status VARCHAR(10) NOT NULL DEFAULT 'confirmed',
+			hold_expires TIMESTAMP,
+			approver_id INTEGER,
+			approval_expires TIMESTAMP,
+			decision_reason TEXT,
+			title VARCHAR(200),
+			description TEXT,
+			private BOOLEAN NOT NULL DEFAULT false,
+			priority INTEGER NOT NULL DEFAULT 0,
+			checked_in TIMESTAMP,
 			last_modified TIMESTAMP,
 			created TIMESTAMP,
 			CONSTRAINT room_id FOREIGN KEY (room_id)
 				REFERENCES public.room (id) MATCH SIMPLE
 				ON UPDATE NO ACTION
 				ON DELETE NO ACTION
+				NOT VALID,
+			CONSTRAINT user_id FOREIGN KEY (user_id)
+				REFERENCES public.users (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE NO ACTION
+				NOT VALID,
+			CONSTRAINT created_by FOREIGN KEY (created_by)
+				REFERENCES public.users (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE NO ACTION
 				NOT VALID
 		)
 		
@@ -60,6 +236,216 @@ func createTables(db *pgxpool.Pool) {
 		panic("Error seeding database: " + err.Error())
 	}
 
+	// the people invited to a reservation, either users or external email addresses
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists attendee cascade;
+		CREATE TABLE attendee
+		(
+			id SERIAL PRIMARY KEY,
+			reservation_id INTEGER NOT NULL,
+			user_id INTEGER,
+			email VARCHAR(254),
+			rsvp VARCHAR(10) NOT NULL DEFAULT 'pending',
+			CHECK (user_id IS NOT NULL OR email IS NOT NULL),
+			CONSTRAINT reservation_id FOREIGN KEY (reservation_id)
+				REFERENCES public.reservation (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE CASCADE,
+			CONSTRAINT user_id FOREIGN KEY (user_id)
+				REFERENCES public.users (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE CASCADE
+		)
+
+		TABLESPACE pg_default;
+
+		CREATE UNIQUE INDEX attendee_user ON attendee (reservation_id, user_id);
+		CREATE UNIQUE INDEX attendee_email ON attendee (reservation_id, email);
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	// the reservations that were displaced by higher priority ones and why
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists bump cascade;
+		CREATE TABLE bump
+		(
+			id SERIAL PRIMARY KEY,
+			reservation_id INTEGER NOT NULL,
+			by_reservation_id INTEGER NOT NULL,
+			reason TEXT,
+			created TIMESTAMP DEFAULT NOW(),
+			CONSTRAINT reservation_id FOREIGN KEY (reservation_id)
+				REFERENCES public.reservation (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE CASCADE,
+			CONSTRAINT by_reservation_id FOREIGN KEY (by_reservation_id)
+				REFERENCES public.reservation (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE CASCADE
+		)
+
+		TABLESPACE pg_default;
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	// when to remind users about their reservations, kept here rather than in memory so
+	// reminders are still sent if the service restarts
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists reminder cascade;
+		CREATE TABLE reminder
+		(
+			reservation_id INTEGER PRIMARY KEY,
+			remind_at TIMESTAMP NOT NULL,
+			CONSTRAINT reservation_id FOREIGN KEY (reservation_id)
+				REFERENCES public.reservation (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE CASCADE
+		)
+
+		TABLESPACE pg_default;
+
+		CREATE INDEX reminder_remind_at ON reminder (remind_at);
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	// the times each site is open every week. Days with no hours are closed,
+	// unless the site has no hours at all
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists business_hours cascade;
+		CREATE TABLE business_hours
+		(
+			id SERIAL PRIMARY KEY,
+			site VARCHAR(80) NOT NULL,
+			weekday INTEGER NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+			opens TIME NOT NULL,
+			closes TIME NOT NULL,
+			CHECK (closes > opens)
+		)
+
+		TABLESPACE pg_default;
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists holiday cascade;
+		CREATE TABLE holiday
+		(
+			id SERIAL PRIMARY KEY,
+			site VARCHAR(80) NOT NULL,
+			date DATE NOT NULL,
+			name VARCHAR(200),
+			UNIQUE (site, date)
+		)
+
+		TABLESPACE pg_default;
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	// times a room, building or site cannot be reserved. Exactly one of them is set
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists closure cascade;
+		CREATE TABLE closure
+		(
+			id SERIAL PRIMARY KEY,
+			room_id INTEGER,
+			building VARCHAR(80),
+			site VARCHAR(80),
+			start_time TIMESTAMP NOT NULL,
+			end_time TIMESTAMP NOT NULL,
+			recurrence VARCHAR(10) NOT NULL DEFAULT 'none',
+			repeat_until TIMESTAMP,
+			reason TEXT,
+			created_by INTEGER NOT NULL,
+			created TIMESTAMP DEFAULT NOW(),
+			CHECK (num_nonnulls(room_id, building, site) = 1),
+			CHECK (end_time > start_time),
+			CONSTRAINT room_id FOREIGN KEY (room_id)
+				REFERENCES public.room (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE CASCADE,
+			CONSTRAINT created_by FOREIGN KEY (created_by)
+				REFERENCES public.users (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE NO ACTION
+		)
+
+		TABLESPACE pg_default;
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists waitlist cascade;
+		CREATE TABLE waitlist
+		(
+			id SERIAL PRIMARY KEY,
+			room_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			start_time TIMESTAMP NOT NULL,
+			end_time TIMESTAMP,
+			auto_accept BOOLEAN DEFAULT false,
+			status VARCHAR(10) NOT NULL DEFAULT 'waiting',
+			offer_expires TIMESTAMP,
+			reservation_id INTEGER,
+			last_modified TIMESTAMP,
+			created TIMESTAMP,
+			CONSTRAINT room_id FOREIGN KEY (room_id)
+				REFERENCES public.room (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE NO ACTION
+				NOT VALID,
+			CONSTRAINT user_id FOREIGN KEY (user_id)
+				REFERENCES public.users (id) MATCH SIMPLE
+				ON UPDATE NO ACTION
+				ON DELETE NO ACTION
+				NOT VALID
+		)
+
+		TABLESPACE pg_default;
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists reservation_event cascade;
+		CREATE TABLE reservation_event
+		(
+			id BIGSERIAL PRIMARY KEY,
+			room_id INTEGER NOT NULL,
+			reservation_id INTEGER NOT NULL,
+			operation VARCHAR(10) NOT NULL,
+			reserved BOOLEAN NOT NULL,
+			created TIMESTAMPTZ DEFAULT NOW()
+		)
+
+		TABLESPACE pg_default;
+
+		CREATE INDEX reservation_event_room_id ON reservation_event (room_id, id);
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
 	// create triggers for timestamp fields
 	_, err = db.Exec(context.Background(), `
 		CREATE OR REPLACE FUNCTION trigger_set_last_modified()
@@ -106,6 +492,22 @@ func createTables(db *pgxpool.Pool) {
 		panic("Error seeding database: " + err.Error())
 	}
 
+	_, err = db.Exec(context.Background(), `
+		CREATE TRIGGER users_insert
+		BEFORE INSERT ON users
+		FOR EACH ROW
+		EXECUTE PROCEDURE trigger_set_created();
+
+		CREATE TRIGGER users_update
+		BEFORE UPDATE ON users
+		FOR EACH ROW
+		EXECUTE PROCEDURE trigger_set_last_modified();
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
 	_, err = db.Exec(context.Background(), `
 		CREATE TRIGGER reservation_insert
 		BEFORE INSERT ON reservation
@@ -121,22 +523,162 @@ func createTables(db *pgxpool.Pool) {
 	if err != nil {
 		panic("Error seeding database: " + err.Error())
 	}
+
+	_, err = db.Exec(context.Background(), `
+		CREATE TRIGGER waitlist_insert
+		BEFORE INSERT ON waitlist
+		FOR EACH ROW
+		EXECUTE PROCEDURE trigger_set_created();
+		
+		CREATE TRIGGER waitlist_update
+		BEFORE UPDATE ON waitlist
+		FOR EACH ROW
+		EXECUTE PROCEDURE trigger_set_last_modified();
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	// record every change to a reservation and notify any listeners so
+	// live updates reach every instance of the service
+	_, err = db.Exec(context.Background(), `
+		CREATE OR REPLACE FUNCTION trigger_notify_reservation()
+		RETURNS TRIGGER AS $$
+		DECLARE
+			rec reservation;
+			event reservation_event;
+		BEGIN
+			IF TG_OP = 'DELETE' THEN
+				rec = OLD;
+			ELSE
+				rec = NEW;
+			END IF;
+
+			INSERT INTO reservation_event (room_id, reservation_id, operation, reserved)
+			VALUES (
+				rec.room_id,
+				rec.id,
+				TG_OP,
+				EXISTS(
+					SELECT 1 FROM reservation
+					WHERE room_id = rec.room_id AND expired = false
+					AND start_time <= NOW() AND (end_time IS NULL OR end_time > NOW())
+					AND (status != 'hold' OR hold_expires > NOW())
+				)
+			)
+			RETURNING * INTO event;
+
+			PERFORM pg_notify('reservation_events', json_build_object(
+				'id', event.id,
+				'roomId', event.room_id,
+				'reservationId', event.reservation_id,
+				'operation', event.operation,
+				'reserved', event.reserved,
+				'created', event.created
+			)::text);
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
+
+	_, err = db.Exec(context.Background(), `
+		CREATE TRIGGER reservation_notify
+		AFTER INSERT OR UPDATE OR DELETE ON reservation
+		FOR EACH ROW
+		EXECUTE PROCEDURE trigger_notify_reservation();
+	`)
+
+	if err != nil {
+		panic("Error seeding database: " + err.Error())
+	}
 }
 
 func createRoomData(db *pgxpool.Pool) {
 
-	rooms := []string{"Meeting Room", "Conference Room", "Lunch Room"}
+	// the lunch room has no limit on how many people fit in it, but needs
+	// cleaning for 15 minutes after each reservation
+	rooms := []struct {
+		name        string
+		floor       int32
+		amenities   []string
+		capacity    *int32
+		bufferAfter int32
+	}{
+		{"Meeting Room", 1, []string{"screen"}, capacity(8), 0},
+		{"Conference Room", 1, []string{"screen", "video"}, capacity(20), 0},
+		{"Lunch Room", 0, []string{}, nil, 15},
+	}
 
-	for _, name := range rooms {
+	for _, room := range rooms {
 		_, err := db.Exec(context.Background(), `
 			INSERT INTO 
-				room (name)
+				room (name, building, site, floor, amenities, capacity, buffer_after)
 			VALUES 
-				($1)
-		`, name)
+				($1, 'Head Office', 'Dublin', $2, $3, $4, $5)
+		`, room.name, room.floor, room.amenities, room.capacity, room.bufferAfter)
 
 		if err != nil {
 			panic("Error reserving room: " + err.Error())
 		}
 	}
+
+	// reservations of the boardroom have to be approved by Jane
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO 
+			room (name, building, site, floor, amenities, requires_approval, approver_id, capacity)
+		VALUES 
+			($1, 'Head Office', 'Dublin', 2, '{screen,video}', true, 2, 12)
+	`, "Boardroom")
+
+	if err != nil {
+		panic("Error reserving room: " + err.Error())
+	}
+}
+
+func capacity(people int32) *int32 {
+	return &people
+}
+
+func createUserData(db *pgxpool.Pool) {
+
+	users := []struct {
+		name  string
+		email string
+		team  string
+		role  string
+	}{
+		{"Conor Downey", "conor@avaros.local", "Engineering", "employee"},
+		{"Jane Doe", "jane@avaros.local", "Executive", "manager"},
+		{"John Smith", "john@avaros.local", "Engineering", "employee"},
+	}
+
+	for _, user := range users {
+		_, err := db.Exec(context.Background(), `
+			INSERT INTO 
+				users (name, email, team, role)
+			VALUES 
+				($1, $2, $3, $4)
+		`, user.name, user.email, user.team, user.role)
+
+		if err != nil {
+			panic("Error creating user: " + err.Error())
+		}
+	}
+
+	// managers can displace everyone else's reservations
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO
+			role_priority (role, max_priority)
+		VALUES
+			('manager', 2)
+	`)
+
+	if err != nil {
+		panic("Error creating role priorities: " + err.Error())
+	}
 }
@@ -0,0 +1,261 @@
+/**
+migrations that create and update the schema of a SQLite database, for running on a single node without Postgres
+*/
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// The migrations that bring a SQLite database up to date, in the order they are applied.
+// The database's user version is how many of them it has had, so new migrations must only
+// ever be added to the end. Times are stored as text in UTC, all with the same number of
+// digits so they compare in order
+var sqliteMigrations = []string{
+	// the users, rooms and reservations, and the rules on reserving them
+	`
+	CREATE TABLE users
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name VARCHAR(80),
+		email VARCHAR(254),
+		team VARCHAR(80),
+		role VARCHAR(40),
+		last_modified TEXT,
+		created TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+	);
+
+	CREATE TABLE quota
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER REFERENCES users (id) ON DELETE CASCADE,
+		team VARCHAR(80),
+		role VARCHAR(40),
+		max_future_reservations INTEGER,
+		max_hours_per_week DOUBLE PRECISION,
+		max_per_room_per_day INTEGER,
+		CHECK ((user_id IS NOT NULL) + (team IS NOT NULL) + (role IS NOT NULL) <= 1)
+	);
+
+	CREATE TABLE role_priority
+	(
+		role VARCHAR(40) PRIMARY KEY,
+		max_priority INTEGER NOT NULL DEFAULT 0
+	);
+
+	CREATE TABLE room
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name VARCHAR(80),
+		building VARCHAR(80),
+		site VARCHAR(80),
+		floor INTEGER,
+		amenities TEXT NOT NULL DEFAULT '[]', -- a JSON array
+		requires_approval BOOLEAN NOT NULL DEFAULT false,
+		approver_id INTEGER REFERENCES users (id),
+		capacity INTEGER,
+		buffer_before INTEGER NOT NULL DEFAULT 0,
+		buffer_after INTEGER NOT NULL DEFAULT 0,
+		last_modified TEXT,
+		created TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+	);
+
+	CREATE TABLE delegation
+	(
+		user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+		delegate_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+		created TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+		PRIMARY KEY (user_id, delegate_id)
+	);
+
+	CREATE TABLE reservation
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		room_id INTEGER NOT NULL REFERENCES room (id),
+		user_id INTEGER NOT NULL REFERENCES users (id),
+		created_by INTEGER NOT NULL REFERENCES users (id),
+		start_time TEXT,
+		end_time TEXT,
+		expired BOOLEAN NOT NULL DEFAULT false,
+		status VARCHAR(10) NOT NULL DEFAULT 'confirmed',
+		hold_expires TEXT,
+		approver_id INTEGER,
+		approval_expires TEXT,
+		decision_reason TEXT,
+		title VARCHAR(200),
+		description TEXT,
+		private BOOLEAN NOT NULL DEFAULT false,
+		priority INTEGER NOT NULL DEFAULT 0,
+		last_modified TEXT,
+		created TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+	);
+
+	CREATE INDEX reservation_room_time ON reservation (room_id, start_time);
+	CREATE INDEX reservation_user ON reservation (user_id);
+
+	CREATE TABLE attendee
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		reservation_id INTEGER NOT NULL REFERENCES reservation (id) ON DELETE CASCADE,
+		user_id INTEGER REFERENCES users (id) ON DELETE CASCADE,
+		email VARCHAR(254),
+		rsvp VARCHAR(10) NOT NULL DEFAULT 'pending',
+		CHECK (user_id IS NOT NULL OR email IS NOT NULL)
+	);
+
+	CREATE UNIQUE INDEX attendee_user ON attendee (reservation_id, user_id);
+	CREATE UNIQUE INDEX attendee_email ON attendee (reservation_id, email);
+
+	CREATE TABLE bump
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		reservation_id INTEGER NOT NULL REFERENCES reservation (id) ON DELETE CASCADE,
+		by_reservation_id INTEGER NOT NULL REFERENCES reservation (id) ON DELETE CASCADE,
+		reason TEXT,
+		created TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+	);
+
+	CREATE TABLE business_hours
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		site VARCHAR(80) NOT NULL,
+		weekday INTEGER NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+		opens TEXT NOT NULL, -- 15:04
+		closes TEXT NOT NULL,
+		CHECK (closes > opens)
+	);
+
+	CREATE TABLE holiday
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		site VARCHAR(80) NOT NULL,
+		date TEXT NOT NULL, -- 2006-01-02
+		name VARCHAR(200),
+		UNIQUE (site, date)
+	);
+
+	CREATE TABLE closure
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		room_id INTEGER REFERENCES room (id) ON DELETE CASCADE,
+		building VARCHAR(80),
+		site VARCHAR(80),
+		start_time TEXT NOT NULL,
+		end_time TEXT NOT NULL,
+		recurrence VARCHAR(10) NOT NULL DEFAULT 'none',
+		repeat_until TEXT,
+		reason TEXT,
+		created_by INTEGER NOT NULL REFERENCES users (id),
+		created TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+		CHECK ((room_id IS NOT NULL) + (building IS NOT NULL) + (site IS NOT NULL) = 1),
+		CHECK (end_time > start_time)
+	);
+
+	CREATE TRIGGER users_update AFTER UPDATE ON users FOR EACH ROW
+	BEGIN
+		UPDATE users SET last_modified = strftime('%Y-%m-%d %H:%M:%f', 'now') WHERE id = NEW.id;
+	END;
+
+	CREATE TRIGGER room_update AFTER UPDATE ON room FOR EACH ROW
+	BEGIN
+		UPDATE room SET last_modified = strftime('%Y-%m-%d %H:%M:%f', 'now') WHERE id = NEW.id;
+	END;
+
+	CREATE TRIGGER reservation_update AFTER UPDATE ON reservation FOR EACH ROW
+	BEGIN
+		UPDATE reservation SET last_modified = strftime('%Y-%m-%d %H:%M:%f', 'now') WHERE id = NEW.id;
+	END;
+	`,
+
+	// the same users and rooms the Postgres database is seeded with. Managers can displace
+	// everyone else's reservations, the lunch room needs cleaning for 15 minutes after each
+	// reservation and reservations of the boardroom have to be approved by Jane
+	`
+	INSERT INTO
+		users (name, email, team, role)
+	VALUES
+		('Conor Downey', 'conor@avaros.local', 'Engineering', 'employee'),
+		('Jane Doe', 'jane@avaros.local', 'Executive', 'manager'),
+		('John Smith', 'john@avaros.local', 'Engineering', 'employee');
+
+	INSERT INTO
+		role_priority (role, max_priority)
+	VALUES
+		('manager', 2);
+
+	INSERT INTO
+		room (name, building, site, floor, amenities, requires_approval, approver_id, capacity, buffer_after)
+	VALUES
+		('Meeting Room', 'Head Office', 'Dublin', 1, '["screen"]', false, NULL, 8, 0),
+		('Conference Room', 'Head Office', 'Dublin', 1, '["screen","video"]', false, NULL, 20, 0),
+		('Lunch Room', 'Head Office', 'Dublin', 0, '[]', false, NULL, NULL, 15),
+		('Boardroom', 'Head Office', 'Dublin', 2, '["screen","video"]', true, 2, 12, 0);
+	`,
+
+	// reservations of a room that needs approval have to have someone to approve them. SQLite
+	// cannot add a check to a table that exists, so triggers refuse rooms without one
+	`
+	CREATE TRIGGER room_approver_insert BEFORE INSERT ON room FOR EACH ROW
+	WHEN NEW.requires_approval AND NEW.approver_id IS NULL
+	BEGIN
+		SELECT RAISE(ABORT, 'A room that requires approval needs an approver');
+	END;
+
+	CREATE TRIGGER room_approver_update BEFORE UPDATE OF requires_approval, approver_id ON room FOR EACH ROW
+	WHEN NEW.requires_approval AND NEW.approver_id IS NULL
+	BEGIN
+		SELECT RAISE(ABORT, 'A room that requires approval needs an approver');
+	END;
+	`,
+}
+
+// MigrateSQLite applies any migrations a SQLite database has not had yet, each in its own
+// transaction
+func MigrateSQLite(db *sql.DB) error {
+	var version int
+	err := db.QueryRow("PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return err
+	}
+
+	for ; version < len(sqliteMigrations); version++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(sqliteMigrations[version])
+		if err == nil {
+			_, err = tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", version+1))
+		}
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("Error applying migration %d: %w", version+1, err)
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckSQLiteMigrations checks a SQLite database has had every migration applied
+func CheckSQLiteMigrations(ctx context.Context, db *sql.DB) error {
+	var version int
+	err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return err
+	}
+
+	if version < len(sqliteMigrations) {
+		return fmt.Errorf("%d of %d migrations have been applied", version, len(sqliteMigrations))
+	}
+
+	return nil
+}
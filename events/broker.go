@@ -0,0 +1,138 @@
+/*
+	Fans out reservation events from postgres to anyone watching a room.
+*/
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Channel is the postgres notification channel the reservation trigger publishes to
+const Channel = "reservation_events"
+
+// how many events a subscriber can fall behind before it is dropped. A dropped
+// subscriber can reconnect with the last event id it saw and replay what it missed
+const subscriberBuffer = 32
+
+// how long to wait before listening again if the connection to the database is lost
+const reconnectDelay = 5 * time.Second
+
+// Broker listens for reservation events on the database and passes them on to
+// the subscribers for the room the event is for
+type Broker struct {
+	db          *pgxpool.Pool
+	mu          sync.Mutex
+	subscribers map[int32]map[chan models.ReservationEvent]struct{}
+}
+
+// NewBroker creates a broker that listens for events on the database supplied
+func NewBroker(db *pgxpool.Pool) *Broker {
+	return &Broker{
+		db:          db,
+		subscribers: map[int32]map[chan models.ReservationEvent]struct{}{},
+	}
+}
+
+// Listen listens for reservation events until the context is cancelled. If the
+// connection is lost it will keep trying to listen again. Should only be opened in a thread
+func (b *Broker) Listen(ctx context.Context) error {
+	for {
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		slog.ErrorContext(ctx, "Error listening for reservation events", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// listen holds a connection from the pool and waits on notifications from it
+func (b *Broker) listen(ctx context.Context) error {
+	conn, err := b.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, "LISTEN "+Channel)
+	if err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event models.ReservationEvent
+		err = json.Unmarshal([]byte(notification.Payload), &event)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error reading reservation event", "error", err)
+			continue
+		}
+		b.Publish(event)
+	}
+}
+
+// Subscribe returns a channel that receives every event for the room and a function
+// to call when finished with it. The channel is closed if the subscriber falls too far behind
+func (b *Broker) Subscribe(roomId int32) (<-chan models.ReservationEvent, func()) {
+	ch := make(chan models.ReservationEvent, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[roomId] == nil {
+		b.subscribers[roomId] = map[chan models.ReservationEvent]struct{}{}
+	}
+	b.subscribers[roomId][ch] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(roomId, ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish sends an event to everyone subscribed to the event's room
+func (b *Broker) Publish(event models.ReservationEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[event.RoomId] {
+		select {
+		case ch <- event:
+		default:
+			// never block the listener on a slow client
+			b.remove(event.RoomId, ch)
+		}
+	}
+}
+
+// remove closes a subscriber's channel if it is still subscribed. Must hold the lock
+func (b *Broker) remove(roomId int32, ch chan models.ReservationEvent) {
+	if _, ok := b.subscribers[roomId][ch]; !ok {
+		return
+	}
+	delete(b.subscribers[roomId], ch)
+	close(ch)
+	if len(b.subscribers[roomId]) == 0 {
+		delete(b.subscribers, roomId)
+	}
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"testing"
+
+	"avaros/models"
+)
+
+func TestPublish(t *testing.T) {
+	broker := NewBroker(nil)
+
+	events, unsubscribe := broker.Subscribe(1)
+	defer unsubscribe()
+	otherEvents, unsubscribeOther := broker.Subscribe(2)
+	defer unsubscribeOther()
+
+	broker.Publish(models.ReservationEvent{Id: 1, RoomId: 1, Reserved: true})
+
+	event := <-events
+	if event.Id != 1 || !event.Reserved {
+		t.Errorf("Subscriber for room 1 should have received event 1")
+	}
+
+	if len(otherEvents) != 0 {
+		t.Errorf("Subscriber for room 2 should not have received any events")
+	}
+}
+
+func TestSlowSubscriberDropped(t *testing.T) {
+	broker := NewBroker(nil)
+
+	events, unsubscribe := broker.Subscribe(1)
+	defer unsubscribe()
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		broker.Publish(models.ReservationEvent{Id: int64(i + 1), RoomId: 1})
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+
+	if received != subscriberBuffer {
+		t.Errorf("Subscriber should have received %d events before being dropped, got %d", subscriberBuffer, received)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	broker := NewBroker(nil)
+
+	events, unsubscribe := broker.Subscribe(1)
+	unsubscribe()
+	// unsubscribing twice should be safe
+	unsubscribe()
+
+	if _, ok := <-events; ok {
+		t.Errorf("Channel should be closed after unsubscribing")
+	}
+
+	broker.Publish(models.ReservationEvent{Id: 1, RoomId: 1})
+}
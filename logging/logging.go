@@ -0,0 +1,88 @@
+/*
+	Structured logging. Every log line written with a context carries the id of the request,
+	or background job, it was written for.
+*/
+
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+type requestIdKey struct{}
+
+// Setup makes a logger writing to w the default. The level is debug, info, warn or error and
+// the format json or text
+func Setup(w io.Writer, level string, format string) error {
+	var l slog.Level
+	err := l.UnmarshalText([]byte(level))
+	if err != nil {
+		return fmt.Errorf("Unknown log level %s, it must be debug, info, warn or error", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: l}
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case "json":
+		handler = slog.NewJSONHandler(w, opts)
+	case "text":
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return fmt.Errorf("Unknown log format %s, it must be json or text", format)
+	}
+
+	slog.SetDefault(slog.New(requestIdHandler{handler}))
+	return nil
+}
+
+// NewRequestId creates an id for a request, or a run of a background job, that did not come with one
+func NewRequestId() string {
+	b := make([]byte, 8)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic("Error creating request id: " + err.Error())
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// WithRequestId returns a context that logs with the request id supplied
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// RequestId gets the request id from the context, or an empty string if it does not have one
+func RequestId(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
+
+// requestIdHandler adds the request id from the context to every record
+type requestIdHandler struct {
+	slog.Handler
+}
+
+func (h requestIdHandler) Handle(ctx context.Context, r slog.Record) error {
+	if requestId := RequestId(ctx); requestId != "" {
+		r.AddAttrs(slog.String("requestId", requestId))
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h requestIdHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestIdHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h requestIdHandler) WithGroup(name string) slog.Handler {
+	return requestIdHandler{h.Handler.WithGroup(name)}
+}
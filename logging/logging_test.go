@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestRequestIdLogged(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	var buf bytes.Buffer
+	err := Setup(&buf, "info", "json")
+	if err != nil {
+		t.Fatalf("Error setting up logging: %s", err.Error())
+	}
+
+	ctx := WithRequestId(context.Background(), "abc123")
+	slog.InfoContext(ctx, "reserved", "roomId", 1)
+	// loggers made from the default still add the id
+	slog.Default().With("userId", 1).DebugContext(ctx, "below the level")
+	slog.Default().With("userId", 1).WarnContext(ctx, "with attrs")
+	slog.Info("no request")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines to be logged, got %d: %s", len(lines), buf.String())
+	}
+
+	expected := []string{"abc123", "abc123", ""}
+	for i, line := range lines {
+		var record map[string]interface{}
+		err := json.Unmarshal(line, &record)
+		if err != nil {
+			t.Fatalf("Error reading log line %s: %s", line, err.Error())
+		}
+		requestId, _ := record["requestId"].(string)
+		if requestId != expected[i] {
+			t.Errorf("Line %d should have request id %q, got %q", i, expected[i], requestId)
+		}
+	}
+}
+
+func TestSetupRejectsUnknown(t *testing.T) {
+	var buf bytes.Buffer
+	if Setup(&buf, "verbose", "json") == nil {
+		t.Error("Unknown level should not be accepted")
+	}
+	if Setup(&buf, "info", "xml") == nil {
+		t.Error("Unknown format should not be accepted")
+	}
+}
+
+func TestNewRequestId(t *testing.T) {
+	a, b := NewRequestId(), NewRequestId()
+	if len(a) != 16 || a == b {
+		t.Errorf("Request ids should be 16 hex characters and unique, got %s and %s", a, b)
+	}
+}
@@ -2,36 +2,76 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"flag"
+	"log/slog"
 	"net/http"
 	"os"
+	"time"
 
+	config "avaros/config"
+	dataAccess "avaros/dataAccess"
 	database "avaros/database"
+	events "avaros/events"
+	logging "avaros/logging"
+	metrics "avaros/metrics"
+	notification "avaros/notification"
+	repository "avaros/repository"
 	rest "avaros/rest"
 	router "avaros/router"
+	scheduler "avaros/scheduler"
+	tracing "avaros/tracing"
 
+	"github.com/gocraft/web"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
 func main() {
-	fmt.Println("server running")
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		panic("Error loading config: " + err.Error())
+	}
+	err = logging.Setup(os.Stdout, cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		panic("Error setting up logging: " + err.Error())
+	}
+	slog.Info("config", "config", cfg.Redacted())
+
+	// spans for requests, queries and background jobs
+	stopTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		panic("Error setting up tracing: " + err.Error())
+	}
+	traced := cfg.Tracing.Exporter != "none"
+
+	// how long a request, and each call it makes to the database, can take before it is given up on
+	router.SetRequestTimeout(cfg.RequestTimeout())
+	dataAccess.SetQueryTimeout(cfg.QueryTimeout())
 
 	router := router.NewRouter()
-	db := newDatabase()
-	defer db.Close()
-	// create and seed the database
-	database.Seed(db)
+	server := &http.Server{Addr: cfg.ListenAddr, Handler: router}
 
-	// instantiate a rest object so all rest services have the same database and router
-	RestObj := rest.RestServiceObject{
-		router,
-		db,
+	// rooms and reservations can be stored in Postgres, or in SQLite when running on a single node
+	var restServices []rest.RestService
+	var steps []shutdownStep
+	switch cfg.Database.Driver {
+	case "postgres":
+		db := newDatabase(cfg.Database, traced)
+		restServices, steps = postgresServices(server, router, db, cfg)
+	case "sqlite":
+		repo := newSQLite(cfg)
+		restServices = sqliteServices(router, repo)
+		steps = []shutdownStep{
+			{"database", func(context.Context) error { return repo.Close() }},
+		}
 	}
 
-	// Doing it this way as it is easy then to add any more services as required
-	restServices := []rest.RestService{
-		&rest.RoomService{RestObj},
-	}
+	// send the spans of everything stopped before
+	steps = append(steps, shutdownStep{"tracing", stopTracing})
 
 	// Loop through and initialise their routes
 	for _, service := range restServices {
@@ -41,45 +81,224 @@ func main() {
 		}
 	}
 
-	listen := os.Getenv("LISTEN_ADDR")
-	fmt.Println("LISTEN: " + listen)
-	if listen == "" {
-		listen = "localhost:3000"
+	slog.Info("server running", "addr", cfg.ListenAddr)
+	serve(server, cfg.ShutdownTimeout(), steps)
+}
+
+// postgresServices seeds the Postgres database, starts everything that runs in the background
+// and creates every rest service. Returns the steps that stop the background work, in order
+func postgresServices(server *http.Server, router *web.Router, db *pgxpool.Pool, cfg config.Config) ([]rest.RestService, []shutdownStep) {
+	// create and seed the database
+	database.Seed(db)
+
+	// listen for reservation changes so they can be pushed to anyone watching a room
+	broker := events.NewBroker(db)
+	brokerCtx, stopBroker := context.WithCancel(context.Background())
+	brokerDone := make(chan struct{})
+	go func() {
+		defer close(brokerDone)
+		broker.Listen(brokerCtx)
+	}()
+
+	// email users about their reservations
+	notifier := notification.NewNotifier(notification.NewMailer(cfg.SMTP), cfg.ReminderBefore(), db)
+	dataAccess.SetNotifier(notifier)
+
+	// how long someone on a waitlist has to accept a room once it is offered to them, and how
+	// long an approver has to answer a reservation request before it is rejected
+	dataAccess.SetWaitlistOfferWindow(cfg.WaitlistOfferWindow())
+	dataAccess.SetApprovalWindow(cfg.ApprovalWindow())
+
+	// run the background jobs
+	sched := scheduler.New()
+	sched.Every(time.Second, "release expired holds", func(ctx context.Context) {
+		err := dataAccess.ReleaseExpiredHolds(ctx, db)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error releasing expired holds", "error", err)
+		}
+	})
+	sched.Every(time.Minute, "expire approval requests", func(ctx context.Context) {
+		err := dataAccess.ExpireApprovalRequests(ctx, db)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error expiring approval requests", "error", err)
+		}
+	})
+	sched.Every(time.Second, "expire waitlist offers", func(ctx context.Context) {
+		err := dataAccess.ExpireWaitlistOffers(ctx, db)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error expiring waitlist offers", "error", err)
+		}
+	})
+	sched.Every(time.Minute, "send reminders", func(ctx context.Context) {
+		err := notifier.SendReminders(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error sending reminders", "error", err)
+		}
+	})
+	sched.Every(time.Hour, "delete old reservation events", func(ctx context.Context) {
+		err := dataAccess.DeleteReservationEvents(ctx, time.Now().Add(-cfg.EventRetention()), db)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error deleting old reservation events", "error", err)
+		}
+	})
+	if cfg.NoShowAfter() > 0 {
+		sched.Every(time.Minute, "release no-shows", func(ctx context.Context) {
+			err := dataAccess.ReleaseNoShows(ctx, cfg.NoShowAfter(), db)
+			if err != nil {
+				slog.ErrorContext(ctx, "Error releasing no-shows", "error", err)
+			}
+		})
+	}
+	schedCtx, stopSched := context.WithCancel(context.Background())
+	schedDone := make(chan struct{})
+	go func() {
+		defer close(schedDone)
+		sched.Run(schedCtx)
+	}()
+
+	// instantiate a rest object so all rest services have the same database and router
+	repo := repository.NewPostgres(db)
+	RestObj := rest.RestServiceObject{
+		Router:       router,
+		Db:           db,
+		Rooms:        repo,
+		Reservations: repo,
+	}
+
+	// the scheduler and data access functions can leave emails to send, and everything
+	// needs the database, so the notifier and then the database go last
+	steps := []shutdownStep{
+		{"scheduler", func(ctx context.Context) error {
+			stopSched()
+			<-schedDone
+			return waitFor(ctx, sched.Wait)
+		}},
+		{"background work", dataAccess.Shutdown},
+		{"event broker", func(ctx context.Context) error {
+			stopBroker()
+			return waitFor(ctx, func() { <-brokerDone })
+		}},
+		{"notifications", notifier.Close},
+		{"database", func(context.Context) error {
+			db.Close()
+			return nil
+		}},
+	}
+
+	metrics.WatchPool(db)
+	metrics.WatchScheduler(sched.Queued)
+
+	// the service is only ready once the database can be reached, has its tables and the
+	// background jobs are running
+	checks := []rest.HealthCheck{
+		{Name: "database", Check: db.Ping},
+		{Name: "migrations", Check: func(ctx context.Context) error {
+			return database.CheckTables(ctx, db)
+		}},
+		{Name: "scheduler", Check: func(context.Context) error {
+			if !sched.Running() {
+				return errors.New("Background jobs are not running")
+			}
+			return nil
+		}},
+	}
+
+	if cfg.RoomsOnly {
+		return []rest.RestService{
+			&rest.HealthService{RestObj: RestObj, Checks: checks},
+			&rest.MetricsService{RestObj: RestObj},
+			&rest.DocsService{RestObj: RestObj, Tags: rest.RoomsOnlyTags},
+			&rest.RoomService{RestObj: RestObj},
+		}, steps
 	}
 
-	http.ListenAndServe(listen, router)
+	// event streams never finish on their own so they are ended as soon as shutdown starts
+	eventService := &rest.EventService{RestObj: RestObj, Broker: broker}
+	server.RegisterOnShutdown(eventService.Close)
+
+	// Doing it this way as it is easy then to add any more services as required
+	return []rest.RestService{
+		&rest.HealthService{RestObj: RestObj, Checks: checks},
+		&rest.MetricsService{RestObj: RestObj},
+		&rest.DocsService{RestObj: RestObj},
+		&rest.RoomService{RestObj: RestObj},
+		eventService,
+		&rest.NotificationService{RestObj: RestObj},
+		&rest.ReservationService{RestObj: RestObj},
+		&rest.DelegationService{RestObj: RestObj},
+		&rest.ClosureService{RestObj: RestObj},
+		&rest.CalendarService{RestObj: RestObj},
+		&rest.FreeBusyService{RestObj: RestObj},
+		&rest.QuotaService{RestObj: RestObj},
+		&rest.PriorityService{RestObj: RestObj},
+		&rest.WaitlistService{RestObj: RestObj},
+		&rest.HoldService{RestObj: RestObj, HoldLength: cfg.HoldLength()},
+	}, steps
 }
 
-// newDatabase connects to a database at the start and passes that connection to
-// any service below it.
-func newDatabase() *pgxpool.Pool {
+// sqliteServices creates the rest services that work without Postgres. Only rooms and their
+// reservations can be used, and nothing is sent to users about them, so the config has to
+// ask for just the room service to get here
+func sqliteServices(router *web.Router, repo *repository.SQLite) []rest.RestService {
+	slog.Info("Using SQLite, only the room service is available")
 
-	user := os.Getenv("DB_USER")
-	if user == "" {
-		user = "postgres"
+	RestObj := rest.RestServiceObject{
+		Router:       router,
+		Rooms:        repo,
+		Reservations: repo,
 	}
 
-	name := os.Getenv("DB_NAME")
-	if name == "" {
-		name = "postgres"
+	metrics.WatchSQLite(repo.Db)
+
+	checks := []rest.HealthCheck{
+		{Name: "database", Check: repo.Db.PingContext},
+		{Name: "migrations", Check: func(ctx context.Context) error {
+			return database.CheckSQLiteMigrations(ctx, repo.Db)
+		}},
 	}
 
-	password := os.Getenv("DB_PASSWORD")
-	if password == "" {
-		password = "password"
+	return []rest.RestService{
+		&rest.HealthService{RestObj: RestObj, Checks: checks},
+		&rest.MetricsService{RestObj: RestObj},
+		&rest.DocsService{RestObj: RestObj, Tags: rest.RoomsOnlyTags},
+		&rest.RoomService{RestObj: RestObj},
 	}
+}
 
-	host := os.Getenv("DB_HOST")
-	if host == "" {
-		host = "localhost"
+// newDatabase connects to a database at the start and passes that connection to
+// any service below it. Each query is traced if traced is set
+func newDatabase(cfg config.Database, traced bool) *pgxpool.Pool {
+	poolConfig, err := pgxpool.ParseConfig(cfg.ConnString())
+	if err != nil {
+		panic("Error parsing database config: " + err.Error())
 	}
 
-	conn, err := pgxpool.Connect(context.Background(),
-		fmt.Sprintf("user=%s dbname=%s password=%s host=%s sslmode=disable",
-			user, name, password, host))
+	if cfg.MaxConns > 0 {
+		poolConfig.MaxConns = int32(cfg.MaxConns)
+	}
+	if cfg.MinConns > 0 {
+		poolConfig.MinConns = int32(cfg.MinConns)
+	}
+	if traced {
+		poolConfig.ConnConfig.Logger = tracing.QueryLogger{}
+		poolConfig.ConnConfig.LogLevel = pgx.LogLevelInfo
+	}
+
+	conn, err := pgxpool.ConnectConfig(context.Background(), poolConfig)
 	if err != nil {
 		panic("Unable to connect to database: " + err.Error())
 	}
 
 	return conn
 }
+
+// newSQLite opens the SQLite database, creating it if it does not exist
+func newSQLite(cfg config.Config) *repository.SQLite {
+	repo, err := repository.NewSQLite(cfg.Database.Path)
+	if err != nil {
+		panic("Unable to open database: " + err.Error())
+	}
+	repo.ApprovalWindow = cfg.ApprovalWindow()
+
+	return repo
+}
@@ -0,0 +1,152 @@
+/*
+	Prometheus metrics for requests, the database, background jobs and bookings.
+*/
+
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "avaros"
+
+// The things that can happen to a booking
+const (
+	BookingCreated   = "created"
+	BookingCancelled = "cancelled"
+	BookingExpired   = "expired"
+	BookingEnded     = "ended"    // ended early by the user
+	BookingRejected  = "rejected" // by an approver, or because nobody approved it in time
+	BookingBumped    = "bumped"   // displaced by a higher priority booking
+	BookingNoShow    = "no_show"  // released because nobody checked in
+	HoldReleased     = "released" // a hold that was never confirmed
+)
+
+// The reasons a booking can be refused
+const (
+	RejectedConflict = "conflict"
+	RejectedClosed   = "closed"
+	RejectedPolicy   = "policy"
+	RejectedQuota    = "quota"
+)
+
+// HTTPRequests counts the requests served by route, method and status
+var HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "http_requests_total",
+	Help:      "Requests served by route, method and status.",
+}, []string{"route", "method", "status"})
+
+// HTTPDuration is how long requests take by route, method and status
+var HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "http_request_duration_seconds",
+	Help:      "How long requests take by route, method and status.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"route", "method", "status"})
+
+// Bookings counts what has happened to bookings
+var Bookings = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "bookings_total",
+	Help:      "Bookings by what happened to them: created, cancelled, expired, ended, rejected, bumped, no_show or released.",
+}, []string{"event"})
+
+// BookingRejections counts the bookings that were refused by why
+var BookingRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "booking_rejections_total",
+	Help:      "Bookings refused because of a conflict, a closure, the booking policy or a quota.",
+}, []string{"reason"})
+
+// SchedulerLag is how late background jobs start after they were due
+var SchedulerLag = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "scheduler_lag_seconds",
+	Help:      "How late background jobs start after they were due.",
+	Buckets:   []float64{.001, .005, .01, .05, .1, .5, 1, 5, 10},
+}, []string{"job"})
+
+// Handler serves every metric for Prometheus to scrape
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// WatchScheduler reports how many jobs the scheduler has waiting to run
+func WatchScheduler(queued func() int) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "scheduler_queued_jobs",
+		Help:      "Background jobs waiting to run.",
+	}, func() float64 {
+		return float64(queued())
+	})
+}
+
+// WatchPool reports the state of the Postgres connection pool
+func WatchPool(pool *pgxpool.Pool) {
+	prometheus.MustRegister(&poolCollector{pool: pool})
+}
+
+// WatchSQLite reports the state of the SQLite connection pool
+func WatchSQLite(db *sql.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, "sqlite"))
+}
+
+var (
+	poolAcquired = prometheus.NewDesc(namespace+"_db_pool_acquired_connections",
+		"Connections in use.", nil, nil)
+	poolIdle = prometheus.NewDesc(namespace+"_db_pool_idle_connections",
+		"Connections waiting to be used.", nil, nil)
+	poolConstructing = prometheus.NewDesc(namespace+"_db_pool_constructing_connections",
+		"Connections being opened.", nil, nil)
+	poolTotal = prometheus.NewDesc(namespace+"_db_pool_total_connections",
+		"Connections open or being opened.", nil, nil)
+	poolMax = prometheus.NewDesc(namespace+"_db_pool_max_connections",
+		"Most connections the pool will open.", nil, nil)
+	poolAcquires = prometheus.NewDesc(namespace+"_db_pool_acquires_total",
+		"Connections taken from the pool.", nil, nil)
+	poolEmptyAcquires = prometheus.NewDesc(namespace+"_db_pool_empty_acquires_total",
+		"Connections taken from the pool that had to be waited on or opened.", nil, nil)
+	poolCanceledAcquires = prometheus.NewDesc(namespace+"_db_pool_canceled_acquires_total",
+		"Waits on a connection that were given up on.", nil, nil)
+	poolAcquireSeconds = prometheus.NewDesc(namespace+"_db_pool_acquire_seconds_total",
+		"Time spent waiting on connections from the pool.", nil, nil)
+)
+
+// poolCollector reads the pgx pool's stats each time the metrics are scraped
+type poolCollector struct {
+	pool *pgxpool.Pool
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolAcquired
+	ch <- poolIdle
+	ch <- poolConstructing
+	ch <- poolTotal
+	ch <- poolMax
+	ch <- poolAcquires
+	ch <- poolEmptyAcquires
+	ch <- poolCanceledAcquires
+	ch <- poolAcquireSeconds
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(poolAcquired, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(poolIdle, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(poolConstructing, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(poolTotal, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(poolMax, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolCanceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireSeconds, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
@@ -0,0 +1,19 @@
+package models
+
+// The answers an attendee can give to an invitation
+const (
+	RsvpPending   = "pending"
+	RsvpAccepted  = "accepted"
+	RsvpDeclined  = "declined"
+	RsvpTentative = "tentative"
+)
+
+// Attendee is someone invited to a reservation. Either a user of the service or,
+// for people outside it, just an email address
+type Attendee struct {
+	Id            int32  `json:"id"`
+	ReservationId int32  `json:"reservationId"`
+	UserId        *int32 `json:"userId,omitempty"`
+	Email         string `json:"email,omitempty"`
+	Rsvp          string `json:"rsvp"`
+}
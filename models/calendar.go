@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// BusinessHours is a time a site is open on a day of the week. Times are in the
+// form 15:04, and a site closing at midnight closes at 24:00
+type BusinessHours struct {
+	Weekday time.Weekday `json:"weekday"` // 0 is Sunday
+	Opens   string       `json:"opens"`
+	Closes  string       `json:"closes"`
+}
+
+// Holiday is a day a site is closed
+type Holiday struct {
+	Id   int32     `json:"id"`
+	Site string    `json:"site"`
+	Date time.Time `json:"date"`
+	Name string    `json:"name"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// How often a closure repeats
+const (
+	RecurrenceNone   = "none"
+	RecurrenceDaily  = "daily"
+	RecurrenceWeekly = "weekly"
+	RecurrenceYearly = "yearly"
+)
+
+// Closure is a time a room, every room in a building or every room on a site cannot be
+// reserved. Exactly one of the room id, building and site is set. A recurring closure
+// repeats from its start until the repeat until time, or forever if there is none
+type Closure struct {
+	Id          int32      `json:"id"`
+	RoomId      *int32     `json:"roomId,omitempty"`
+	Building    string     `json:"building,omitempty"`
+	Site        string     `json:"site,omitempty"`
+	StartTime   time.Time  `json:"startTime"`
+	EndTime     time.Time  `json:"endTime"`
+	Recurrence  string     `json:"recurrence"`
+	RepeatUntil *time.Time `json:"repeatUntil,omitempty"`
+	Reason      string     `json:"reason,omitempty"`
+	CreatedBy   int32      `json:"createdBy"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// Delegation lets the delegate book, change and cancel reservations for the user
+// who granted it
+type Delegation struct {
+	UserId     int32     `json:"userId"`
+	DelegateId int32     `json:"delegateId"`
+	Created    time.Time `json:"created"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// BusyPeriod is a span of time a room or user is busy. Anything that overlaps or runs
+// straight into something else is joined into one period
+type BusyPeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Busy  []Busy    `json:"busy"` // what the room or user is busy with
+}
+
+// Busy is a reservation or closure taking up a room's or user's time. The details of a
+// private reservation are only shown to its organizer and attendees
+type Busy struct {
+	ReservationId int32  `json:"reservationId,omitempty"`
+	RoomId        int32  `json:"roomId,omitempty"`
+	Title         string `json:"title,omitempty"`
+	Private       bool   `json:"private,omitempty"`
+	Closed        bool   `json:"closed,omitempty"` // the room is closed rather than reserved
+}
+
+// FreeBusy is when each of the rooms and users asked for is busy, keyed by their ids
+type FreeBusy struct {
+	Rooms map[int32][]BusyPeriod `json:"rooms"`
+	Users map[int32][]BusyPeriod `json:"users"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// The priorities a reservation can have. A reservation displaces any that overlap it
+// with a lower priority
+const (
+	PriorityNormal   int32 = 0
+	PriorityHigh     int32 = 1 // e.g. executive meetings
+	PriorityCritical int32 = 2 // e.g. incident war rooms
+)
+
+// RolePriority is the highest priority users with a role can reserve rooms at
+type RolePriority struct {
+	Role        string `json:"role"`
+	MaxPriority int32  `json:"maxPriority"`
+}
+
+// Bump records a reservation being displaced by a higher priority one
+type Bump struct {
+	Id              int32     `json:"id"`
+	ReservationId   int32     `json:"reservationId"`
+	ByReservationId int32     `json:"byReservationId"`
+	ByUserId        int32     `json:"byUserId"` // who made the reservation that displaced it
+	Reason          string    `json:"reason"`
+	Created         time.Time `json:"created"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// Quota limits how much users can reserve. At most one of the user id, team and role is
+// set, and a quota with none of them applies to everyone. Any limit left empty is not
+// enforced
+type Quota struct {
+	Id                    int32    `json:"id"`
+	UserId                *int32   `json:"userId,omitempty"`
+	Team                  string   `json:"team,omitempty"`
+	Role                  string   `json:"role,omitempty"`
+	MaxFutureReservations *int32   `json:"maxFutureReservations,omitempty"` // reservations that have not ended yet
+	MaxHoursPerWeek       *float64 `json:"maxHoursPerWeek,omitempty"`
+	MaxPerRoomPerDay      *int32   `json:"maxPerRoomPerDay,omitempty"`
+}
+
+// QuotaUsage is how much of their quota a user has used. The remaining amounts are empty
+// when there is no limit
+type QuotaUsage struct {
+	Limits                      Quota    `json:"limits"`
+	FutureReservations          int32    `json:"futureReservations"`
+	RemainingFutureReservations *int32   `json:"remainingFutureReservations,omitempty"`
+	HoursThisWeek               float64  `json:"hoursThisWeek"`
+	RemainingHoursThisWeek      *float64 `json:"remainingHoursThisWeek,omitempty"`
+}
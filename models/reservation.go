@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// The states a reservation can be in
+const (
+	ReservationConfirmed = "confirmed"
+	ReservationHold      = "hold"    // blocks the time until it is confirmed or the hold expires
+	ReservationPending   = "pending" // blocks the time until it is approved, rejected or not answered in time
+	ReservationRejected  = "rejected"
+	ReservationBumped    = "bumped" // displaced by a higher priority reservation
+)
+
+// Reservation is a booking of a room by a user
+type Reservation struct {
+	Id          int32      `json:"id"`
+	RoomId      int32      `json:"roomId"`
+	UserId      int32      `json:"userId"`    // the organizer the room is reserved for
+	CreatedBy   int32      `json:"createdBy"` // who made the reservation, the organizer or their delegate
+	StartTime   time.Time  `json:"startTime"`
+	EndTime     *time.Time `json:"endTime,omitempty"` // empty until the reservation ends
+	Expired     bool       `json:"expired"`
+	Status      string     `json:"status"`
+	HoldExpires *time.Time `json:"holdExpires,omitempty"`
+	// set for reservations on rooms that need approval
+	ApproverId      *int32     `json:"approverId,omitempty"`
+	ApprovalExpires *time.Time `json:"approvalExpires,omitempty"`
+	DecisionReason  string     `json:"decisionReason,omitempty"`
+	ReservationDetails
+}
+
+// ReservationDetails describe what a reservation is for, who is invited to it and how
+// important it is
+type ReservationDetails struct {
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Attendees   []Attendee `json:"attendees,omitempty"`
+	Priority    int32      `json:"priority,omitempty"`
+	Private     bool       `json:"private,omitempty"` // only the organizer and attendees see the details in free/busy
+
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// ReservationEvent is a change to a room's reservations. They are recorded by a
+// trigger on the reservation table and pushed out over postgres NOTIFY so every
+// instance of the service sees them.
+type ReservationEvent struct {
+	Id            int64     `json:"id"`
+	RoomId        int32     `json:"roomId"`
+	ReservationId int32     `json:"reservationId"`
+	Operation     string    `json:"operation"` // INSERT, UPDATE, DELETE or STATE for the initial state
+	Reserved      bool      `json:"reserved"`  // whether the room is reserved after the change
+	Created       time.Time `json:"created"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// Room is a room that can be reserved
+type Room struct {
+	Id               int32    `json:"id"`
+	Name             string   `json:"name"`
+	Building         string   `json:"building,omitempty"`
+	Site             string   `json:"site,omitempty"`
+	Floor            *int32   `json:"floor,omitempty"`
+	Amenities        []string `json:"amenities,omitempty"` // e.g. screen, video
+	RequiresApproval bool     `json:"requiresApproval"`    // reservations have to be approved by the approver
+	ApproverId       *int32   `json:"approverId,omitempty"`
+	Capacity         *int32   `json:"capacity,omitempty"` // how many people fit, empty if there is no limit
+	// minutes kept free before and after each reservation for setup and teardown
+	BufferBefore int32 `json:"bufferBeforeMinutes"`
+	BufferAfter  int32 `json:"bufferAfterMinutes"`
+}
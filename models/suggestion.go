@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Suggestion is a room and time that could be reserved instead of the one asked for
+type Suggestion struct {
+	Room      Room       `json:"room"`
+	StartTime time.Time  `json:"startTime"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+}
+
+// Suggestions are the alternatives to a reservation that could not be made, best first
+type Suggestions struct {
+	SameRoom   []Suggestion `json:"sameRoom"`   // the room asked for at the nearest free times
+	OtherRooms []Suggestion `json:"otherRooms"` // similar rooms in the same building free at the time asked for
+}
@@ -0,0 +1,10 @@
+package models
+
+// User is someone who can reserve rooms
+type User struct {
+	Id    int32  `json:"userId"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Team  string `json:"team,omitempty"`
+	Role  string `json:"role,omitempty"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// The states a waitlist entry can be in
+const (
+	WaitlistWaiting   = "waiting"
+	WaitlistOffered   = "offered"
+	WaitlistAccepted  = "accepted"
+	WaitlistExpired   = "expired"
+	WaitlistCancelled = "cancelled"
+)
+
+// WaitlistEntry is a user waiting for a room to become free for a time slot
+type WaitlistEntry struct {
+	Id            int32      `json:"id"`
+	RoomId        int32      `json:"roomId"`
+	UserId        int32      `json:"userId"`
+	StartTime     time.Time  `json:"startTime"`
+	EndTime       *time.Time `json:"endTime,omitempty"`
+	AutoAccept    bool       `json:"autoAccept"` // reserve the slot straight away rather than offer it
+	Status        string     `json:"status"`
+	OfferExpires  *time.Time `json:"offerExpires,omitempty"`
+	ReservationId *int32     `json:"reservationId,omitempty"`
+}
@@ -0,0 +1,103 @@
+/*
+	Sends emails through an SMTP relay.
+*/
+
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+
+	"avaros/config"
+)
+
+// Sender sends an email with both a plain text and html body
+type Sender interface {
+	Send(to string, subject string, text string, html string) error
+}
+
+// Mailer sends emails through an SMTP relay
+type Mailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// NewMailer creates a mailer for the SMTP relay configured
+func NewMailer(cfg config.SMTP) *Mailer {
+	return &Mailer{
+		Host:     cfg.Host,
+		Port:     strconv.Itoa(cfg.Port),
+		Username: cfg.Username,
+		Password: cfg.Password,
+		From:     cfg.From,
+	}
+}
+
+// Send sends an email to the address supplied
+func (m *Mailer) Send(to string, subject string, text string, html string) error {
+	msg, err := buildMessage(m.From, to, subject, text, html)
+	if err != nil {
+		return err
+	}
+
+	// only authenticate if the relay needs it
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	return smtp.SendMail(m.Host+":"+m.Port, auth, m.From, []string{to}, msg)
+}
+
+// buildMessage builds a multipart email so clients can show whichever body they support
+func buildMessage(from string, to string, subject string, text string, html string) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		// clients show the last part they understand so html goes last
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	}
+
+	for _, part := range parts {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"8bit"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, err = w.Write([]byte(part.content))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject))
+	fmt.Fprint(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%s\r\n", writer.Boundary())
+	fmt.Fprint(&msg, "\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,296 @@
+/*
+	Lets users know about their reservations by email.
+*/
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmlTemplate "html/template"
+	"log/slog"
+	"sync"
+	textTemplate "text/template"
+	"time"
+
+	"avaros/dataAccess"
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// The kinds of notification a user can be sent, and opt out of
+const (
+	Booked    = "booked"
+	Reminder  = "reminder"
+	Expired   = "expired"
+	Cancelled = "cancelled"
+	Waitlist  = "waitlist"
+	Pending   = "pending"
+	Approval  = "approval"
+	Rejected  = "rejected"
+	Bumped    = "bumped"
+)
+
+// Kinds contains every kind of notification
+var Kinds = []string{Booked, Reminder, Expired, Cancelled, Waitlist, Pending, Approval, Rejected, Bumped}
+
+var subjects = map[string]string{
+	Booked:    "Reservation confirmed: %s",
+	Reminder:  "Reservation starting soon: %s",
+	Expired:   "Reservation ended: %s",
+	Cancelled: "Reservation cancelled: %s",
+	Waitlist:  "Room available: %s",
+	Pending:   "Reservation awaiting approval: %s",
+	Approval:  "Reservation to approve: %s",
+	Rejected:  "Reservation rejected: %s",
+	Bumped:    "Reservation displaced: %s",
+}
+
+//go:embed templates
+var templateFiles embed.FS
+
+var templateFuncs = map[string]interface{}{
+	"formatTime": func(t time.Time) string {
+		return t.Format("Mon 2 Jan 2006 15:04")
+	},
+	"deref": func(t *time.Time) time.Time {
+		return *t
+	},
+}
+
+var textTemplates = textTemplate.Must(textTemplate.New("").Funcs(templateFuncs).ParseFS(templateFiles, "templates/*.txt"))
+var htmlTemplates = htmlTemplate.Must(htmlTemplate.New("").Funcs(templateFuncs).ParseFS(templateFiles, "templates/*.html"))
+
+// templateData is what the templates are filled in with
+type templateData struct {
+	User         models.User
+	Room         models.Room
+	Reservation  models.Reservation
+	Entry        models.WaitlistEntry
+	OfferExpires time.Time
+	Requester    models.User   // who the reservation is for when the email is to someone else
+	Alternatives []models.Room // other rooms the user could use instead
+}
+
+// Notifier emails users when their reservations are booked, are about to start, expire,
+// are cancelled, are waiting on or rejected by an approver or are displaced, when a slot they are waiting
+// on is offered to them, and when they have a reservation to approve, unless they have
+// opted out of that kind of email
+type Notifier struct {
+	sender         Sender
+	db             *pgxpool.Pool
+	reminderBefore time.Duration
+
+	mu      sync.Mutex
+	sending sync.WaitGroup
+	closed  bool
+}
+
+// NewNotifier creates a notifier that sends emails with the sender supplied. Reminders are
+// sent the time supplied before a reservation starts, and never if it is 0
+func NewNotifier(sender Sender, reminderBefore time.Duration, db *pgxpool.Pool) *Notifier {
+	return &Notifier{
+		sender:         sender,
+		db:             db,
+		reminderBefore: reminderBefore,
+	}
+}
+
+// ReservationBooked lets the user know their reservation is confirmed and, if it starts
+// far enough in the future, stores a reminder for SendReminders to send before it starts
+func (n *Notifier) ReservationBooked(ctx context.Context, reservation models.Reservation) {
+	n.async(ctx, func(ctx context.Context) { n.notify(ctx, Booked, reservation) })
+
+	remindAt := reservation.StartTime.Add(-n.reminderBefore)
+	if n.reminderBefore <= 0 || !remindAt.After(time.Now()) {
+		return
+	}
+
+	n.async(ctx, func(ctx context.Context) {
+		err := dataAccess.AddReminder(ctx, reservation.Id, remindAt, n.db)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error setting reminder", "reservationId", reservation.Id, "error", err)
+		}
+	})
+}
+
+// SendReminders reminds users about their reservations that are about to start. It is run
+// by the scheduler, and only reminds them about reservations they still have
+func (n *Notifier) SendReminders(ctx context.Context) error {
+	reservations, err := dataAccess.DueReminders(ctx, n.db)
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		reservation := reservation
+		n.async(ctx, func(ctx context.Context) { n.notify(ctx, Reminder, reservation) })
+	}
+	return nil
+}
+
+// ReservationExpired lets the user know their reservation has ended
+func (n *Notifier) ReservationExpired(ctx context.Context, reservation models.Reservation) {
+	n.async(ctx, func(ctx context.Context) { n.notify(ctx, Expired, reservation) })
+}
+
+// ReservationCancelled lets the user know their reservation has been cancelled
+func (n *Notifier) ReservationCancelled(ctx context.Context, reservation models.Reservation) {
+	n.async(ctx, func(ctx context.Context) { n.notify(ctx, Cancelled, reservation) })
+}
+
+// ApprovalRequested lets the user know their reservation is waiting on approval and
+// lets the approver know they have a reservation to approve
+func (n *Notifier) ApprovalRequested(ctx context.Context, reservation models.Reservation) {
+	n.async(ctx, func(ctx context.Context) { n.notify(ctx, Pending, reservation) })
+
+	if reservation.ApproverId == nil {
+		return
+	}
+
+	n.async(ctx, func(ctx context.Context) {
+		requester, err := dataAccess.GetUser(ctx, reservation.UserId, n.db)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error sending notification", "kind", Approval, "roomId", reservation.RoomId, "error", err)
+			return
+		}
+
+		n.notifyUser(ctx, Approval, *reservation.ApproverId, reservation.RoomId, templateData{
+			Reservation: reservation,
+			Requester:   requester,
+		})
+	})
+}
+
+// ReservationRejected lets the user know their reservation was rejected
+func (n *Notifier) ReservationRejected(ctx context.Context, reservation models.Reservation) {
+	n.async(ctx, func(ctx context.Context) { n.notify(ctx, Rejected, reservation) })
+}
+
+// ReservationBumped lets the user know their reservation was displaced by a higher
+// priority one and suggests other rooms they could use
+func (n *Notifier) ReservationBumped(ctx context.Context, reservation models.Reservation, alternatives []models.Room) {
+	n.async(ctx, func(ctx context.Context) {
+		n.notifyUser(ctx, Bumped, reservation.UserId, reservation.RoomId, templateData{
+			Reservation:  reservation,
+			Alternatives: alternatives,
+		})
+	})
+}
+
+// WaitlistOffered lets the user know the slot they were waiting on is free and
+// how long they have to accept it
+func (n *Notifier) WaitlistOffered(ctx context.Context, entry models.WaitlistEntry) {
+	data := templateData{Entry: entry}
+	if entry.OfferExpires != nil {
+		data.OfferExpires = *entry.OfferExpires
+	}
+
+	n.async(ctx, func(ctx context.Context) { n.notifyUser(ctx, Waitlist, entry.UserId, entry.RoomId, data) })
+}
+
+// Close waits for the emails being sent to go, or for the context to be done. Nothing is
+// sent once it is closed. Reminders that are not due yet are kept in the database and sent
+// once the service is running again
+func (n *Notifier) Close(ctx context.Context) error {
+	n.mu.Lock()
+	n.closed = true
+	n.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		n.sending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// async sends a notification in a thread, so nothing waits on the email, that Close waits on.
+// The context supplied is carried over for its trace and request id but the email is still
+// sent once whatever made it is done
+func (n *Notifier) async(ctx context.Context, fn func(ctx context.Context)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.closed {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	n.sending.Add(1)
+	go func() {
+		defer n.sending.Done()
+		fn(ctx)
+	}()
+}
+
+// notify sends a notification to the user who made the reservation
+func (n *Notifier) notify(ctx context.Context, kind string, reservation models.Reservation) {
+	n.notifyUser(ctx, kind, reservation.UserId, reservation.RoomId, templateData{Reservation: reservation})
+}
+
+// notifyUser sends a notification about a room to a user. Errors are only logged
+// as nothing is waiting on the notification
+func (n *Notifier) notifyUser(ctx context.Context, kind string, userId int32, roomId int32, data templateData) {
+	err := n.send(ctx, kind, userId, roomId, data)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error sending notification", "kind", kind, "roomId", roomId, "error", err)
+	}
+}
+
+func (n *Notifier) send(ctx context.Context, kind string, userId int32, roomId int32, data templateData) error {
+	optOuts, err := dataAccess.GetNotificationOptOuts(ctx, userId, n.db)
+	if err != nil {
+		return err
+	}
+	for _, optOut := range optOuts {
+		if optOut == kind {
+			return nil
+		}
+	}
+
+	data.User, err = dataAccess.GetUser(ctx, userId, n.db)
+	if err != nil {
+		return err
+	}
+	if data.User.Email == "" {
+		return nil
+	}
+
+	data.Room, err = dataAccess.GetRoom(ctx, roomId, n.db)
+	if err != nil {
+		return err
+	}
+
+	subject, text, html, err := render(kind, data)
+	if err != nil {
+		return err
+	}
+
+	return n.sender.Send(data.User.Email, subject, text, html)
+}
+
+// render fills in the subject and the text and html bodies for a kind of notification
+func render(kind string, data templateData) (string, string, string, error) {
+	var text bytes.Buffer
+	err := textTemplates.ExecuteTemplate(&text, kind+".txt", data)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var html bytes.Buffer
+	err = htmlTemplates.ExecuteTemplate(&html, kind+".html", data)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return fmt.Sprintf(subjects[kind], data.Room.Name), text.String(), html.String(), nil
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"avaros/models"
+)
+
+func TestRender(t *testing.T) {
+	approvalExpires := time.Now().Add(time.Hour)
+	data := templateData{
+		User: models.User{Id: 1, Name: "Conor Downey", Email: "conor@avaros.local"},
+		Room: models.Room{Id: 1, Name: "Meeting Room"},
+		Reservation: models.Reservation{RoomId: 1, UserId: 1, StartTime: time.Now(),
+			ApprovalExpires: &approvalExpires, DecisionReason: "Room is being cleaned"},
+	}
+
+	for _, kind := range Kinds {
+		subject, text, html, err := render(kind, data)
+		if err != nil {
+			t.Fatalf("Error rendering %s notification: %s", kind, err.Error())
+		}
+
+		if !strings.Contains(subject, "Meeting Room") {
+			t.Errorf("Subject for %s notification should contain the room name", kind)
+		}
+
+		if !strings.Contains(text, "Conor Downey") || !strings.Contains(html, "Conor Downey") {
+			t.Errorf("Body for %s notification should contain the user's name", kind)
+		}
+	}
+}
+
+func TestBuildMessage(t *testing.T) {
+	msg, err := buildMessage("avaros@avaros.local", "conor@avaros.local", "Reservation confirmed",
+		"plain body", "<p>html body</p>")
+	if err != nil {
+		t.Fatalf("Error building message: %s", err.Error())
+	}
+
+	for _, expected := range []string{
+		"To: conor@avaros.local\r\n",
+		"Content-Type: multipart/alternative",
+		"plain body",
+		"<p>html body</p>",
+	} {
+		if !strings.Contains(string(msg), expected) {
+			t.Errorf("Message should contain %q", expected)
+		}
+	}
+}
+
+func TestClose(t *testing.T) {
+	n := NewNotifier(nil, 15*time.Minute, nil)
+
+	// an email is being sent
+	sending := make(chan struct{})
+	n.async(context.Background(), func(context.Context) { <-sending })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := n.Close(ctx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Close should give up waiting on the email being sent, got %v", err)
+	}
+
+	close(sending)
+	err = n.Close(context.Background())
+	if err != nil {
+		t.Errorf("Close should wait for the email being sent, got %s", err.Error())
+	}
+
+	n.async(context.Background(), func(context.Context) {
+		t.Errorf("Nothing should be sent once closed")
+	})
+	n.sending.Wait()
+}
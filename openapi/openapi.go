@@ -0,0 +1,75 @@
+/*
+	The OpenAPI document describing every route the rest services serve. It is written by hand
+	in openapi.yaml, served by the docs service and used to generate the client package.
+*/
+
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is the OpenAPI document as it is written, in YAML
+//
+//go:embed openapi.yaml
+var Document []byte
+
+// JSON converts the document to JSON, which is what Swagger UI and most other tools read. If
+// any tags are supplied only the operations with one of them are kept, so a server that does
+// not run every rest service does not document routes it does not serve
+func JSON(tags ...string) ([]byte, error) {
+	var doc map[string]interface{}
+	err := yaml.Unmarshal(Document, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) > 0 {
+		keepTagged(doc, tags)
+	}
+
+	return json.Marshal(doc)
+}
+
+// keepTagged removes the operations without any of the tags supplied from the document, the
+// paths left with no operations and the tags not supplied
+func keepTagged(doc map[string]interface{}, tags []string) {
+	keep := map[string]bool{}
+	for _, tag := range tags {
+		keep[tag] = true
+	}
+
+	paths, _ := doc["paths"].(map[string]interface{})
+	for path, item := range paths {
+		operations, _ := item.(map[string]interface{})
+		for method, operation := range operations {
+			operation, _ := operation.(map[string]interface{})
+			opTags, _ := operation["tags"].([]interface{})
+			tagged := false
+			for _, tag := range opTags {
+				if name, _ := tag.(string); keep[name] {
+					tagged = true
+				}
+			}
+			if !tagged {
+				delete(operations, method)
+			}
+		}
+		if len(operations) == 0 {
+			delete(paths, path)
+		}
+	}
+
+	docTags, _ := doc["tags"].([]interface{})
+	kept := []interface{}{}
+	for _, tag := range docTags {
+		tag, _ := tag.(map[string]interface{})
+		if name, _ := tag["name"].(string); keep[name] {
+			kept = append(kept, tag)
+		}
+	}
+	doc["tags"] = kept
+}
@@ -0,0 +1,78 @@
+/*
+	The OpenAPI document.
+*/
+
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestDocumentValid(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData(Document)
+	if err != nil {
+		t.Fatalf("Error loading the document: %s", err.Error())
+	}
+
+	err = doc.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("The document is not valid: %s", err.Error())
+	}
+
+	// every operation needs an id for the client to name its method after
+	for path, item := range doc.Paths.Map() {
+		for method, operation := range item.Operations() {
+			if operation.OperationID == "" {
+				t.Errorf("%s %s has no operation id", method, path)
+			}
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	document, err := JSON()
+	if err != nil {
+		t.Fatalf("Error converting the document: %s", err.Error())
+	}
+
+	var doc openapi3.T
+	err = json.Unmarshal(document, &doc)
+	if err != nil {
+		t.Fatalf("Error reading the converted document: %s", err.Error())
+	}
+
+	if doc.OpenAPI != "3.0.3" || doc.Paths.Find("/room/reserve/{id}") == nil {
+		t.Errorf("The converted document should match the YAML, got version %s", doc.OpenAPI)
+	}
+}
+
+func TestJSONTags(t *testing.T) {
+	document, err := JSON("rooms", "operations")
+	if err != nil {
+		t.Fatalf("Error converting the document: %s", err.Error())
+	}
+
+	doc, err := openapi3.NewLoader().LoadFromData(document)
+	if err != nil {
+		t.Fatalf("Error loading the converted document: %s", err.Error())
+	}
+
+	err = doc.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("The converted document is not valid: %s", err.Error())
+	}
+
+	if doc.Paths.Find("/room/reserve/{id}") == nil || doc.Paths.Find("/healthz") == nil {
+		t.Errorf("The operations with the tags supplied should be kept")
+	}
+	if doc.Paths.Find("/reservations/batch") != nil || doc.Paths.Find("/rooms/{id}/holds") != nil {
+		t.Errorf("The operations without the tags supplied should be removed")
+	}
+	if len(doc.Tags) != 2 {
+		t.Errorf("Only the tags supplied should be kept, got %d", len(doc.Tags))
+	}
+}
@@ -0,0 +1,689 @@
+/*
+	Stores rooms and reservations in memory. Follows the same rules as the Postgres data access
+	functions so the rest services can be run and tested without a database.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"avaros/calendar"
+	"avaros/dataAccess"
+	"avaros/models"
+)
+
+// delegation is a user and someone they have made their delegate
+type delegation struct {
+	userId     int32
+	delegateId int32
+}
+
+// Memory is a room and reservation repository that keeps everything in memory. Nothing is
+// sent to users when their reservations change
+type Memory struct {
+	mu             sync.Mutex
+	users          map[int32]models.User
+	rooms          map[int32]models.Room
+	reservations   []*models.Reservation // indexed by id - 1, nil once deleted
+	bumps          []models.Bump
+	delegations    map[delegation]bool
+	rolePriorities map[string]int32
+	quotas         []models.Quota
+	closures       []models.Closure
+	businessHours  map[string][]models.BusinessHours
+	holidays       map[string][]models.Holiday
+	nextRoomId     int32
+	// how long an approver has to answer a request before it is rejected
+	ApprovalWindow time.Duration
+}
+
+// NewMemory creates an empty in memory repository
+func NewMemory() *Memory {
+	return &Memory{
+		users:          map[int32]models.User{},
+		rooms:          map[int32]models.Room{},
+		delegations:    map[delegation]bool{},
+		rolePriorities: map[string]int32{},
+		businessHours:  map[string][]models.BusinessHours{},
+		holidays:       map[string][]models.Holiday{},
+		ApprovalWindow: 24 * time.Hour,
+	}
+}
+
+// AddUser adds a user. The id supplied is kept
+func (m *Memory) AddUser(user models.User) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.users[user.Id] = user
+}
+
+// AddRoom adds a room and returns the id it is given
+func (m *Memory) AddRoom(room models.Room) int32 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextRoomId++
+	room.Id = m.nextRoomId
+	m.rooms[room.Id] = room
+	return room.Id
+}
+
+// AddDelegation lets the delegate book, change and cancel reservations for the user
+func (m *Memory) AddDelegation(userId int32, delegateId int32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.delegations[delegation{userId, delegateId}] = true
+}
+
+// SetRolePriority sets the highest priority users with a role can reserve rooms at
+func (m *Memory) SetRolePriority(priority models.RolePriority) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rolePriorities[priority.Role] = priority.MaxPriority
+}
+
+// SetQuota sets the quota for a user, team, role or, if none of them are set, everyone,
+// replacing any quota they already had
+func (m *Memory) SetQuota(quota models.Quota) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	quotas := []models.Quota{}
+	for _, existing := range m.quotas {
+		if existing.Id >= quota.Id {
+			quota.Id = existing.Id + 1
+		}
+		if !sameQuotaScope(existing, quota) {
+			quotas = append(quotas, existing)
+		}
+	}
+	if quota.Id == 0 {
+		quota.Id = 1
+	}
+	m.quotas = append(quotas, quota)
+}
+
+// AddClosure closes a room, building or site
+func (m *Memory) AddClosure(closure models.Closure) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if closure.Recurrence == "" {
+		closure.Recurrence = models.RecurrenceNone
+	}
+	closure.Id = int32(len(m.closures) + 1)
+	m.closures = append(m.closures, closure)
+}
+
+// SetBusinessHours replaces the times a site is open each week
+func (m *Memory) SetBusinessHours(site string, hours []models.BusinessHours) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.businessHours[site] = hours
+}
+
+// AddHolidays adds days a site is closed
+func (m *Memory) AddHolidays(site string, days []models.Holiday) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.holidays[site] = append(m.holidays[site], days...)
+}
+
+// GetReservation gets a reservation by its id
+func (m *Memory) GetReservation(id int32) (models.Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if id < 1 || int(id) > len(m.reservations) || m.reservations[id-1] == nil {
+		return models.Reservation{}, fmt.Errorf("Reservation with id %d does not exist", id)
+	}
+
+	return *m.reservations[id-1], nil
+}
+
+// GetBumps gets the records of the user's reservations being displaced, newest first
+func (m *Memory) GetBumps(userId int32) []models.Bump {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bumps := []models.Bump{}
+	for i := len(m.bumps) - 1; i >= 0; i-- {
+		reservation := m.reservations[m.bumps[i].ReservationId-1]
+		if reservation != nil && reservation.UserId == userId {
+			bumps = append(bumps, m.bumps[i])
+		}
+	}
+
+	return bumps
+}
+
+func (m *Memory) CheckRoomExists(ctx context.Context, id int32) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.rooms[id]
+	return ok, nil
+}
+
+func (m *Memory) GetRoom(ctx context.Context, id int32) (models.Room, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.room(id)
+}
+
+func (m *Memory) RoomFits(ctx context.Context, roomId int32, people int) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	room, err := m.room(roomId)
+	if err != nil {
+		return false, err
+	}
+
+	return room.Capacity == nil || int(*room.Capacity) >= people, nil
+}
+
+// CheckOpen checks if the site a room is on is open for the whole of the time supplied.
+// Rooms that are not on a site, or do not exist, are always open
+func (m *Memory) CheckOpen(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.open(roomId, startTime, endTime), nil
+}
+
+func (m *Memory) CheckClosed(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.closed(roomId, startTime, endTime), nil
+}
+
+// SuggestAlternatives suggests the same room at the nearest times it is free for as long, and
+// rooms in the same building that fit everyone and have the same amenities that are free at the
+// time supplied. Rooms on the same floor and closest in size come first
+func (m *Memory) SuggestAlternatives(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time, people int) (models.Suggestions, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	suggestions := models.Suggestions{
+		SameRoom:   []models.Suggestion{},
+		OtherRooms: []models.Suggestion{},
+	}
+	room, err := m.room(roomId)
+	if err != nil {
+		return suggestions, err
+	}
+
+	if endTime != nil {
+		suggestions.SameRoom = m.sameRoomTimes(room, startTime, *endTime)
+	}
+	suggestions.OtherRooms = m.similarRooms(room, startTime, endTime, people)
+	return suggestions, nil
+}
+
+func (m *Memory) CheckReservation(ctx context.Context, roomId int32) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.current(roomId)) > 0, nil
+}
+
+// CheckReservationOverlap checks if a room has a reservation that overlaps the time supplied,
+// or is closed for any of it
+func (m *Memory) CheckReservationOverlap(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.slotTaken(roomId, startTime, endTime), nil
+}
+
+// Reserve reserves a room from now for the user, made by createdBy. Returns ErrSlotTaken if
+// the room is reserved or closed then and a QuotaError if it would take the user over their quota
+func (m *Memory) Reserve(ctx context.Context, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails) (int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reservation, err := m.reserve(roomId, userId, createdBy, expiryTime, details)
+	if err != nil {
+		return -1, err
+	}
+
+	return reservation.Id, nil
+}
+
+// ReserveRooms reserves every room supplied for the same time, or none of them. Reservations
+// with a lower priority than the details supplied are displaced rather than conflicting.
+// Returns a QuotaError if the reservations would take the user over their quota
+func (m *Memory) ReserveRooms(ctx context.Context, roomIds []int32, userId int32, createdBy int32, startTime time.Time, expiryTime int, details models.ReservationDetails) ([]int32, []int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	endTime := dataAccess.EndTime(startTime, expiryTime)
+
+	conflicts := []int32{}
+	displaced := map[int32][]*models.Reservation{}
+	for _, roomId := range roomIds {
+		room, err := m.room(roomId)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = checkApprover(room)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		bumpable, ok := m.preemptable(roomId, startTime, endTime, details.Priority)
+		if !ok {
+			conflicts = append(conflicts, roomId)
+		}
+		displaced[roomId] = bumpable
+	}
+
+	if len(conflicts) > 0 {
+		return nil, conflicts, nil
+	}
+
+	reservations := []models.Reservation{}
+	for _, roomId := range roomIds {
+		reservations = append(reservations, models.Reservation{
+			RoomId:             roomId,
+			UserId:             userId,
+			CreatedBy:          createdBy,
+			StartTime:          startTime,
+			EndTime:            endTime,
+			Status:             models.ReservationConfirmed,
+			ReservationDetails: details,
+		})
+	}
+
+	err := m.checkQuota(userId, reservations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := []int32{}
+	for _, reservation := range reservations {
+		inserted := m.insert(reservation)
+		m.bump(displaced[reservation.RoomId], inserted)
+		ids = append(ids, inserted.Id)
+	}
+
+	return ids, nil, nil
+}
+
+// ReserveLater reserves a room after the number of minutes supplied if it is free then
+func (m *Memory) ReserveLater(ctx context.Context, timeInFuture float64, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails) {
+	time.AfterFunc(time.Minute*time.Duration(timeInFuture), func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		// nothing is reserved if the room was taken in the meantime
+		m.reserve(roomId, userId, createdBy, expiryTime, details)
+	})
+}
+
+// CheckQuota checks reservations would not take the user over their quota without making
+// them. Returns a QuotaError if they would
+func (m *Memory) CheckQuota(ctx context.Context, userId int32, reservations []models.Reservation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.checkQuota(userId, reservations)
+}
+
+// DeleteReservation deletes every reservation of a room
+func (m *Memory) DeleteReservation(ctx context.Context, roomId int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, reservation := range m.reservations {
+		if reservation != nil && reservation.RoomId == roomId {
+			m.reservations[i] = nil
+		}
+	}
+
+	return nil
+}
+
+// EndReservation ends the reservation a room currently has early. Returns false if the
+// room has no current reservation
+func (m *Memory) EndReservation(ctx context.Context, roomId int32) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	ended := false
+	for _, reservation := range m.current(roomId) {
+		if reservation.Status == models.ReservationConfirmed {
+			reservation.Expired = true
+			reservation.EndTime = &now
+			ended = true
+		}
+	}
+
+	return ended, nil
+}
+
+// CanActFor checks if a user may book, change and cancel reservations for another user.
+// Users can always act for themselves
+func (m *Memory) CanActFor(ctx context.Context, delegateId int32, userId int32) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return delegateId == userId || m.delegations[delegation{userId, delegateId}], nil
+}
+
+// CanManageReservation checks if a user may change or cancel a room's current reservation.
+// They can if it is theirs, they are a delegate of whoever it is for or there is none
+func (m *Memory) CanManageReservation(ctx context.Context, roomId int32, userId int32) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, reservation := range m.current(roomId) {
+		if reservation.UserId != userId && !m.delegations[delegation{reservation.UserId, userId}] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// UserExists checks if a user id supplied is one of the users
+func (m *Memory) UserExists(ctx context.Context, userId int32) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.users[userId]
+	return ok, nil
+}
+
+// MaxPriority gets the highest priority a user can reserve rooms at. Users whose role
+// has no priority set can only make normal reservations
+func (m *Memory) MaxPriority(ctx context.Context, userId int32) (int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userId]
+	if !ok {
+		return models.PriorityNormal, fmt.Errorf("User with id %d does not exist", userId)
+	}
+
+	return m.rolePriorities[user.Role], nil
+}
+
+// room gets a room by its id. The lock must be held
+func (m *Memory) room(id int32) (models.Room, error) {
+	room, ok := m.rooms[id]
+	if !ok {
+		return room, fmt.Errorf("Room with id %d does not exist", id)
+	}
+
+	return room, nil
+}
+
+// reserve checks the quota and inserts a reservation starting now. The lock must be held
+func (m *Memory) reserve(roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails) (models.Reservation, error) {
+	reservation := models.Reservation{
+		RoomId:             roomId,
+		UserId:             userId,
+		CreatedBy:          createdBy,
+		StartTime:          time.Now(),
+		Status:             models.ReservationConfirmed,
+		ReservationDetails: details,
+	}
+	reservation.EndTime = dataAccess.EndTime(reservation.StartTime, expiryTime)
+
+	err := checkApprover(m.rooms[roomId])
+	if err != nil {
+		return reservation, err
+	}
+
+	if m.slotTaken(roomId, reservation.StartTime, reservation.EndTime) {
+		return reservation, dataAccess.ErrSlotTaken
+	}
+
+	err = m.checkQuota(userId, []models.Reservation{reservation})
+	if err != nil {
+		return reservation, err
+	}
+
+	return *m.insert(reservation), nil
+}
+
+// insert stores a reservation and gives it an id. A confirmed reservation on a room that
+// needs approval is made pending instead. The lock must be held
+func (m *Memory) insert(reservation models.Reservation) *models.Reservation {
+	if reservation.CreatedBy == 0 {
+		reservation.CreatedBy = reservation.UserId
+	}
+
+	awaitApproval(&reservation, m.rooms[reservation.RoomId], m.ApprovalWindow)
+
+	reservation.Id = int32(len(m.reservations) + 1)
+	// the same attendees can be supplied for several reservations
+	reservation.Attendees = append([]models.Attendee{}, reservation.Attendees...)
+	for i := range reservation.Attendees {
+		reservation.Attendees[i].ReservationId = reservation.Id
+		if reservation.Attendees[i].Rsvp == "" {
+			reservation.Attendees[i].Rsvp = models.RsvpPending
+		}
+	}
+
+	m.reservations = append(m.reservations, &reservation)
+	return &reservation
+}
+
+// current gets the reservations a room has now. The lock must be held
+func (m *Memory) current(roomId int32) []*models.Reservation {
+	now := time.Now()
+	current := []*models.Reservation{}
+	for _, reservation := range m.reservations {
+		if reservation == nil || reservation.RoomId != roomId || reservation.Expired ||
+			reservation.StartTime.After(now) || !heldAt(reservation, now) {
+			continue
+		}
+		if reservation.EndTime == nil || reservation.EndTime.After(now) {
+			current = append(current, reservation)
+		}
+	}
+
+	return current
+}
+
+// overlapping gets the reservations on a room that overlap the time supplied, keeping the
+// room's buffers free between them. A reservation that has ended still blocks its teardown
+// time. The lock must be held
+func (m *Memory) overlapping(roomId int32, startTime time.Time, endTime *time.Time) []*models.Reservation {
+	room := m.rooms[roomId]
+	gap := time.Minute * time.Duration(room.BufferBefore+room.BufferAfter)
+	now := time.Now()
+
+	overlapping := []*models.Reservation{}
+	for _, reservation := range m.reservations {
+		if reservation == nil || reservation.RoomId != roomId || !heldAt(reservation, now) {
+			continue
+		}
+		if reservation.Expired && reservation.Status != models.ReservationConfirmed {
+			continue
+		}
+		if endTime != nil && !reservation.StartTime.Before(endTime.Add(gap)) {
+			continue
+		}
+		if reservation.EndTime != nil && !reservation.EndTime.Add(gap).After(startTime) {
+			continue
+		}
+		// an expired reservation without an end cannot block anything
+		if reservation.Expired && reservation.EndTime == nil {
+			continue
+		}
+		overlapping = append(overlapping, reservation)
+	}
+
+	return overlapping
+}
+
+// closed checks if a room is closed for any of the time supplied. The lock must be held
+func (m *Memory) closed(roomId int32, startTime time.Time, endTime *time.Time) bool {
+	room, ok := m.rooms[roomId]
+	if !ok {
+		return false
+	}
+
+	for _, closure := range m.closures {
+		applies := (closure.RoomId != nil && *closure.RoomId == roomId) ||
+			(closure.Building != "" && closure.Building == room.Building) ||
+			(closure.Site != "" && closure.Site == room.Site)
+		if applies && closureOverlaps(closure, startTime, endTime) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// open checks if the site a room is on is open for the whole of the time supplied. The
+// lock must be held
+func (m *Memory) open(roomId int32, startTime time.Time, endTime *time.Time) bool {
+	room, ok := m.rooms[roomId]
+	if !ok || room.Site == "" {
+		return true
+	}
+
+	return calendar.IsOpen(m.businessHours[room.Site], m.holidays[room.Site], startTime, endTime)
+}
+
+// slotTaken checks if a room cannot be reserved for the time supplied, either because it is
+// closed or something else overlaps it. The lock must be held
+func (m *Memory) slotTaken(roomId int32, startTime time.Time, endTime *time.Time) bool {
+	return m.closed(roomId, startTime, endTime) || len(m.overlapping(roomId, startTime, endTime)) > 0
+}
+
+// preemptable checks if a room can be reserved at the priority supplied for the time supplied,
+// returning the reservations in the way that would be displaced. The lock must be held
+func (m *Memory) preemptable(roomId int32, startTime time.Time, endTime *time.Time, priority int32) ([]*models.Reservation, bool) {
+	if m.closed(roomId, startTime, endTime) {
+		return nil, false
+	}
+
+	overlapping := m.overlapping(roomId, startTime, endTime)
+	if len(overlapping) == 0 {
+		return nil, true
+	}
+	if priority <= models.PriorityNormal {
+		return nil, false
+	}
+
+	for _, reservation := range overlapping {
+		if reservation.Priority >= priority {
+			return nil, false
+		}
+	}
+
+	return overlapping, true
+}
+
+// bump displaces the reservations supplied with the one that took their time and records
+// why. The lock must be held
+func (m *Memory) bump(displaced []*models.Reservation, by *models.Reservation) {
+	reason := bumpReason(by)
+
+	for _, reservation := range displaced {
+		reservation.Status = models.ReservationBumped
+		reservation.Expired = true
+		reservation.DecisionReason = reason
+		m.bumps = append(m.bumps, models.Bump{
+			Id:              int32(len(m.bumps) + 1),
+			ReservationId:   reservation.Id,
+			ByReservationId: by.Id,
+			ByUserId:        by.CreatedBy,
+			Reason:          reason,
+			Created:         time.Now(),
+		})
+	}
+}
+
+// checkQuota checks the reservations supplied would not take the user over their quota.
+// The lock must be held
+func (m *Memory) checkQuota(userId int32, reservations []models.Reservation) error {
+	user, ok := m.users[userId]
+	if !ok {
+		user.Id = userId
+	}
+
+	booked := []*models.Reservation{}
+	for _, reservation := range m.reservations {
+		if reservation != nil && reservation.UserId == userId {
+			booked = append(booked, reservation)
+		}
+	}
+
+	return checkQuota(effectiveQuota(user, m.quotas), booked, reservations, time.Now())
+}
+
+// sameRoomTimes finds the nearest times to the one asked for that the room is free for as
+// long. The lock must be held
+func (m *Memory) sameRoomTimes(room models.Room, startTime time.Time, endTime time.Time) []models.Suggestion {
+	gap := time.Minute * time.Duration(room.BufferBefore+room.BufferAfter)
+	windowEnd := endTime.Add(suggestionWindow)
+	busy := m.overlapping(room.Id, startTime.Add(-suggestionWindow), &windowEnd)
+
+	suggestions := []models.Suggestion{}
+	for _, candidate := range suggestionTimes(busy, startTime, endTime, gap) {
+		candidateEnd := candidate.Add(endTime.Sub(startTime))
+		if m.slotTaken(room.Id, candidate, &candidateEnd) || !m.open(room.Id, candidate, &candidateEnd) {
+			continue
+		}
+
+		suggestions = append(suggestions, models.Suggestion{
+			Room:      room,
+			StartTime: candidate,
+			EndTime:   &candidateEnd,
+		})
+		if len(suggestions) == suggestionsPerKind {
+			break
+		}
+	}
+
+	return suggestions
+}
+
+// similarRooms finds other rooms in the same building as the room supplied that fit everyone
+// and have at least its amenities that are free for the time supplied. The lock must be held
+func (m *Memory) similarRooms(room models.Room, startTime time.Time, endTime *time.Time, people int) []models.Suggestion {
+	candidates := []models.Room{}
+	for _, candidate := range m.rooms {
+		if similarTo(room, candidate, people) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	sortSimilar(room, candidates)
+
+	suggestions := []models.Suggestion{}
+	for _, candidate := range candidates {
+		if m.slotTaken(candidate.Id, startTime, endTime) || !m.open(candidate.Id, startTime, endTime) {
+			continue
+		}
+
+		suggestions = append(suggestions, models.Suggestion{
+			Room:      candidate,
+			StartTime: startTime,
+			EndTime:   endTime,
+		})
+		if len(suggestions) == suggestionsPerKind {
+			break
+		}
+	}
+
+	return suggestions
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	dataAccess "avaros/dataAccess"
+	models "avaros/models"
+
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryBuffer(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemory()
+	roomId := repo.AddRoom(models.Room{Name: "Lunch Room", BufferAfter: 15})
+
+	startTime := time.Now().Add(time.Hour)
+	_, conflicts, err := repo.ReserveRooms(ctx, []int32{roomId}, 1, 1, startTime, 60, models.ReservationDetails{})
+	if err != nil || len(conflicts) != 0 {
+		t.Fatalf("Room should have been reserved, got %v, %v", conflicts, err)
+	}
+
+	// starts in the buffer after the first reservation
+	overlap, err := repo.CheckReservationOverlap(ctx, roomId, startTime.Add(70*time.Minute), nil)
+	if err != nil {
+		t.Errorf("Error checking overlap: %s", err.Error())
+	}
+
+	if !overlap {
+		t.Errorf("A reservation in the buffer should overlap")
+	}
+
+	overlap, err = repo.CheckReservationOverlap(ctx, roomId, startTime.Add(80*time.Minute), nil)
+	if err != nil {
+		t.Errorf("Error checking overlap: %s", err.Error())
+	}
+
+	if overlap {
+		t.Errorf("A reservation after the buffer should not overlap")
+	}
+}
+
+func TestMemoryReserveTaken(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemory()
+	roomId := repo.AddRoom(models.Room{Name: "Meeting Room"})
+
+	_, err := repo.Reserve(ctx, roomId, 1, 1, 60, models.ReservationDetails{})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	_, err = repo.Reserve(ctx, roomId, 2, 2, 60, models.ReservationDetails{})
+	if !errors.Is(err, dataAccess.ErrSlotTaken) {
+		t.Errorf("A reserved room should not be reserved again, got %v", err)
+	}
+}
+
+func TestMemoryNoApprover(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemory()
+	roomId := repo.AddRoom(models.Room{Name: "Boardroom", RequiresApproval: true})
+
+	_, _, err := repo.ReserveRooms(ctx, []int32{roomId}, 1, 1, time.Now().Add(time.Hour), 60, models.ReservationDetails{})
+	if !errors.Is(err, dataAccess.ErrNoApprover) {
+		t.Errorf("A room no one can approve should not be reserved, got %v", err)
+	}
+
+	_, err = repo.Reserve(ctx, roomId, 1, 1, 60, models.ReservationDetails{})
+	if !errors.Is(err, dataAccess.ErrNoApprover) {
+		t.Errorf("A room no one can approve should not be reserved, got %v", err)
+	}
+}
+
+func TestMemoryBump(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemory()
+	repo.AddUser(models.User{Id: 1, Role: "employee"})
+	repo.AddUser(models.User{Id: 2, Role: "manager"})
+	repo.SetRolePriority(models.RolePriority{Role: "manager", MaxPriority: models.PriorityCritical})
+	roomId := repo.AddRoom(models.Room{Name: "Boardroom"})
+
+	startTime := time.Now().Add(time.Hour)
+	ids, _, err := repo.ReserveRooms(ctx, []int32{roomId}, 1, 1, startTime, 60, models.ReservationDetails{})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	// the same priority conflicts
+	_, conflicts, err := repo.ReserveRooms(ctx, []int32{roomId}, 2, 2, startTime, 60, models.ReservationDetails{})
+	if err != nil || len(conflicts) != 1 {
+		t.Fatalf("Reservation should conflict, got %v, %v", conflicts, err)
+	}
+
+	byIds, conflicts, err := repo.ReserveRooms(ctx, []int32{roomId}, 2, 2, startTime, 60,
+		models.ReservationDetails{Title: "Board meeting", Priority: models.PriorityHigh})
+	if err != nil || len(conflicts) != 0 {
+		t.Fatalf("Reservation should displace the first, got %v, %v", conflicts, err)
+	}
+
+	bumped, err := repo.GetReservation(ids[0])
+	if err != nil {
+		t.Fatalf("Error getting reservation: %s", err.Error())
+	}
+
+	if bumped.Status != models.ReservationBumped {
+		t.Errorf("Reservation should be bumped, got %s", bumped.Status)
+	}
+
+	bumps := repo.GetBumps(1)
+	if len(bumps) != 1 || bumps[0].ByReservationId != byIds[0] {
+		t.Errorf("Bump should be recorded, got %v", bumps)
+	}
+}
+
+func TestMemoryQuota(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemory()
+	repo.AddUser(models.User{Id: 1, Role: "employee"})
+	roomId := repo.AddRoom(models.Room{Name: "Meeting Room"})
+
+	max := int32(1)
+	repo.SetQuota(models.Quota{MaxFutureReservations: &max})
+
+	startTime := time.Now().Add(time.Hour)
+	_, _, err := repo.ReserveRooms(ctx, []int32{roomId}, 1, 1, startTime, 60, models.ReservationDetails{})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	_, _, err = repo.ReserveRooms(ctx, []int32{roomId}, 1, 1, startTime.Add(2*time.Hour), 60, models.ReservationDetails{})
+	var quotaErr *dataAccess.QuotaError
+	if !errors.As(err, &quotaErr) {
+		t.Errorf("Reservation should be over the quota, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+	Stores rooms and reservations in Postgres through the data access functions.
+*/
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"avaros/dataAccess"
+	"avaros/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Postgres is a room and reservation repository backed by a Postgres database
+type Postgres struct {
+	Db *pgxpool.Pool
+}
+
+// NewPostgres creates a repository that uses the database supplied
+func NewPostgres(db *pgxpool.Pool) *Postgres {
+	return &Postgres{Db: db}
+}
+
+func (p *Postgres) CheckRoomExists(ctx context.Context, id int32) (bool, error) {
+	return dataAccess.CheckRoomExists(ctx, id, p.Db)
+}
+
+func (p *Postgres) GetRoom(ctx context.Context, id int32) (models.Room, error) {
+	return dataAccess.GetRoom(ctx, id, p.Db)
+}
+
+func (p *Postgres) RoomFits(ctx context.Context, roomId int32, people int) (bool, error) {
+	return dataAccess.RoomFits(ctx, roomId, people, p.Db)
+}
+
+func (p *Postgres) CheckOpen(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time) (bool, error) {
+	return dataAccess.CheckOpen(ctx, roomId, startTime, endTime, p.Db)
+}
+
+func (p *Postgres) CheckClosed(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time) (bool, error) {
+	return dataAccess.CheckClosed(ctx, roomId, startTime, endTime, p.Db)
+}
+
+func (p *Postgres) SuggestAlternatives(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time, people int) (models.Suggestions, error) {
+	return dataAccess.SuggestAlternatives(ctx, roomId, startTime, endTime, people, p.Db)
+}
+
+func (p *Postgres) CheckReservation(ctx context.Context, roomId int32) (bool, error) {
+	return dataAccess.CheckReservation(ctx, roomId, p.Db)
+}
+
+func (p *Postgres) CheckReservationOverlap(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time) (bool, error) {
+	return dataAccess.CheckReservationOverlap(ctx, roomId, startTime, endTime, p.Db)
+}
+
+func (p *Postgres) Reserve(ctx context.Context, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails) (int32, error) {
+	return dataAccess.Reserve(ctx, roomId, userId, createdBy, expiryTime, details, p.Db)
+}
+
+func (p *Postgres) ReserveRooms(ctx context.Context, roomIds []int32, userId int32, createdBy int32, startTime time.Time, expiryTime int, details models.ReservationDetails) ([]int32, []int32, error) {
+	return dataAccess.ReserveRooms(ctx, roomIds, userId, createdBy, startTime, expiryTime, details, p.Db)
+}
+
+func (p *Postgres) ReserveLater(ctx context.Context, timeInFuture float64, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails) {
+	dataAccess.CreateFutureReservation(ctx, timeInFuture, roomId, userId, createdBy, expiryTime, details, p.Db)
+}
+
+func (p *Postgres) CheckQuota(ctx context.Context, userId int32, reservations []models.Reservation) error {
+	return dataAccess.CheckQuota(ctx, userId, reservations, p.Db)
+}
+
+func (p *Postgres) DeleteReservation(ctx context.Context, roomId int32) error {
+	return dataAccess.DeleteReservation(ctx, roomId, p.Db)
+}
+
+func (p *Postgres) EndReservation(ctx context.Context, roomId int32) (bool, error) {
+	return dataAccess.EndReservation(ctx, roomId, p.Db)
+}
+
+func (p *Postgres) CanActFor(ctx context.Context, delegateId int32, userId int32) (bool, error) {
+	return dataAccess.CanActFor(ctx, delegateId, userId, p.Db)
+}
+
+func (p *Postgres) CanManageReservation(ctx context.Context, roomId int32, userId int32) (bool, error) {
+	return dataAccess.CanManageReservation(ctx, roomId, userId, p.Db)
+}
+
+func (p *Postgres) UserExists(ctx context.Context, userId int32) (bool, error) {
+	return dataAccess.UserExists(ctx, userId, p.Db)
+}
+
+func (p *Postgres) MaxPriority(ctx context.Context, userId int32) (int32, error) {
+	return dataAccess.MaxPriority(ctx, userId, p.Db)
+}
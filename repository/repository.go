@@ -0,0 +1,67 @@
+/*
+	Interfaces the rest services use to get at rooms and reservations, so they do not depend
+	on where they are stored.
+
+	Only the room service uses them so far. Every other rest service still calls dataAccess
+	with RestServiceObject.Db, so it needs Postgres, and SQLite can only run the room service.
+	The rest are to be moved over a service at a time: add the calls it makes to an interface
+	here, implement them for Postgres by calling dataAccess and for Memory and SQLite, then
+	switch the service from Db to the interface. Db goes once nothing uses it.
+*/
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"avaros/models"
+)
+
+// RoomRepository gets rooms and works out when they can be used
+type RoomRepository interface {
+	// CheckRoomExists checks if a room id supplied is one that can be reserved
+	CheckRoomExists(ctx context.Context, id int32) (bool, error)
+	// GetRoom gets a room by its id
+	GetRoom(ctx context.Context, id int32) (models.Room, error)
+	// RoomFits checks if the number of people supplied fit in a room
+	RoomFits(ctx context.Context, roomId int32, people int) (bool, error)
+	// CheckOpen checks if the site a room is on is open for the whole of the time supplied
+	CheckOpen(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time) (bool, error)
+	// CheckClosed checks if a room is closed for any of the time supplied
+	CheckClosed(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time) (bool, error)
+	// SuggestAlternatives suggests what could be reserved instead of a room that is taken
+	SuggestAlternatives(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time, people int) (models.Suggestions, error)
+}
+
+// ReservationRepository makes, checks and ends reservations and works out who can make them
+type ReservationRepository interface {
+	// CheckReservation checks if a room is reserved now
+	CheckReservation(ctx context.Context, roomId int32) (bool, error)
+	// CheckReservationOverlap checks if a room is reserved or closed for any of the time supplied
+	CheckReservationOverlap(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time) (bool, error)
+	// Reserve reserves a room from now for the user, made by createdBy. Returns
+	// dataAccess.ErrSlotTaken if the room was reserved or closed since it was checked
+	Reserve(ctx context.Context, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails) (int32, error)
+	// ReserveRooms reserves every room supplied for the same time, or none of them. Returns the
+	// ids of the reservations, or the rooms that could not be reserved. Returns
+	// dataAccess.ErrNoApprover if a room needs approval but has no one to give it
+	ReserveRooms(ctx context.Context, roomIds []int32, userId int32, createdBy int32, startTime time.Time, expiryTime int, details models.ReservationDetails) ([]int32, []int32, error)
+	// ReserveLater reserves a room after the number of minutes supplied if it is free then.
+	// Returns straight away, the context is only used for the request id to log with
+	ReserveLater(ctx context.Context, timeInFuture float64, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails)
+	// CheckQuota checks the reservations would not take the user over their quota
+	CheckQuota(ctx context.Context, userId int32, reservations []models.Reservation) error
+	// DeleteReservation deletes the reservations of a room
+	DeleteReservation(ctx context.Context, roomId int32) error
+	// EndReservation ends the reservation a room currently has early
+	EndReservation(ctx context.Context, roomId int32) (bool, error)
+	// CanActFor checks if a user may book, change and cancel reservations for another user
+	CanActFor(ctx context.Context, delegateId int32, userId int32) (bool, error)
+	// CanManageReservation checks if a user may change or cancel a room's current reservation
+	CanManageReservation(ctx context.Context, roomId int32, userId int32) (bool, error)
+	// MaxPriority gets the highest priority a user can reserve rooms at
+	MaxPriority(ctx context.Context, userId int32) (int32, error)
+	// UserExists checks if a user id supplied is one of the users
+	UserExists(ctx context.Context, userId int32) (bool, error)
+}
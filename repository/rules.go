@@ -0,0 +1,357 @@
+/*
+	The rules every repository follows when working out quotas, closures and suggestions,
+	whatever the rooms and reservations are stored in.
+*/
+
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"avaros/dataAccess"
+	"avaros/models"
+)
+
+// how many of each kind of alternative are suggested
+const suggestionsPerKind = 3
+
+// how far either side of the time asked for a room's other free times are looked for
+const suggestionWindow = 24 * time.Hour
+
+// effectiveQuota gets the limits that apply to a user, each taken from the most specific quota
+// that sets it: the user's own, then their team's, then their role's, then the one for everyone
+func effectiveQuota(user models.User, quotas []models.Quota) models.Quota {
+	quotas = append([]models.Quota{}, quotas...)
+	specificity := func(quota models.Quota) int {
+		switch {
+		case quota.UserId != nil:
+			return 0
+		case quota.Team != "":
+			return 1
+		case quota.Role != "":
+			return 2
+		}
+		return 3
+	}
+	sort.SliceStable(quotas, func(i, j int) bool {
+		return specificity(quotas[i]) < specificity(quotas[j])
+	})
+
+	effective := models.Quota{UserId: &user.Id}
+	for _, quota := range quotas {
+		applies := (quota.UserId != nil && *quota.UserId == user.Id) ||
+			(quota.Team != "" && quota.Team == user.Team) ||
+			(quota.Role != "" && quota.Role == user.Role) ||
+			specificity(quota) == 3
+		if !applies {
+			continue
+		}
+		if effective.MaxFutureReservations == nil {
+			effective.MaxFutureReservations = quota.MaxFutureReservations
+		}
+		if effective.MaxHoursPerWeek == nil {
+			effective.MaxHoursPerWeek = quota.MaxHoursPerWeek
+		}
+		if effective.MaxPerRoomPerDay == nil {
+			effective.MaxPerRoomPerDay = quota.MaxPerRoomPerDay
+		}
+	}
+
+	return effective
+}
+
+// checkQuota checks the reservations supplied would not take a user over their quota, given
+// the reservations they already have. Returns a QuotaError if they would
+func checkQuota(quota models.Quota, booked []*models.Reservation, reservations []models.Reservation, now time.Time) error {
+	if quota.MaxFutureReservations != nil {
+		var current int32
+		for _, reservation := range booked {
+			if counted(reservation, now) && !reservation.Expired &&
+				(reservation.EndTime == nil || reservation.EndTime.After(now)) {
+				current++
+			}
+		}
+		if current+int32(len(reservations)) > *quota.MaxFutureReservations {
+			return &dataAccess.QuotaError{Reason: fmt.Sprintf(
+				"You already have %d upcoming reservations, the most allowed is %d.", current, *quota.MaxFutureReservations)}
+		}
+	}
+
+	if quota.MaxHoursPerWeek != nil {
+		weeks := map[time.Time]float64{}
+		for _, reservation := range reservations {
+			week := weekStart(reservation.StartTime)
+			weeks[week] += hoursWithin(reservation.StartTime, reservation.EndTime, week, week.AddDate(0, 0, 7))
+		}
+		for week, hours := range weeks {
+			var hoursBooked float64
+			for _, reservation := range booked {
+				if counted(reservation, now) {
+					hoursBooked += hoursWithin(reservation.StartTime, reservation.EndTime, week, week.AddDate(0, 0, 7))
+				}
+			}
+			if hoursBooked+hours > *quota.MaxHoursPerWeek {
+				return &dataAccess.QuotaError{Reason: fmt.Sprintf(
+					"You have %.1f hours reserved the week of %s, the most allowed is %.1f.",
+					hoursBooked, week.Format("2 Jan"), *quota.MaxHoursPerWeek)}
+			}
+		}
+	}
+
+	if quota.MaxPerRoomPerDay != nil {
+		type roomDay struct {
+			roomId int32
+			day    time.Time
+		}
+		dayOf := func(reservation *models.Reservation) roomDay {
+			start := reservation.StartTime
+			return roomDay{reservation.RoomId, time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())}
+		}
+
+		days := map[roomDay]int32{}
+		for i := range reservations {
+			days[dayOf(&reservations[i])]++
+		}
+		for key, count := range days {
+			var dayBooked int32
+			for _, reservation := range booked {
+				if counted(reservation, now) && dayOf(reservation) == key {
+					dayBooked++
+				}
+			}
+			if dayBooked+count > *quota.MaxPerRoomPerDay {
+				return &dataAccess.QuotaError{Reason: fmt.Sprintf(
+					"You already have %d reservations of room %d on %s, the most allowed is %d.",
+					dayBooked, key.roomId, key.day.Format("2 Jan"), *quota.MaxPerRoomPerDay)}
+			}
+		}
+	}
+
+	return nil
+}
+
+// quotaFrom gets the earliest time a user's reservations can count towards the quota for the
+// reservations supplied. Anything that ended before then can be ignored
+func quotaFrom(reservations []models.Reservation, now time.Time) time.Time {
+	from := now
+	for _, reservation := range reservations {
+		if week := weekStart(reservation.StartTime); week.Before(from) {
+			from = week
+		}
+	}
+
+	return from
+}
+
+// suggestionTimes gets the times nearest the one asked for that a room could be free for as
+// long, either side of the reservations in the way, nearest first
+func suggestionTimes(busy []*models.Reservation, startTime time.Time, endTime time.Time, gap time.Duration) []time.Time {
+	length := endTime.Sub(startTime)
+	candidates := []time.Time{}
+	for _, reservation := range busy {
+		candidates = append(candidates, reservation.StartTime.Add(-gap-length))
+		if reservation.EndTime != nil {
+			candidates = append(candidates, reservation.EndTime.Add(gap))
+		}
+	}
+
+	distance := func(t time.Time) time.Duration {
+		if t.Before(startTime) {
+			return startTime.Sub(t)
+		}
+		return t.Sub(startTime)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return distance(candidates[i]) < distance(candidates[j])
+	})
+
+	now := time.Now()
+	times := []time.Time{}
+	for i, candidate := range candidates {
+		if candidate.Before(now) || candidate.Equal(startTime) || (i > 0 && candidate.Equal(candidates[i-1])) {
+			continue
+		}
+		times = append(times, candidate)
+	}
+
+	return times
+}
+
+// similarTo checks a room is in the same building as another, fits everyone and has at least
+// its amenities
+func similarTo(room models.Room, candidate models.Room, people int) bool {
+	return candidate.Id != room.Id && candidate.Building == room.Building &&
+		hasAmenities(candidate, room.Amenities) &&
+		(candidate.Capacity == nil || int(*candidate.Capacity) >= people)
+}
+
+// sortSimilar sorts rooms similar to the one supplied so those on the same floor and closest
+// in size come first
+func sortSimilar(room models.Room, candidates []models.Room) {
+	sameFloor := func(candidate models.Room) bool {
+		if candidate.Floor == nil || room.Floor == nil {
+			return candidate.Floor == room.Floor
+		}
+		return *candidate.Floor == *room.Floor
+	}
+	sizeDifference := func(candidate models.Room) int32 {
+		var size, wanted int32
+		if candidate.Capacity != nil {
+			size = *candidate.Capacity
+		}
+		if room.Capacity != nil {
+			wanted = *room.Capacity
+		}
+		if size > wanted {
+			return size - wanted
+		}
+		return wanted - size
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if sameFloor(candidates[i]) != sameFloor(candidates[j]) {
+			return sameFloor(candidates[i])
+		}
+		if sizeDifference(candidates[i]) != sizeDifference(candidates[j]) {
+			return sizeDifference(candidates[i]) < sizeDifference(candidates[j])
+		}
+		return candidates[i].Id < candidates[j].Id
+	})
+}
+
+// bumpReason gets why reservations were displaced by the one supplied
+func bumpReason(by *models.Reservation) string {
+	reason := fmt.Sprintf("Displaced by a priority %d reservation", by.Priority)
+	if by.Title != "" {
+		reason += ": " + by.Title
+	}
+
+	return reason
+}
+
+// heldAt checks a reservation is not a hold that has run out by the time supplied
+func heldAt(reservation *models.Reservation, at time.Time) bool {
+	return reservation.Status != models.ReservationHold ||
+		(reservation.HoldExpires != nil && reservation.HoldExpires.After(at))
+}
+
+// counted checks if a reservation counts towards its user's quota
+func counted(reservation *models.Reservation, at time.Time) bool {
+	switch reservation.Status {
+	case models.ReservationConfirmed, models.ReservationPending:
+		return true
+	case models.ReservationHold:
+		return heldAt(reservation, at)
+	}
+	return false
+}
+
+// closureOverlaps checks if an occurrence of a closure overlaps the start and end supplied.
+// An empty end runs forever, so the occurrences of a closure that repeats forever are only
+// worked out for a year past the start
+func closureOverlaps(closure models.Closure, startTime time.Time, endTime *time.Time) bool {
+	length := closure.EndTime.Sub(closure.StartTime)
+
+	last := closure.StartTime
+	if closure.Recurrence != models.RecurrenceNone {
+		if closure.RepeatUntil != nil {
+			last = *closure.RepeatUntil
+		} else if endTime != nil && endTime.After(last) {
+			last = *endTime
+		} else if endTime == nil && startTime.AddDate(1, 0, 0).After(last) {
+			last = startTime.AddDate(1, 0, 0)
+		}
+	}
+
+	for occurrence := closure.StartTime; !occurrence.After(last); occurrence = nextOccurrence(occurrence, closure.Recurrence) {
+		if (endTime == nil || occurrence.Before(*endTime)) && occurrence.Add(length).After(startTime) {
+			return true
+		}
+		if closure.Recurrence == models.RecurrenceNone {
+			break
+		}
+	}
+
+	return false
+}
+
+// nextOccurrence gets when a closure that repeats as supplied next starts
+func nextOccurrence(occurrence time.Time, recurrence string) time.Time {
+	switch recurrence {
+	case models.RecurrenceWeekly:
+		return occurrence.AddDate(0, 0, 7)
+	case models.RecurrenceYearly:
+		return occurrence.AddDate(1, 0, 0)
+	}
+	return occurrence.AddDate(0, 0, 1)
+}
+
+// hasAmenities checks a room has every one of the amenities supplied
+func hasAmenities(room models.Room, amenities []string) bool {
+	has := map[string]bool{}
+	for _, amenity := range room.Amenities {
+		has[amenity] = true
+	}
+	for _, amenity := range amenities {
+		if !has[amenity] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameQuotaScope checks if two quotas are for the same user, team, role or everyone
+func sameQuotaScope(a models.Quota, b models.Quota) bool {
+	sameUser := (a.UserId == nil && b.UserId == nil) || (a.UserId != nil && b.UserId != nil && *a.UserId == *b.UserId)
+	return sameUser && a.Team == b.Team && a.Role == b.Role
+}
+
+// hoursWithin gets how many hours of a reservation fall between from and to. A reservation
+// without an end runs until to
+func hoursWithin(start time.Time, end *time.Time, from time.Time, to time.Time) float64 {
+	finish := to
+	if end != nil && end.Before(to) {
+		finish = *end
+	}
+	if start.Before(from) {
+		start = from
+	}
+	if !finish.After(start) {
+		return 0
+	}
+
+	return finish.Sub(start).Hours()
+}
+
+// weekStart gets midnight on the Monday of the week the time supplied is in
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// checkApprover checks reservations of a room that needs approval can be approved by someone
+func checkApprover(room models.Room) error {
+	if room.RequiresApproval && room.ApproverId == nil {
+		return dataAccess.ErrNoApprover
+	}
+
+	return nil
+}
+
+// awaitApproval makes a confirmed reservation of a room that needs approval pending until the
+// approver answers it or the window supplied runs out, whichever is first of that and its start
+func awaitApproval(reservation *models.Reservation, room models.Room, window time.Duration) {
+	if reservation.Status != models.ReservationConfirmed || !room.RequiresApproval {
+		return
+	}
+
+	approvalExpires := time.Now().Add(window)
+	if reservation.StartTime.After(time.Now()) && reservation.StartTime.Before(approvalExpires) {
+		approvalExpires = reservation.StartTime
+	}
+	reservation.Status = models.ReservationPending
+	reservation.ApproverId = room.ApproverId
+	reservation.ApprovalExpires = &approvalExpires
+}
@@ -0,0 +1,950 @@
+/*
+	Stores rooms and reservations in a SQLite database, so a single node can be run without
+	Postgres. Follows the same rules as the Postgres data access functions. Nothing is sent to
+	users when their reservations change.
+*/
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"avaros/calendar"
+	"avaros/dataAccess"
+	"avaros/database"
+	"avaros/models"
+
+	_ "modernc.org/sqlite"
+)
+
+// the layout times are stored in, always in UTC
+const sqliteTimeLayout = "2006-01-02 15:04:05.000000000"
+
+// the columns a reservation is read from, in the order scanReservation expects them
+const sqliteReservationColumns = `
+	id, room_id, user_id, created_by, start_time, end_time, expired, status, hold_expires,
+	approver_id, approval_expires, COALESCE(decision_reason, ''), COALESCE(title, ''),
+	COALESCE(description, ''), private, priority
+`
+
+// the columns a room is read from, in the order scanSQLiteRoom expects them
+const sqliteRoomColumns = `
+	id, COALESCE(name, ''), COALESCE(building, ''), COALESCE(site, ''), floor, amenities,
+	requires_approval, approver_id, capacity, buffer_before, buffer_after
+`
+
+// sqlQuerier is something queries can be run on, either the database or a transaction
+type sqlQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// sqlScanner is a row or rows that can be scanned
+type sqlScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// SQLite is a room and reservation repository backed by a SQLite database
+type SQLite struct {
+	Db *sql.DB
+	// how long an approver has to answer a request before it is rejected
+	ApprovalWindow time.Duration
+
+	// the reservations waiting to be made later, so they can be stopped on Close
+	mu      sync.Mutex
+	later   map[*time.Timer]struct{}
+	running sync.WaitGroup
+	closing bool
+}
+
+// NewSQLite opens the SQLite database at the path supplied, creating it if it does not exist,
+// and brings its schema up to date
+func NewSQLite(path string) (*SQLite, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite only has one writer at a time, so every check and the write that depends on it
+	// have the one connection to themselves. It also keeps an in memory database alive
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec("PRAGMA foreign_keys = ON")
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	err = database.MigrateSQLite(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLite{Db: db, ApprovalWindow: 24 * time.Hour}, nil
+}
+
+// Close stops any reservations waiting to be made later, waits for those being made and
+// closes the database
+func (s *SQLite) Close() error {
+	s.mu.Lock()
+	s.closing = true
+	for timer := range s.later {
+		// a stopped timer's reservation will never be made, so nothing waits on it
+		if timer.Stop() {
+			s.running.Done()
+		}
+	}
+	s.later = nil
+	s.mu.Unlock()
+
+	// let reservations that are being made finish before the database goes
+	s.running.Wait()
+	return s.Db.Close()
+}
+
+// GetReservation gets a reservation by its id
+func (s *SQLite) GetReservation(id int32) (models.Reservation, error) {
+	reservation, err := scanSQLiteReservation(s.Db.QueryRow(`
+		SELECT `+sqliteReservationColumns+` FROM reservation WHERE id = ?
+	`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return reservation, fmt.Errorf("Reservation with id %d does not exist", id)
+	}
+
+	return reservation, err
+}
+
+func (s *SQLite) CheckRoomExists(ctx context.Context, id int32) (bool, error) {
+	var exists bool
+	err := s.Db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM room WHERE id = ?)", id).Scan(&exists)
+	return exists, err
+}
+
+func (s *SQLite) GetRoom(ctx context.Context, id int32) (models.Room, error) {
+	room, found, err := s.room(ctx, s.Db, id)
+	if err == nil && !found {
+		err = fmt.Errorf("Room with id %d does not exist", id)
+	}
+
+	return room, err
+}
+
+func (s *SQLite) RoomFits(ctx context.Context, roomId int32, people int) (bool, error) {
+	room, err := s.GetRoom(ctx, roomId)
+	if err != nil {
+		return false, err
+	}
+
+	return room.Capacity == nil || int(*room.Capacity) >= people, nil
+}
+
+// CheckOpen checks if the site a room is on is open for the whole of the time supplied.
+// Rooms that are not on a site, or do not exist, are always open
+func (s *SQLite) CheckOpen(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time) (bool, error) {
+	room, _, err := s.room(ctx, s.Db, roomId)
+	if err != nil {
+		return false, err
+	}
+
+	return s.open(ctx, s.Db, room, startTime, endTime)
+}
+
+func (s *SQLite) CheckClosed(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time) (bool, error) {
+	room, found, err := s.room(ctx, s.Db, roomId)
+	if err != nil || !found {
+		return false, err
+	}
+
+	return s.closed(ctx, s.Db, room, startTime, endTime)
+}
+
+// SuggestAlternatives suggests the same room at the nearest times it is free for as long, and
+// rooms in the same building that fit everyone and have the same amenities that are free at the
+// time supplied. Rooms on the same floor and closest in size come first
+func (s *SQLite) SuggestAlternatives(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time, people int) (models.Suggestions, error) {
+	suggestions := models.Suggestions{
+		SameRoom:   []models.Suggestion{},
+		OtherRooms: []models.Suggestion{},
+	}
+	room, err := s.GetRoom(ctx, roomId)
+	if err != nil {
+		return suggestions, err
+	}
+
+	if endTime != nil {
+		suggestions.SameRoom, err = s.sameRoomTimes(ctx, room, startTime, *endTime)
+		if err != nil {
+			return suggestions, err
+		}
+	}
+	suggestions.OtherRooms, err = s.similarRooms(ctx, room, startTime, endTime, people)
+	return suggestions, err
+}
+
+func (s *SQLite) CheckReservation(ctx context.Context, roomId int32) (bool, error) {
+	current, err := s.current(ctx, s.Db, roomId)
+	return len(current) > 0, err
+}
+
+// CheckReservationOverlap checks if a room has a reservation that overlaps the time supplied,
+// or is closed for any of it
+func (s *SQLite) CheckReservationOverlap(ctx context.Context, roomId int32, startTime time.Time, endTime *time.Time) (bool, error) {
+	room, _, err := s.room(ctx, s.Db, roomId)
+	if err != nil {
+		return false, err
+	}
+
+	return s.slotTaken(ctx, s.Db, room, startTime, endTime)
+}
+
+// Reserve reserves a room from now for the user, made by createdBy. Returns ErrSlotTaken if
+// the room is reserved or closed then and a QuotaError if it would take the user over their quota
+func (s *SQLite) Reserve(ctx context.Context, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails) (int32, error) {
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return -1, err
+	}
+	defer tx.Rollback()
+
+	reservation, err := s.reserve(ctx, tx, roomId, userId, createdBy, expiryTime, details)
+	if err != nil {
+		return -1, err
+	}
+
+	return reservation.Id, tx.Commit()
+}
+
+// ReserveRooms reserves every room supplied for the same time, or none of them. Reservations
+// with a lower priority than the details supplied are displaced rather than conflicting.
+// Returns a QuotaError if the reservations would take the user over their quota
+func (s *SQLite) ReserveRooms(ctx context.Context, roomIds []int32, userId int32, createdBy int32, startTime time.Time, expiryTime int, details models.ReservationDetails) ([]int32, []int32, error) {
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	endTime := dataAccess.EndTime(startTime, expiryTime)
+
+	conflicts := []int32{}
+	displaced := map[int32][]*models.Reservation{}
+	for _, roomId := range roomIds {
+		room, found, err := s.room(ctx, tx, roomId)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !found {
+			return nil, nil, fmt.Errorf("Room with id %d does not exist", roomId)
+		}
+
+		bumpable, ok, err := s.preemptable(ctx, tx, room, startTime, endTime, details.Priority)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			conflicts = append(conflicts, roomId)
+		}
+		displaced[roomId] = bumpable
+	}
+
+	if len(conflicts) > 0 {
+		return nil, conflicts, nil
+	}
+
+	reservations := []models.Reservation{}
+	for _, roomId := range roomIds {
+		reservations = append(reservations, models.Reservation{
+			RoomId:             roomId,
+			UserId:             userId,
+			CreatedBy:          createdBy,
+			StartTime:          startTime,
+			EndTime:            endTime,
+			Status:             models.ReservationConfirmed,
+			ReservationDetails: details,
+		})
+	}
+
+	err = s.checkQuota(ctx, tx, userId, reservations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := []int32{}
+	for _, reservation := range reservations {
+		inserted, err := s.insert(ctx, tx, reservation)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = s.bump(ctx, tx, displaced[reservation.RoomId], inserted)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids = append(ids, inserted.Id)
+	}
+
+	return ids, nil, tx.Commit()
+}
+
+// ReserveLater reserves a room after the number of minutes supplied if it is free then. Nothing
+// is reserved if the database is closed first
+func (s *SQLite) ReserveLater(ctx context.Context, timeInFuture float64, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closing {
+		return
+	}
+	if s.later == nil {
+		s.later = map[*time.Timer]struct{}{}
+	}
+
+	// the request that asked for it will have long finished
+	ctx = context.WithoutCancel(ctx)
+	s.running.Add(1)
+	var timer *time.Timer
+	timer = time.AfterFunc(time.Minute*time.Duration(timeInFuture), func() {
+		defer s.running.Done()
+		s.mu.Lock()
+		delete(s.later, timer)
+		s.mu.Unlock()
+
+		err := s.reserveIfFree(ctx, roomId, userId, createdBy, expiryTime, details)
+		var quotaErr *dataAccess.QuotaError
+		if errors.As(err, &quotaErr) {
+			slog.InfoContext(ctx, "Future reservation not created", "roomId", roomId, "reason", quotaErr.Reason)
+		} else if err != nil {
+			slog.ErrorContext(ctx, "Error creating future reservation", "roomId", roomId, "error", err)
+		}
+	})
+	s.later[timer] = struct{}{}
+}
+
+// CheckQuota checks reservations would not take the user over their quota without making
+// them. Returns a QuotaError if they would
+func (s *SQLite) CheckQuota(ctx context.Context, userId int32, reservations []models.Reservation) error {
+	return s.checkQuota(ctx, s.Db, userId, reservations)
+}
+
+// DeleteReservation deletes every reservation of a room
+func (s *SQLite) DeleteReservation(ctx context.Context, roomId int32) error {
+	_, err := s.Db.ExecContext(ctx, "DELETE FROM reservation WHERE room_id = ?", roomId)
+	return err
+}
+
+// EndReservation ends the reservation a room currently has early. Returns false if the
+// room has no current reservation
+func (s *SQLite) EndReservation(ctx context.Context, roomId int32) (bool, error) {
+	now := sqliteTime(time.Now())
+	result, err := s.Db.ExecContext(ctx, `
+		UPDATE reservation SET expired = true, end_time = ?1
+		WHERE room_id = ?2 AND status = 'confirmed' AND expired = false
+		AND start_time <= ?1 AND (end_time IS NULL OR end_time > ?1)
+	`, now, roomId)
+	if err != nil {
+		return false, err
+	}
+
+	ended, err := result.RowsAffected()
+	return ended > 0, err
+}
+
+// CanActFor checks if a user may book, change and cancel reservations for another user.
+// Users can always act for themselves
+func (s *SQLite) CanActFor(ctx context.Context, delegateId int32, userId int32) (bool, error) {
+	if delegateId == userId {
+		return true, nil
+	}
+
+	var delegated bool
+	err := s.Db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM delegation WHERE user_id = ? AND delegate_id = ?)
+	`, userId, delegateId).Scan(&delegated)
+	return delegated, err
+}
+
+// CanManageReservation checks if a user may change or cancel a room's current reservation.
+// They can if it is theirs, they are a delegate of whoever it is for or there is none
+func (s *SQLite) CanManageReservation(ctx context.Context, roomId int32, userId int32) (bool, error) {
+	current, err := s.current(ctx, s.Db, roomId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, reservation := range current {
+		allowed, err := s.CanActFor(ctx, userId, reservation.UserId)
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// UserExists checks if a user id supplied is one of the users
+func (s *SQLite) UserExists(ctx context.Context, userId int32) (bool, error) {
+	var exists bool
+	err := s.Db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM users WHERE id = ?)
+	`, userId).Scan(&exists)
+	return exists, err
+}
+
+// MaxPriority gets the highest priority a user can reserve rooms at. Users whose role
+// has no priority set can only make normal reservations
+func (s *SQLite) MaxPriority(ctx context.Context, userId int32) (int32, error) {
+	var priority int32
+	err := s.Db.QueryRowContext(ctx, `
+		SELECT COALESCE(role_priority.max_priority, 0)
+		FROM users LEFT JOIN role_priority ON role_priority.role = users.role
+		WHERE users.id = ?
+	`, userId).Scan(&priority)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.PriorityNormal, fmt.Errorf("User with id %d does not exist", userId)
+	}
+
+	return priority, err
+}
+
+// room gets a room by its id, and whether it exists
+func (s *SQLite) room(ctx context.Context, q sqlQuerier, id int32) (models.Room, bool, error) {
+	room, err := scanSQLiteRoom(q.QueryRowContext(ctx, "SELECT "+sqliteRoomColumns+" FROM room WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Room{Id: id}, false, nil
+	}
+
+	return room, err == nil, err
+}
+
+// reserveIfFree reserves a room from now if nothing overlaps it
+func (s *SQLite) reserveIfFree(ctx context.Context, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails) error {
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = s.reserve(ctx, tx, roomId, userId, createdBy, expiryTime, details)
+	if errors.Is(err, dataAccess.ErrSlotTaken) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// reserve checks the room is free and the quota and inserts a reservation starting now
+func (s *SQLite) reserve(ctx context.Context, tx *sql.Tx, roomId int32, userId int32, createdBy int32, expiryTime int, details models.ReservationDetails) (models.Reservation, error) {
+	reservation := models.Reservation{
+		RoomId:             roomId,
+		UserId:             userId,
+		CreatedBy:          createdBy,
+		StartTime:          time.Now(),
+		Status:             models.ReservationConfirmed,
+		ReservationDetails: details,
+	}
+	reservation.EndTime = dataAccess.EndTime(reservation.StartTime, expiryTime)
+
+	room, found, err := s.room(ctx, tx, roomId)
+	if err != nil {
+		return reservation, err
+	}
+	if !found {
+		return reservation, fmt.Errorf("Room with id %d does not exist", roomId)
+	}
+	taken, err := s.slotTaken(ctx, tx, room, reservation.StartTime, reservation.EndTime)
+	if err != nil {
+		return reservation, err
+	}
+	if taken {
+		return reservation, dataAccess.ErrSlotTaken
+	}
+
+	err = s.checkQuota(ctx, tx, userId, []models.Reservation{reservation})
+	if err != nil {
+		return reservation, err
+	}
+
+	inserted, err := s.insert(ctx, tx, reservation)
+	if err != nil {
+		return reservation, err
+	}
+
+	return *inserted, nil
+}
+
+// insert stores a reservation and its attendees. A confirmed reservation on a room that
+// needs approval is made pending instead
+func (s *SQLite) insert(ctx context.Context, tx *sql.Tx, reservation models.Reservation) (*models.Reservation, error) {
+	if reservation.CreatedBy == 0 {
+		reservation.CreatedBy = reservation.UserId
+	}
+
+	room, _, err := s.room(ctx, tx, reservation.RoomId)
+	if err != nil {
+		return nil, err
+	}
+	err = checkApprover(room)
+	if err != nil {
+		return nil, err
+	}
+	awaitApproval(&reservation, room, s.ApprovalWindow)
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO
+			reservation (room_id, user_id, created_by, start_time, end_time, status, hold_expires,
+				approver_id, approval_expires, title, description, private, priority)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`, reservation.RoomId, reservation.UserId, reservation.CreatedBy, sqliteTime(reservation.StartTime),
+		sqliteNullTime(reservation.EndTime), reservation.Status, sqliteNullTime(reservation.HoldExpires),
+		reservation.ApproverId, sqliteNullTime(reservation.ApprovalExpires), reservation.Title,
+		reservation.Description, reservation.Private, reservation.Priority).Scan(&reservation.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the same attendees can be supplied for several reservations
+	reservation.Attendees = append([]models.Attendee{}, reservation.Attendees...)
+	for i := range reservation.Attendees {
+		attendee := &reservation.Attendees[i]
+		attendee.ReservationId = reservation.Id
+		if attendee.Rsvp == "" {
+			attendee.Rsvp = models.RsvpPending
+		}
+
+		var email interface{}
+		if attendee.Email != "" {
+			email = attendee.Email
+		}
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO attendee (reservation_id, user_id, email, rsvp) VALUES (?, ?, ?, ?) RETURNING id
+		`, attendee.ReservationId, attendee.UserId, email, attendee.Rsvp).Scan(&attendee.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &reservation, nil
+}
+
+// current gets the reservations a room has now
+func (s *SQLite) current(ctx context.Context, q sqlQuerier, roomId int32) ([]*models.Reservation, error) {
+	return querySQLiteReservations(ctx, q, `
+		SELECT `+sqliteReservationColumns+` FROM reservation
+		WHERE room_id = ?1 AND expired = false AND start_time <= ?2
+		AND (end_time IS NULL OR end_time > ?2)
+		AND (status != 'hold' OR hold_expires > ?2)
+	`, roomId, sqliteTime(time.Now()))
+}
+
+// overlapping gets the reservations on a room that overlap the time supplied, keeping the
+// room's buffers free between them. A reservation that has ended still blocks its teardown time
+func (s *SQLite) overlapping(ctx context.Context, q sqlQuerier, room models.Room, startTime time.Time, endTime *time.Time) ([]*models.Reservation, error) {
+	gap := time.Minute * time.Duration(room.BufferBefore+room.BufferAfter)
+
+	var until interface{}
+	if endTime != nil {
+		until = sqliteTime(endTime.Add(gap))
+	}
+
+	return querySQLiteReservations(ctx, q, `
+		SELECT `+sqliteReservationColumns+` FROM reservation
+		WHERE room_id = ?1
+		AND (status != 'hold' OR hold_expires > ?2)
+		AND (expired = false OR (status = 'confirmed' AND end_time IS NOT NULL))
+		AND (?3 IS NULL OR start_time < ?3)
+		AND (end_time IS NULL OR end_time > ?4)
+		ORDER BY start_time
+	`, room.Id, sqliteTime(time.Now()), until, sqliteTime(startTime.Add(-gap)))
+}
+
+// closed checks if a room is closed for any of the time supplied
+func (s *SQLite) closed(ctx context.Context, q sqlQuerier, room models.Room, startTime time.Time, endTime *time.Time) (bool, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT start_time, end_time, recurrence, repeat_until FROM closure
+		WHERE room_id = ? OR building = ? OR site = ?
+	`, room.Id, room.Building, room.Site)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var closure models.Closure
+		var start, end string
+		var repeatUntil sql.NullString
+		err = rows.Scan(&start, &end, &closure.Recurrence, &repeatUntil)
+		if err != nil {
+			return false, err
+		}
+
+		closure.StartTime, err = parseSQLiteTime(start)
+		if err == nil {
+			closure.EndTime, err = parseSQLiteTime(end)
+		}
+		if err == nil {
+			closure.RepeatUntil, err = parseSQLiteNullTime(repeatUntil)
+		}
+		if err != nil {
+			return false, err
+		}
+
+		if closureOverlaps(closure, startTime, endTime) {
+			return true, nil
+		}
+	}
+
+	return false, rows.Err()
+}
+
+// open checks if the site a room is on is open for the whole of the time supplied
+func (s *SQLite) open(ctx context.Context, q sqlQuerier, room models.Room, startTime time.Time, endTime *time.Time) (bool, error) {
+	if room.Site == "" {
+		return true, nil
+	}
+
+	rows, err := q.QueryContext(ctx, "SELECT weekday, opens, closes FROM business_hours WHERE site = ?", room.Site)
+	if err != nil {
+		return false, err
+	}
+
+	hours := []models.BusinessHours{}
+	for rows.Next() {
+		var open models.BusinessHours
+		err = rows.Scan(&open.Weekday, &open.Opens, &open.Closes)
+		if err != nil {
+			rows.Close()
+			return false, err
+		}
+		hours = append(hours, open)
+	}
+	rows.Close()
+
+	rows, err = q.QueryContext(ctx, `
+		SELECT id, site, date, COALESCE(name, '') FROM holiday WHERE site = ? AND date >= ?
+	`, room.Site, startTime.Format("2006-01-02"))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	holidays := []models.Holiday{}
+	for rows.Next() {
+		var holiday models.Holiday
+		var date string
+		err = rows.Scan(&holiday.Id, &holiday.Site, &date, &holiday.Name)
+		if err != nil {
+			return false, err
+		}
+
+		holiday.Date, err = time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			return false, err
+		}
+		holidays = append(holidays, holiday)
+	}
+
+	return calendar.IsOpen(hours, holidays, startTime, endTime), rows.Err()
+}
+
+// slotTaken checks if a room cannot be reserved for the time supplied, either because it is
+// closed or something else overlaps it
+func (s *SQLite) slotTaken(ctx context.Context, q sqlQuerier, room models.Room, startTime time.Time, endTime *time.Time) (bool, error) {
+	closed, err := s.closed(ctx, q, room, startTime, endTime)
+	if err != nil || closed {
+		return closed, err
+	}
+
+	overlapping, err := s.overlapping(ctx, q, room, startTime, endTime)
+	return len(overlapping) > 0, err
+}
+
+// preemptable checks if a room can be reserved at the priority supplied for the time supplied,
+// returning the reservations in the way that would be displaced
+func (s *SQLite) preemptable(ctx context.Context, q sqlQuerier, room models.Room, startTime time.Time, endTime *time.Time, priority int32) ([]*models.Reservation, bool, error) {
+	closed, err := s.closed(ctx, q, room, startTime, endTime)
+	if err != nil || closed {
+		return nil, false, err
+	}
+
+	overlapping, err := s.overlapping(ctx, q, room, startTime, endTime)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(overlapping) == 0 {
+		return nil, true, nil
+	}
+	if priority <= models.PriorityNormal {
+		return nil, false, nil
+	}
+
+	for _, reservation := range overlapping {
+		if reservation.Priority >= priority {
+			return nil, false, nil
+		}
+	}
+
+	return overlapping, true, nil
+}
+
+// bump displaces the reservations supplied with the one that took their time and records why
+func (s *SQLite) bump(ctx context.Context, tx *sql.Tx, displaced []*models.Reservation, by *models.Reservation) error {
+	reason := bumpReason(by)
+	for _, reservation := range displaced {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE reservation SET status = ?, expired = true, decision_reason = ? WHERE id = ?
+		`, models.ReservationBumped, reason, reservation.Id)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO bump (reservation_id, by_reservation_id, reason) VALUES (?, ?, ?)
+		`, reservation.Id, by.Id, reason)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkQuota checks the reservations supplied would not take the user over their quota
+func (s *SQLite) checkQuota(ctx context.Context, q sqlQuerier, userId int32, reservations []models.Reservation) error {
+	user := models.User{Id: userId}
+	err := q.QueryRowContext(ctx, `
+		SELECT COALESCE(team, ''), COALESCE(role, '') FROM users WHERE id = ?
+	`, userId).Scan(&user.Team, &user.Role)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, user_id, COALESCE(team, ''), COALESCE(role, ''), max_future_reservations,
+			max_hours_per_week, max_per_room_per_day
+		FROM quota
+	`)
+	if err != nil {
+		return err
+	}
+
+	quotas := []models.Quota{}
+	for rows.Next() {
+		var quota models.Quota
+		err = rows.Scan(&quota.Id, &quota.UserId, &quota.Team, &quota.Role, &quota.MaxFutureReservations,
+			&quota.MaxHoursPerWeek, &quota.MaxPerRoomPerDay)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		quotas = append(quotas, quota)
+	}
+	rows.Close()
+
+	quota := effectiveQuota(user, quotas)
+	if quota.MaxFutureReservations == nil && quota.MaxHoursPerWeek == nil && quota.MaxPerRoomPerDay == nil {
+		return nil
+	}
+
+	now := time.Now()
+	booked, err := querySQLiteReservations(ctx, q, `
+		SELECT `+sqliteReservationColumns+` FROM reservation
+		WHERE user_id = ? AND (end_time IS NULL OR end_time > ?)
+	`, userId, sqliteTime(quotaFrom(reservations, now)))
+	if err != nil {
+		return err
+	}
+
+	return checkQuota(quota, booked, reservations, now)
+}
+
+// sameRoomTimes finds the nearest times to the one asked for that the room is free for as long
+func (s *SQLite) sameRoomTimes(ctx context.Context, room models.Room, startTime time.Time, endTime time.Time) ([]models.Suggestion, error) {
+	gap := time.Minute * time.Duration(room.BufferBefore+room.BufferAfter)
+	windowEnd := endTime.Add(suggestionWindow)
+	busy, err := s.overlapping(ctx, s.Db, room, startTime.Add(-suggestionWindow), &windowEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []models.Suggestion{}
+	for _, candidate := range suggestionTimes(busy, startTime, endTime, gap) {
+		candidateEnd := candidate.Add(endTime.Sub(startTime))
+		free, err := s.freeAndOpen(ctx, room, candidate, &candidateEnd)
+		if err != nil {
+			return nil, err
+		}
+		if !free {
+			continue
+		}
+
+		suggestions = append(suggestions, models.Suggestion{
+			Room:      room,
+			StartTime: candidate,
+			EndTime:   &candidateEnd,
+		})
+		if len(suggestions) == suggestionsPerKind {
+			break
+		}
+	}
+
+	return suggestions, nil
+}
+
+// similarRooms finds other rooms in the same building as the room supplied that fit everyone
+// and have at least its amenities that are free for the time supplied
+func (s *SQLite) similarRooms(ctx context.Context, room models.Room, startTime time.Time, endTime *time.Time, people int) ([]models.Suggestion, error) {
+	rows, err := s.Db.QueryContext(ctx, "SELECT "+sqliteRoomColumns+" FROM room WHERE building = ?", room.Building)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []models.Room{}
+	for rows.Next() {
+		candidate, err := scanSQLiteRoom(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if similarTo(room, candidate, people) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	rows.Close()
+	sortSimilar(room, candidates)
+
+	suggestions := []models.Suggestion{}
+	for _, candidate := range candidates {
+		free, err := s.freeAndOpen(ctx, candidate, startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+		if !free {
+			continue
+		}
+
+		suggestions = append(suggestions, models.Suggestion{
+			Room:      candidate,
+			StartTime: startTime,
+			EndTime:   endTime,
+		})
+		if len(suggestions) == suggestionsPerKind {
+			break
+		}
+	}
+
+	return suggestions, nil
+}
+
+// freeAndOpen checks a room could be reserved for the time supplied
+func (s *SQLite) freeAndOpen(ctx context.Context, room models.Room, startTime time.Time, endTime *time.Time) (bool, error) {
+	taken, err := s.slotTaken(ctx, s.Db, room, startTime, endTime)
+	if err != nil || taken {
+		return false, err
+	}
+
+	return s.open(ctx, s.Db, room, startTime, endTime)
+}
+
+// querySQLiteReservations gets the reservations a query returns
+func querySQLiteReservations(ctx context.Context, q sqlQuerier, query string, args ...interface{}) ([]*models.Reservation, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reservations := []*models.Reservation{}
+	for rows.Next() {
+		reservation, err := scanSQLiteReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, &reservation)
+	}
+
+	return reservations, rows.Err()
+}
+
+// scanSQLiteReservation scans a reservation read from sqliteReservationColumns
+func scanSQLiteReservation(row sqlScanner) (models.Reservation, error) {
+	var reservation models.Reservation
+	var start string
+	var end, holdExpires, approvalExpires sql.NullString
+	err := row.Scan(&reservation.Id, &reservation.RoomId, &reservation.UserId, &reservation.CreatedBy,
+		&start, &end, &reservation.Expired, &reservation.Status, &holdExpires, &reservation.ApproverId,
+		&approvalExpires, &reservation.DecisionReason, &reservation.Title, &reservation.Description,
+		&reservation.Private, &reservation.Priority)
+	if err != nil {
+		return reservation, err
+	}
+
+	reservation.StartTime, err = parseSQLiteTime(start)
+	if err == nil {
+		reservation.EndTime, err = parseSQLiteNullTime(end)
+	}
+	if err == nil {
+		reservation.HoldExpires, err = parseSQLiteNullTime(holdExpires)
+	}
+	if err == nil {
+		reservation.ApprovalExpires, err = parseSQLiteNullTime(approvalExpires)
+	}
+
+	return reservation, err
+}
+
+// scanSQLiteRoom scans a room read from sqliteRoomColumns
+func scanSQLiteRoom(row sqlScanner) (models.Room, error) {
+	var room models.Room
+	var amenities string
+	err := row.Scan(&room.Id, &room.Name, &room.Building, &room.Site, &room.Floor, &amenities,
+		&room.RequiresApproval, &room.ApproverId, &room.Capacity, &room.BufferBefore, &room.BufferAfter)
+	if err != nil {
+		return room, err
+	}
+
+	err = json.Unmarshal([]byte(amenities), &room.Amenities)
+	return room, err
+}
+
+// sqliteTime formats a time the way it is stored
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeLayout)
+}
+
+// sqliteNullTime formats a time that may be empty the way it is stored
+func sqliteNullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+
+	return sqliteTime(*t)
+}
+
+// parseSQLiteTime parses a stored time
+func parseSQLiteTime(value string) (time.Time, error) {
+	t, err := time.ParseInLocation(sqliteTimeLayout, value, time.UTC)
+	return t.Local(), err
+}
+
+// parseSQLiteNullTime parses a stored time that may be empty
+func parseSQLiteNullTime(value sql.NullString) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+
+	t, err := parseSQLiteTime(value.String)
+	return &t, err
+}
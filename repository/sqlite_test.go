@@ -0,0 +1,295 @@
+package repository
+
+import (
+	dataAccess "avaros/dataAccess"
+	models "avaros/models"
+
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// the rooms the SQLite database is created with
+const (
+	meetingRoom    = 1
+	conferenceRoom = 2
+	lunchRoom      = 3
+	boardroom      = 4
+)
+
+func TestSQLiteMigrate(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "avaros.db")
+	repo, err := NewSQLite(path)
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err.Error())
+	}
+	_, err = repo.Reserve(ctx, meetingRoom, 1, 1, 0, models.ReservationDetails{Title: "Stand up"})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+	repo.Close()
+
+	// opening it again keeps everything and does not seed it twice
+	repo, err = NewSQLite(path)
+	if err != nil {
+		t.Fatalf("Error reopening database: %s", err.Error())
+	}
+	defer repo.Close()
+
+	exists, err := repo.CheckReservation(ctx, meetingRoom)
+	if err != nil {
+		t.Errorf("Error checking a reservation: %s", err.Error())
+	}
+
+	if !exists {
+		t.Errorf("Reservation should still exist")
+	}
+
+	exists, err = repo.CheckRoomExists(ctx, boardroom+1)
+	if err != nil {
+		t.Errorf("Error checking a room: %s", err.Error())
+	}
+
+	if exists {
+		t.Errorf("Rooms should only be created once")
+	}
+}
+
+func TestSQLiteReservation(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestSQLite(t)
+
+	_, err := repo.Reserve(ctx, meetingRoom, 1, 1, 60, models.ReservationDetails{})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	manage, err := repo.CanManageReservation(ctx, meetingRoom, 3)
+	if err != nil {
+		t.Errorf("Error checking who can manage a reservation: %s", err.Error())
+	}
+
+	if manage {
+		t.Errorf("Only the organizer should be able to manage the reservation")
+	}
+
+	ended, err := repo.EndReservation(ctx, meetingRoom)
+	if err != nil {
+		t.Errorf("Error ending a reservation: %s", err.Error())
+	}
+
+	if !ended {
+		t.Errorf("Reservation should have ended")
+	}
+
+	exists, err := repo.CheckReservation(ctx, meetingRoom)
+	if err != nil {
+		t.Errorf("Error checking a reservation: %s", err.Error())
+	}
+
+	if exists {
+		t.Errorf("Reservation should not exist once it has ended")
+	}
+}
+
+func TestSQLiteBuffer(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestSQLite(t)
+
+	startTime := time.Now().Add(time.Hour)
+	_, conflicts, err := repo.ReserveRooms(ctx, []int32{lunchRoom}, 1, 1, startTime, 60, models.ReservationDetails{})
+	if err != nil || len(conflicts) != 0 {
+		t.Fatalf("Room should have been reserved, got %v, %v", conflicts, err)
+	}
+
+	// starts in the cleaning time after the first reservation
+	overlap, err := repo.CheckReservationOverlap(ctx, lunchRoom, startTime.Add(70*time.Minute), nil)
+	if err != nil {
+		t.Errorf("Error checking overlap: %s", err.Error())
+	}
+
+	if !overlap {
+		t.Errorf("A reservation in the buffer should overlap")
+	}
+
+	overlap, err = repo.CheckReservationOverlap(ctx, lunchRoom, startTime.Add(80*time.Minute), nil)
+	if err != nil {
+		t.Errorf("Error checking overlap: %s", err.Error())
+	}
+
+	if overlap {
+		t.Errorf("A reservation after the buffer should not overlap")
+	}
+
+	end := startTime.Add(time.Hour)
+	suggestions, err := repo.SuggestAlternatives(ctx, lunchRoom, startTime, &end, 1)
+	if err != nil {
+		t.Fatalf("Error suggesting alternatives: %s", err.Error())
+	}
+
+	if len(suggestions.SameRoom) == 0 || !suggestions.SameRoom[0].StartTime.Equal(startTime.Add(75*time.Minute)) {
+		t.Errorf("The lunch room should be suggested once it has been cleaned, got %v", suggestions.SameRoom)
+	}
+}
+
+func TestSQLiteApproval(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestSQLite(t)
+
+	ids, _, err := repo.ReserveRooms(ctx, []int32{boardroom}, 1, 1, time.Now().Add(time.Hour), 60, models.ReservationDetails{})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	reservation, err := repo.GetReservation(ids[0])
+	if err != nil {
+		t.Fatalf("Error getting reservation: %s", err.Error())
+	}
+
+	if reservation.Status != models.ReservationPending || reservation.ApproverId == nil || *reservation.ApproverId != 2 {
+		t.Errorf("Reservation should be waiting for Jane to approve it, got %v", reservation)
+	}
+}
+
+func TestSQLiteNoApprover(t *testing.T) {
+	repo := newTestSQLite(t)
+
+	_, err := repo.Db.Exec(`UPDATE room SET approver_id = NULL WHERE id = ?`, boardroom)
+	if err == nil {
+		t.Errorf("A room that needs approval should not be left without an approver")
+	}
+
+	_, err = repo.Db.Exec(`
+		INSERT INTO room (name, requires_approval) VALUES ('Library', true)
+	`)
+	if err == nil {
+		t.Errorf("A room that needs approval should not be added without an approver")
+	}
+}
+
+func TestSQLiteBump(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestSQLite(t)
+
+	startTime := time.Now().Add(time.Hour)
+	ids, _, err := repo.ReserveRooms(ctx, []int32{conferenceRoom}, 1, 1, startTime, 60, models.ReservationDetails{})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	// the same priority conflicts
+	_, conflicts, err := repo.ReserveRooms(ctx, []int32{conferenceRoom}, 2, 2, startTime, 60, models.ReservationDetails{})
+	if err != nil || len(conflicts) != 1 {
+		t.Fatalf("Reservation should conflict, got %v, %v", conflicts, err)
+	}
+
+	_, conflicts, err = repo.ReserveRooms(ctx, []int32{conferenceRoom}, 2, 2, startTime, 60,
+		models.ReservationDetails{Title: "Board meeting", Priority: models.PriorityHigh})
+	if err != nil || len(conflicts) != 0 {
+		t.Fatalf("Reservation should displace the first, got %v, %v", conflicts, err)
+	}
+
+	bumped, err := repo.GetReservation(ids[0])
+	if err != nil {
+		t.Fatalf("Error getting reservation: %s", err.Error())
+	}
+
+	if bumped.Status != models.ReservationBumped || bumped.DecisionReason != "Displaced by a priority 1 reservation: Board meeting" {
+		t.Errorf("Reservation should be bumped, got %v", bumped)
+	}
+}
+
+func TestSQLiteQuota(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestSQLite(t)
+
+	_, err := repo.Db.Exec("INSERT INTO quota (team, max_future_reservations) VALUES ('Engineering', 1)")
+	if err != nil {
+		t.Fatalf("Error setting quota: %s", err.Error())
+	}
+
+	startTime := time.Now().Add(time.Hour)
+	_, _, err = repo.ReserveRooms(ctx, []int32{meetingRoom}, 1, 1, startTime, 60, models.ReservationDetails{})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	_, _, err = repo.ReserveRooms(ctx, []int32{meetingRoom}, 1, 1, startTime.Add(2*time.Hour), 60, models.ReservationDetails{})
+	var quotaErr *dataAccess.QuotaError
+	if !errors.As(err, &quotaErr) {
+		t.Errorf("Reservation should be over the team's quota, got %v", err)
+	}
+
+	// managers are not in the team
+	_, _, err = repo.ReserveRooms(ctx, []int32{meetingRoom}, 2, 2, startTime.Add(2*time.Hour), 60, models.ReservationDetails{})
+	if err != nil {
+		t.Errorf("Reservation should not be limited by another team's quota, got %v", err)
+	}
+}
+
+// newTestSQLite creates a repository with an in memory database that is closed when the
+// test ends
+func TestSQLiteConcurrentReservations(t *testing.T) {
+	repo := newTestSQLite(t)
+
+	// only one of the reservations made at the same time gets the room
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.Reserve(context.Background(), meetingRoom, 1, 1, 60, models.ReservationDetails{})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	reserved := 0
+	for err := range errs {
+		if err == nil {
+			reserved++
+		} else if !errors.Is(err, dataAccess.ErrSlotTaken) {
+			t.Errorf("Error reserving a room: %s", err.Error())
+		}
+	}
+
+	if reserved != 1 {
+		t.Errorf("The room should be reserved once, got %d", reserved)
+	}
+}
+
+func newTestSQLite(t *testing.T) *SQLite {
+	repo, err := NewSQLite(":memory:")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err.Error())
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	return repo
+}
+
+func TestSQLiteCloseStopsReserveLater(t *testing.T) {
+	repo := newTestSQLite(t)
+
+	repo.ReserveLater(context.Background(), 60, meetingRoom, 1, 1, 0, models.ReservationDetails{})
+	if len(repo.later) != 1 {
+		t.Fatalf("The reservation should be waiting to be made, got %d", len(repo.later))
+	}
+
+	// closing does not wait an hour for the reservation to be made
+	err := repo.Close()
+	if err != nil {
+		t.Fatalf("Error closing database: %s", err.Error())
+	}
+
+	repo.ReserveLater(context.Background(), 60, meetingRoom, 1, 1, 0, models.ReservationDetails{})
+	if len(repo.later) != 0 {
+		t.Errorf("Nothing should wait to be reserved once the database is closed, got %d", len(repo.later))
+	}
+}
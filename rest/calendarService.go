@@ -0,0 +1,130 @@
+/*
+	The calendar rest service. Sets the business hours and holidays of each site.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"avaros/calendar"
+	"avaros/dataAccess"
+	"avaros/models"
+
+	"github.com/gocraft/web"
+)
+
+type CalendarService struct {
+	RestObj RestServiceObject
+}
+
+// Init initialises the service and starts listening for its paths
+func (cs *CalendarService) Init() error {
+	if cs.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+
+	cs.RestObj.Router.Get("/sites/:site/business-hours", cs.getBusinessHours)
+	cs.RestObj.Router.Put("/sites/:site/business-hours", cs.setBusinessHours)
+	cs.RestObj.Router.Get("/sites/:site/holidays", cs.getHolidays)
+	cs.RestObj.Router.Post("/sites/:site/holidays", cs.addHolidays)
+	cs.RestObj.Router.Delete("/sites/:site/holidays/:id", cs.deleteHoliday)
+	return nil
+}
+
+// getBusinessHours gets the times a site is open each week
+func (cs *CalendarService) getBusinessHours(rw web.ResponseWriter, req *web.Request) {
+	hours, err := dataAccess.GetBusinessHours(req.Context(), req.PathParams["site"], cs.RestObj.Db)
+	if err != nil {
+		panic("Error getting business hours: " + err.Error())
+	}
+
+	sendResponse(hours, rw)
+}
+
+// setBusinessHours replaces the times a site is open each week. An empty list opens the
+// site all day, every day
+func (cs *CalendarService) setBusinessHours(rw web.ResponseWriter, req *web.Request) {
+	var hours []models.BusinessHours
+	readRequest(req, &hours)
+
+	for _, open := range hours {
+		if open.Weekday < time.Sunday || open.Weekday > time.Saturday {
+			panic(fmt.Sprintf("Invalid weekday %d, expected 0 (Sunday) to 6", open.Weekday))
+		}
+		opens, err := calendar.ParseClock(open.Opens)
+		if err != nil {
+			panic(err.Error())
+		}
+		closes, err := calendar.ParseClock(open.Closes)
+		if err != nil {
+			panic(err.Error())
+		}
+		if closes <= opens {
+			panic(fmt.Sprintf("Site must close after it opens, %s is not after %s", open.Closes, open.Opens))
+		}
+	}
+
+	err := dataAccess.SetBusinessHours(req.Context(), req.PathParams["site"], hours, cs.RestObj.Db)
+	if err != nil {
+		panic("Error setting business hours: " + err.Error())
+	}
+
+	sendResponse(ReservationResponse{Result: true}, rw)
+}
+
+// getHolidays gets a site's upcoming holidays
+func (cs *CalendarService) getHolidays(rw web.ResponseWriter, req *web.Request) {
+	holidays, err := dataAccess.GetHolidays(req.Context(), req.PathParams["site"], time.Now(), cs.RestObj.Db)
+	if err != nil {
+		panic("Error getting holidays: " + err.Error())
+	}
+
+	sendResponse(holidays, rw)
+}
+
+// addHolidays loads holidays into a site's calendar. The body is read as an iCalendar file
+// if its content type is text/calendar, otherwise as a JSON list of dates and names
+func (cs *CalendarService) addHolidays(rw web.ResponseWriter, req *web.Request) {
+	defer req.Body.Close()
+
+	var holidays []models.Holiday
+	var err error
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "text/calendar") {
+		holidays, err = calendar.ParseICS(req.Body)
+	} else {
+		holidays, err = calendar.ParseHolidaysJSON(req.Body)
+	}
+	if err != nil {
+		panic("Error reading holidays: " + err.Error())
+	}
+
+	err = dataAccess.AddHolidays(req.Context(), req.PathParams["site"], holidays, cs.RestObj.Db)
+	if err != nil {
+		panic("Error adding holidays: " + err.Error())
+	}
+
+	sendResponse(ReservationResponse{Result: true}, rw)
+}
+
+// deleteHoliday removes a holiday from a site's calendar
+func (cs *CalendarService) deleteHoliday(rw web.ResponseWriter, req *web.Request) {
+	holidayId := getIdAsInt(req.PathParams["id"])
+
+	deleted, err := dataAccess.DeleteHoliday(req.Context(), req.PathParams["site"], holidayId, cs.RestObj.Db)
+	if err != nil {
+		panic("Error deleting holiday: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: deleted,
+	}
+	if !deleted {
+		resRsp.Reason = fmt.Sprintf("Holiday with id %d does not exist.", holidayId)
+	}
+
+	sendResponse(resRsp, rw)
+}
@@ -0,0 +1,132 @@
+/*
+	The closure rest service. Closes rooms, buildings or sites so they cannot be reserved.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"avaros/dataAccess"
+	"avaros/models"
+	"avaros/router"
+
+	"github.com/gocraft/web"
+)
+
+type ClosureService struct {
+	RestObj RestServiceObject
+}
+
+// The request object when closing a room, building or site. If cancelReservations is
+// set any reservations that fall inside the closure are cancelled
+type ClosureRequest struct {
+	models.Closure
+	CancelReservations bool `json:"cancelReservations"`
+}
+
+// The response when a closure is created, with the ids of any reservations it cancelled
+type ClosureResponse struct {
+	Result    bool    `json:"result"`
+	Id        int32   `json:"id"`
+	Cancelled []int32 `json:"cancelled"`
+}
+
+// Init initialises the service and starts listening for its paths
+func (cs *ClosureService) Init() error {
+	if cs.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+
+	cs.RestObj.Router.Get("/closures", cs.getClosures)
+	cs.RestObj.Router.Post("/closures", cs.createClosure)
+	cs.RestObj.Router.Delete("/closures/:id", cs.deleteClosure)
+	return nil
+}
+
+// getClosures gets every closure, or only the ones that apply to the room in the roomId query
+func (cs *ClosureService) getClosures(rw web.ResponseWriter, req *web.Request) {
+	var roomId int32
+	if value := req.URL.Query().Get("roomId"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			panic("Error getting room id: " + err.Error())
+		}
+		roomId = int32(id)
+	}
+
+	closures, err := dataAccess.GetClosures(req.Context(), roomId, cs.RestObj.Db)
+	if err != nil {
+		panic("Error getting closures: " + err.Error())
+	}
+
+	sendResponse(closures, rw)
+}
+
+// createClosure closes a room, building or site for a time, once or on a schedule
+func (cs *ClosureService) createClosure(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	var closeReq ClosureRequest
+	readRequest(req, &closeReq)
+
+	closure := closeReq.Closure
+	scopes := 0
+	if closure.RoomId != nil {
+		scopes++
+	}
+	if closure.Building != "" {
+		scopes++
+	}
+	if closure.Site != "" {
+		scopes++
+	}
+	if scopes != 1 {
+		panic("Exactly one of a room id, building or site must be supplied")
+	}
+
+	if closure.StartTime.IsZero() || !closure.EndTime.After(closure.StartTime) {
+		panic("A closure must have a start time and an end time after it")
+	}
+	closure.StartTime = closure.StartTime.Local()
+	closure.EndTime = closure.EndTime.Local()
+	if closure.RepeatUntil != nil {
+		repeatUntil := closure.RepeatUntil.Local()
+		closure.RepeatUntil = &repeatUntil
+	}
+
+	switch closure.Recurrence {
+	case "":
+		closure.Recurrence = models.RecurrenceNone
+	case models.RecurrenceNone, models.RecurrenceDaily, models.RecurrenceWeekly, models.RecurrenceYearly:
+	default:
+		panic(fmt.Sprintf("Unknown recurrence: %s", closure.Recurrence))
+	}
+	closure.CreatedBy = ctx.UserId
+
+	cancelled, err := dataAccess.CreateClosure(req.Context(), &closure, closeReq.CancelReservations, cs.RestObj.Db)
+	if err != nil {
+		panic("Error creating closure: " + err.Error())
+	}
+
+	sendResponse(ClosureResponse{Result: true, Id: closure.Id, Cancelled: cancelled}, rw)
+}
+
+// deleteClosure reopens whatever a closure closed
+func (cs *ClosureService) deleteClosure(rw web.ResponseWriter, req *web.Request) {
+	closureId := getIdAsInt(req.PathParams["id"])
+
+	deleted, err := dataAccess.DeleteClosure(req.Context(), closureId, cs.RestObj.Db)
+	if err != nil {
+		panic("Error deleting closure: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: deleted,
+	}
+	if !deleted {
+		resRsp.Reason = fmt.Sprintf("Closure with id %d does not exist.", closureId)
+	}
+
+	sendResponse(resRsp, rw)
+}
@@ -0,0 +1,82 @@
+/*
+	The delegation rest service. Lets users allow others to book rooms for them.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+
+	"avaros/dataAccess"
+	"avaros/router"
+
+	"github.com/gocraft/web"
+)
+
+type DelegationService struct {
+	RestObj RestServiceObject
+}
+
+// The request object when making someone a delegate
+type DelegationRequest struct {
+	DelegateId int32 `json:"delegateId"`
+}
+
+// Init initialises the service and starts listening for its paths
+func (ds *DelegationService) Init() error {
+	if ds.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+
+	ds.RestObj.Router.Get("/delegations", ds.getDelegations)
+	ds.RestObj.Router.Post("/delegations", ds.grantDelegation)
+	ds.RestObj.Router.Delete("/delegations/:id", ds.revokeDelegation)
+	return nil
+}
+
+// getDelegations gets the delegates the user has and the users they are a delegate of
+func (ds *DelegationService) getDelegations(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	delegations, err := dataAccess.GetDelegations(req.Context(), ctx.UserId, ds.RestObj.Db)
+	if err != nil {
+		panic("Error getting delegations: " + err.Error())
+	}
+
+	sendResponse(delegations, rw)
+}
+
+// grantDelegation lets another user book, change and cancel reservations for the user
+func (ds *DelegationService) grantDelegation(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	var delReq DelegationRequest
+	readRequest(req, &delReq)
+
+	if delReq.DelegateId == 0 || delReq.DelegateId == ctx.UserId {
+		panic("A delegate other than yourself must be supplied")
+	}
+
+	err := dataAccess.GrantDelegation(req.Context(), ctx.UserId, delReq.DelegateId, ds.RestObj.Db)
+	if err != nil {
+		panic("Error granting delegation: " + err.Error())
+	}
+
+	sendResponse(ReservationResponse{Result: true}, rw)
+}
+
+// revokeDelegation stops a delegate acting for the user
+func (ds *DelegationService) revokeDelegation(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	delegateId := getIdAsInt(req.PathParams["id"])
+
+	revoked, err := dataAccess.RevokeDelegation(req.Context(), ctx.UserId, delegateId, ds.RestObj.Db)
+	if err != nil {
+		panic("Error revoking delegation: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: revoked,
+	}
+	if !revoked {
+		resRsp.Reason = fmt.Sprintf("User %d is not your delegate.", delegateId)
+	}
+
+	sendResponse(resRsp, rw)
+}
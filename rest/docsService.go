@@ -0,0 +1,80 @@
+/*
+	The docs rest service. Serves the OpenAPI document describing the rest services, and a
+	Swagger UI page to browse and try it.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+
+	"avaros/openapi"
+	"avaros/router"
+
+	"github.com/gocraft/web"
+)
+
+// the Swagger UI version the docs page loads
+const swaggerUIVersion = "5.17.14"
+
+// the docs page, which loads Swagger UI from a CDN rather than us serving its files
+var docsPage = fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>Avaros API</title>
+	<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@%[1]s/swagger-ui.css">
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="https://unpkg.com/swagger-ui-dist@%[1]s/swagger-ui-bundle.js"></script>
+	<script>
+		SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui", withCredentials: true});
+	</script>
+</body>
+</html>
+`, swaggerUIVersion)
+
+// RoomsOnlyTags are the tags of the operations served when only the room service runs, along
+// with the health checks, metrics and docs
+var RoomsOnlyTags = []string{"rooms", "operations"}
+
+type DocsService struct {
+	RestObj RestServiceObject
+	// the tags of the operations to document, when not every rest service is running
+	Tags []string
+
+	document []byte // the OpenAPI document as JSON
+}
+
+// Init initialises the service and starts listening for its paths
+func (ds *DocsService) Init() error {
+	if ds.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+
+	document, err := openapi.JSON(ds.Tags...)
+	if err != nil {
+		return fmt.Errorf("Error reading the OpenAPI document: %w", err)
+	}
+	ds.document = document
+
+	// the docs are read before logging in, and describe how to
+	router.AllowAnonymous("/openapi.json", "/docs")
+	ds.RestObj.Router.Get("/openapi.json", ds.openAPI)
+	ds.RestObj.Router.Get("/docs", ds.docs)
+	return nil
+}
+
+// openAPI sends the OpenAPI document
+func (ds *DocsService) openAPI(rw web.ResponseWriter, req *web.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Write(ds.document)
+}
+
+// docs sends the Swagger UI page
+func (ds *DocsService) docs(rw web.ResponseWriter, req *web.Request) {
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(rw, docsPage)
+}
@@ -0,0 +1,323 @@
+/*
+	The docs rest service, and that the OpenAPI document matches the rest services.
+*/
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gocraft/web"
+
+	events "avaros/events"
+	openapi "avaros/openapi"
+	router "avaros/router"
+	test "avaros/test"
+)
+
+// the server the document says the service is on, which requests are made to so they match it
+const documentServer = "http://localhost:8080"
+
+// matches the parameters in a gocraft path, like :id
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+func TestDocumentedRoutes(t *testing.T) {
+	router := router.NewRouter()
+	RestObj := RestServiceObject{Router: router}
+	restServices := []RestService{
+		&HealthService{RestObj: RestObj},
+		&MetricsService{RestObj: RestObj},
+		&DocsService{RestObj: RestObj},
+		&RoomService{RestObj: RestObj},
+		&EventService{RestObj: RestObj, Broker: &events.Broker{}},
+		&NotificationService{RestObj: RestObj},
+		&ReservationService{RestObj: RestObj},
+		&DelegationService{RestObj: RestObj},
+		&ClosureService{RestObj: RestObj},
+		&CalendarService{RestObj: RestObj},
+		&FreeBusyService{RestObj: RestObj},
+		&QuotaService{RestObj: RestObj},
+		&PriorityService{RestObj: RestObj},
+		&WaitlistService{RestObj: RestObj},
+		&HoldService{RestObj: RestObj, HoldLength: time.Minute},
+	}
+	for _, service := range restServices {
+		err := service.Init()
+		if err != nil {
+			t.Fatalf("Error initialising %T: %s", service, err.Error())
+		}
+	}
+
+	checkDocumentedRoutes(t, router, loadDocument(t))
+}
+
+func TestDocumentedRoutesRoomsOnly(t *testing.T) {
+	router := router.NewRouter()
+	RestObj := RestServiceObject{Router: router}
+	for _, service := range []RestService{
+		&HealthService{RestObj: RestObj},
+		&MetricsService{RestObj: RestObj},
+		&DocsService{RestObj: RestObj, Tags: RoomsOnlyTags},
+		&RoomService{RestObj: RestObj},
+	} {
+		err := service.Init()
+		if err != nil {
+			t.Fatalf("Error initialising %T: %s", service, err.Error())
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/openapi.json", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	doc, err := openapi3.NewLoader().LoadFromData(rr.Body.Bytes())
+	if err != nil {
+		t.Fatalf("Error loading the served document: %s", err.Error())
+	}
+
+	checkDocumentedRoutes(t, router, doc)
+}
+
+// checkDocumentedRoutes checks every route on the router is in the document and every
+// operation in the document is routed
+func checkDocumentedRoutes(t *testing.T, router *web.Router, doc *openapi3.T) {
+	routed := map[string]bool{}
+	for _, route := range routes(router) {
+		routed[route] = true
+	}
+
+	documented := map[string]bool{}
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			documented[method+" "+path] = true
+		}
+	}
+
+	for route := range routed {
+		if !documented[route] {
+			t.Errorf("%s is not in the OpenAPI document", route)
+		}
+	}
+	for route := range documented {
+		if !routed[route] {
+			t.Errorf("%s is in the OpenAPI document but no service serves it", route)
+		}
+	}
+}
+
+func TestDocs(t *testing.T) {
+	router := router.NewRouter()
+	docsService := DocsService{RestObj: RestServiceObject{Router: router}}
+	err := docsService.Init()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the docs are read without a user id cookie
+	req := httptest.NewRequest("GET", "/docs", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `url: "/openapi.json"`) {
+		t.Fatalf("The docs page should load the document, got %d %s", rr.Code, rr.Body.String())
+	}
+	if !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/html") {
+		t.Errorf("The docs page should be HTML, got %s", rr.Header().Get("Content-Type"))
+	}
+}
+
+func TestDocumentedResponsesInMemory(t *testing.T) {
+	_, router := setupMemory()
+	for _, service := range []RestService{
+		&HealthService{RestObj: RestServiceObject{Router: router}},
+		&DocsService{RestObj: RestServiceObject{Router: router}},
+	} {
+		err := service.Init()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	docRouter := documentRouter(t)
+
+	// user 1 is not a delegate of user 2, so reserving for them fails, and only user 1 is
+	// authorized
+	for _, tc := range []struct {
+		method string
+		path   string
+		body   string
+		userId string
+		status int
+	}{
+		{"POST", "/room/reserve/1", `{"reservationLength": 60, "title": "Standup"}`, "1", http.StatusOK},
+		{"POST", "/room/reserve/1", `{"reservationLength": 60}`, "1", http.StatusOK},
+		{"GET", "/room/check-reservation/1", "", "1", http.StatusOK},
+		{"POST", "/room/end-reservation/1", "", "1", http.StatusOK},
+		{"DELETE", "/room/delete-reservation/1", "", "1", http.StatusOK},
+		{"POST", "/room/reserve/1", `{"onBehalfOf": 2}`, "1", http.StatusInternalServerError},
+		{"GET", "/room/check-reservation/1", "", "2", http.StatusUnauthorized},
+		{"GET", "/healthz", "", "", http.StatusOK},
+		{"GET", "/readyz", "", "", http.StatusOK},
+		{"GET", "/openapi.json", "", "", http.StatusOK},
+	} {
+		status := validateResponse(t, router, docRouter, tc.method, tc.path, tc.body, tc.userId)
+		if status != tc.status {
+			t.Errorf("%s %s should send back %d, got %d", tc.method, tc.path, tc.status, status)
+		}
+	}
+}
+
+func TestDocumentedResponses(t *testing.T) {
+	db, router := setup()
+	defer test.CloseDb(db)
+
+	RestObj := RestServiceObject{Router: router, Db: db}
+	for _, service := range []RestService{
+		&NotificationService{RestObj: RestObj},
+		&DelegationService{RestObj: RestObj},
+		&ClosureService{RestObj: RestObj},
+		&CalendarService{RestObj: RestObj},
+		&FreeBusyService{RestObj: RestObj},
+		&QuotaService{RestObj: RestObj},
+		&PriorityService{RestObj: RestObj},
+		&WaitlistService{RestObj: RestObj},
+	} {
+		err := service.Init()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	docRouter := documentRouter(t)
+
+	for _, tc := range []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{"POST", "/reservations/batch", `{"roomIds": [1, 2], "reservationLength": 30}`},
+		{"GET", "/reservations/pending", ""},
+		{"GET", "/reservations/bumped", ""},
+		{"GET", "/reservations/invitations", ""},
+		{"GET", "/waitlist", ""},
+		{"GET", "/delegations", ""},
+		{"GET", "/closures", ""},
+		{"GET", "/sites/Dublin/business-hours", ""},
+		{"GET", "/sites/Dublin/holidays", ""},
+		{"POST", "/freebusy", `{"roomIds": [1], "userIds": [1], "from": "2030-01-01T09:00:00Z", "to": "2030-01-01T17:00:00Z"}`},
+		{"GET", "/quotas", ""},
+		{"GET", "/quotas/remaining", ""},
+		{"GET", "/priorities", ""},
+		{"GET", "/notifications/preferences", ""},
+	} {
+		status := validateResponse(t, router, docRouter, tc.method, tc.path, tc.body, "1")
+		if status != http.StatusOK {
+			t.Errorf("%s %s should succeed, got %d", tc.method, tc.path, status)
+		}
+	}
+}
+
+// validateResponse makes a request, as the user supplied if there is one, and checks both it
+// and the response match the OpenAPI document. Returns the status sent back
+func validateResponse(t *testing.T, router *web.Router, docRouter routers.Router, method string, path string, body string, userId string) int {
+	ctx := context.Background()
+
+	req := httptest.NewRequest(method, documentServer+path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if userId != "" {
+		req.AddCookie(&http.Cookie{Name: "userId", Value: userId})
+	}
+
+	route, pathParams, err := docRouter.FindRoute(req)
+	if err != nil {
+		t.Fatalf("%s %s is not in the OpenAPI document: %s", method, path, err.Error())
+	}
+
+	requestInput := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			// the cookie is checked by the router, not the document
+			AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+			IncludeResponseStatus: true,
+		},
+	}
+	err = openapi3filter.ValidateRequest(ctx, requestInput)
+	if err != nil {
+		t.Fatalf("%s %s does not match the OpenAPI document: %s", method, path, err.Error())
+	}
+
+	// the request body was read while validating, so it is sent again
+	req.Body = io.NopCloser(strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	err = openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: requestInput,
+		Status:                 rr.Code,
+		Header:                 rr.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(rr.Body.Bytes())),
+		Options:                requestInput.Options,
+	})
+	if err != nil {
+		t.Errorf("The response to %s %s does not match the OpenAPI document: %s\n%s", method, path, err.Error(), rr.Body.String())
+	}
+
+	return rr.Code
+}
+
+// loadDocument loads and validates the OpenAPI document
+func loadDocument(t *testing.T) *openapi3.T {
+	doc, err := openapi3.NewLoader().LoadFromData(openapi.Document)
+	if err != nil {
+		t.Fatalf("Error loading the OpenAPI document: %s", err.Error())
+	}
+
+	err = doc.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("The OpenAPI document is not valid: %s", err.Error())
+	}
+
+	return doc
+}
+
+// documentRouter finds the operation in the OpenAPI document a request is for
+func documentRouter(t *testing.T) routers.Router {
+	docRouter, err := legacy.NewRouter(loadDocument(t))
+	if err != nil {
+		t.Fatalf("Error routing the OpenAPI document: %s", err.Error())
+	}
+
+	return docRouter
+}
+
+// routes lists every route on a router as its method and path, with parameters written the
+// OpenAPI way. gocraft/web does not export its routes, so they are read with reflection
+func routes(router *web.Router) []string {
+	var routes []string
+	registered := reflect.ValueOf(router).Elem().FieldByName("routes")
+	for i := 0; i < registered.Len(); i++ {
+		route := registered.Index(i).Elem()
+		method := route.FieldByName("Method").String()
+		path := pathParam.ReplaceAllString(route.FieldByName("Path").String(), "{$1}")
+		routes = append(routes, method+" "+path)
+	}
+
+	sort.Strings(routes)
+	return routes
+}
@@ -0,0 +1,234 @@
+/*
+	The event rest service. Streams live reservation changes for a room.
+*/
+
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"avaros/dataAccess"
+	"avaros/events"
+	"avaros/models"
+
+	"github.com/gocraft/web"
+	"github.com/gorilla/websocket"
+)
+
+// how often to send something down an idle stream so proxies don't close it
+const heartbeatInterval = 30 * time.Second
+
+var upgrader = websocket.Upgrader{}
+
+type EventService struct {
+	RestObj RestServiceObject
+	Broker  *events.Broker
+
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+// Init initialises the service and starts listening for its paths
+func (es *EventService) Init() error {
+	if es.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+	if es.Broker == nil {
+		return errors.New("A broker must be present for the service to stream events from")
+	}
+
+	es.closing = make(chan struct{})
+	es.RestObj.Router.Get("/rooms/:id/events", es.roomEvents)
+	es.RestObj.Router.Get("/rooms/:id/events/ws", es.roomEventsSocket)
+	return nil
+}
+
+// Close ends every stream so the server can shut down, clients will reconnect to
+// another instance and catch up on what they missed
+func (es *EventService) Close() {
+	es.closeOnce.Do(func() {
+		close(es.closing)
+	})
+}
+
+// roomEvents streams the reservation changes for a room as server sent events
+func (es *EventService) roomEvents(rw web.ResponseWriter, req *web.Request) {
+	roomId := es.getRoomId(req)
+	lastEventId := getLastEventId(req)
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+	rw.WriteHeader(http.StatusOK)
+
+	send := func(event models.ReservationEvent) error {
+		b, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(rw, "id: %d\nevent: reservation\ndata: %s\n\n", event.Id, b)
+		rw.Flush()
+		return err
+	}
+
+	heartbeat := func() error {
+		_, err := fmt.Fprint(rw, ": heartbeat\n\n")
+		rw.Flush()
+		return err
+	}
+
+	es.stream(req, roomId, lastEventId, send, heartbeat, req.Context().Done())
+}
+
+// roomEventsSocket streams the reservation changes for a room over a websocket
+func (es *EventService) roomEventsSocket(rw web.ResponseWriter, req *web.Request) {
+	roomId := es.getRoomId(req)
+	lastEventId := getLastEventId(req)
+
+	conn, err := upgrader.Upgrade(rw, req.Request, nil)
+	if err != nil {
+		// the upgrader has already sent the error back to the client
+		return
+	}
+	defer conn.Close()
+
+	// nothing is expected from the client but the connection has to be read
+	// to handle control messages and to know when it goes away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(event models.ReservationEvent) error {
+		conn.SetWriteDeadline(time.Now().Add(heartbeatInterval))
+		return conn.WriteJSON(event)
+	}
+
+	heartbeat := func() error {
+		return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(heartbeatInterval))
+	}
+
+	es.stream(req, roomId, lastEventId, send, heartbeat, closed)
+}
+
+// stream sends the events for a room until the client goes away. If the client has seen
+// events before, anything it missed is replayed, otherwise it is sent the room's current state
+func (es *EventService) stream(req *web.Request, roomId int32, lastEventId int64, send func(models.ReservationEvent) error,
+	heartbeat func() error, done <-chan struct{}) {
+	// subscribe before catching up so nothing is missed in between
+	events, unsubscribe := es.Broker.Subscribe(roomId)
+	defer unsubscribe()
+
+	// events are only kept for a while, so a client that has been gone longer than that is
+	// sent the room's current state as it cannot be sent everything it missed
+	if lastEventId > 0 {
+		oldest, err := dataAccess.GetOldestReservationEventId(req.Context(), es.RestObj.Db)
+		if err != nil {
+			slog.ErrorContext(req.Context(), "Error getting oldest reservation event", "roomId", roomId, "error", err)
+			return
+		}
+		if oldest == 0 || lastEventId < oldest-1 {
+			lastEventId = 0
+		}
+	}
+
+	if lastEventId > 0 {
+		missed, err := dataAccess.GetReservationEventsSince(req.Context(), roomId, lastEventId, es.RestObj.Db)
+		if err != nil {
+			slog.ErrorContext(req.Context(), "Error getting missed reservation events", "roomId", roomId, "error", err)
+			return
+		}
+		for _, event := range missed {
+			if send(event) != nil {
+				return
+			}
+			lastEventId = event.Id
+		}
+	} else {
+		state, err := dataAccess.GetRoomState(req.Context(), roomId, es.RestObj.Db)
+		if err != nil {
+			slog.ErrorContext(req.Context(), "Error getting room state", "roomId", roomId, "error", err)
+			return
+		}
+		if send(state) != nil {
+			return
+		}
+		lastEventId = state.Id
+	}
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			// the broker dropped us, the client will reconnect and catch up
+			if !ok {
+				return
+			}
+			// already sent while catching up
+			if event.Id <= lastEventId {
+				continue
+			}
+			if send(event) != nil {
+				return
+			}
+			lastEventId = event.Id
+		case <-ticker.C:
+			if heartbeat() != nil {
+				return
+			}
+		case <-done:
+			return
+		case <-es.closing:
+			return
+		}
+	}
+}
+
+// getRoomId gets the room id from the path and checks the room exists
+func (es *EventService) getRoomId(req *web.Request) int32 {
+	roomId := getIdAsInt(req.PathParams["id"])
+
+	roomExists, err := dataAccess.CheckRoomExists(req.Context(), roomId, es.RestObj.Db)
+	if err != nil {
+		panic("Error determining if room exists: " + err.Error())
+	}
+
+	if !roomExists {
+		panic(fmt.Sprintf("Room with id %d does not exist", roomId))
+	}
+
+	return roomId
+}
+
+// getLastEventId gets the id of the last event a reconnecting client saw. Browsers send
+// it as a header for server sent events but it can only be a query parameter for websockets
+func getLastEventId(req *web.Request) int64 {
+	lastEventId := req.Header.Get("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = req.URL.Query().Get("lastEventId")
+	}
+	if lastEventId == "" {
+		return 0
+	}
+
+	id, err := strconv.ParseInt(lastEventId, 10, 64)
+	if err != nil {
+		panic("Error getting last event id: " + err.Error())
+	}
+
+	return id
+}
@@ -0,0 +1,64 @@
+/*
+	The free/busy rest service. Shows when rooms and users are busy so meetings can be scheduled.
+*/
+
+package rest
+
+import (
+	"errors"
+	"time"
+
+	"avaros/dataAccess"
+	"avaros/router"
+
+	"github.com/gocraft/web"
+)
+
+// the longest window free/busy can be asked for at once
+const maxFreeBusyWindow = 31 * 24 * time.Hour
+
+type FreeBusyService struct {
+	RestObj RestServiceObject
+}
+
+// The request object when asking when rooms and users are busy
+type FreeBusyRequest struct {
+	RoomIds []int32   `json:"roomIds"`
+	UserIds []int32   `json:"userIds"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+}
+
+// Init initialises the service and starts listening for its paths
+func (fs *FreeBusyService) Init() error {
+	if fs.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+
+	fs.RestObj.Router.Post("/freebusy", fs.getFreeBusy)
+	return nil
+}
+
+// getFreeBusy gets when each of the rooms and users asked for is busy in the window supplied
+func (fs *FreeBusyService) getFreeBusy(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	var fbReq FreeBusyRequest
+	readRequest(req, &fbReq)
+
+	if len(fbReq.RoomIds) == 0 && len(fbReq.UserIds) == 0 {
+		panic("At least one room id or user id must be supplied")
+	}
+	if fbReq.From.IsZero() || !fbReq.To.After(fbReq.From) {
+		panic("A from time before the to time must be supplied")
+	}
+	if fbReq.To.Sub(fbReq.From) > maxFreeBusyWindow {
+		panic("Free/busy can only be asked for 31 days at a time")
+	}
+
+	freeBusy, err := dataAccess.GetFreeBusy(req.Context(), fbReq.RoomIds, fbReq.UserIds, fbReq.From.Local(),
+		fbReq.To.Local(), ctx.UserId, fs.RestObj.Db)
+	if err != nil {
+		panic("Error getting free/busy: " + err.Error())
+	}
+
+	sendResponse(freeBusy, rw)
+}
@@ -0,0 +1,134 @@
+/*
+	The health rest service. Lets load balancers and orchestrators know if the service is
+	alive and ready to take requests.
+*/
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"avaros/router"
+
+	"github.com/gocraft/web"
+)
+
+// how long each dependency has to answer before it is counted as down
+const healthCheckTimeout = 2 * time.Second
+
+// The statuses a service or one of its dependencies can be in
+const (
+	HealthOk   = "ok"
+	HealthDown = "down"
+)
+
+// HealthCheck checks something the service needs to take requests is working
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type HealthService struct {
+	RestObj RestServiceObject
+	Checks  []HealthCheck
+}
+
+// The response object for the health of the service
+type HealthResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// The result of checking one of the service's dependencies
+type CheckResult struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+// Init initialises the service and starts listening for its paths
+func (hs *HealthService) Init() error {
+	if hs.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+
+	router.AllowAnonymous("/healthz", "/readyz")
+	hs.RestObj.Router.Get("/healthz", hs.healthz)
+	hs.RestObj.Router.Get("/readyz", hs.readyz)
+	return nil
+}
+
+// healthz lets the caller know the process is alive. Nothing it depends on is checked, so a
+// database outage does not get the process restarted
+func (hs *HealthService) healthz(rw web.ResponseWriter, req *web.Request) {
+	sendHealth(HealthResponse{Status: HealthOk}, rw)
+}
+
+// readyz checks everything the service depends on and lets the caller know if it is ready to
+// take requests, with how each dependency is doing
+func (hs *HealthService) readyz(rw web.ResponseWriter, req *web.Request) {
+	rsp := HealthResponse{
+		Status: HealthOk,
+		Checks: map[string]CheckResult{},
+	}
+
+	// the checks are run together so one that hangs does not hold up the rest
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range hs.Checks {
+		wg.Add(1)
+		go func(check HealthCheck) {
+			defer wg.Done()
+			result := runCheck(req.Context(), check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			rsp.Checks[check.Name] = result
+			if result.Status != HealthOk {
+				rsp.Status = HealthDown
+			}
+		}(check)
+	}
+	wg.Wait()
+
+	sendHealth(rsp, rw)
+}
+
+// runCheck runs a check, giving up on it after the health check timeout
+func runCheck(ctx context.Context, check HealthCheck) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Check(ctx)
+	result := CheckResult{
+		Status:    HealthOk,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = HealthDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// sendHealth sends the health of the service back, with a 503 if it is not ok so load
+// balancers stop sending it requests
+func sendHealth(rsp HealthResponse, rw web.ResponseWriter) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("Cache-Control", "no-store")
+	if rsp.Status != HealthOk {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	err := json.NewEncoder(rw).Encode(rsp)
+	if err != nil {
+		panic("Error sending response: " + err.Error())
+	}
+}
@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gocraft/web"
+
+	router "avaros/router"
+)
+
+func TestHealthz(t *testing.T) {
+	router := setupHealth(HealthCheck{Name: "database", Check: func(context.Context) error {
+		return errors.New("connection refused")
+	}})
+
+	// no user id cookie is needed and nothing is checked
+	rsp, code := getHealth(t, router, "/healthz")
+	if code != http.StatusOK || rsp.Status != HealthOk {
+		t.Errorf("Service should be alive, got %d %v", code, rsp)
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	router := setupHealth(HealthCheck{Name: "database", Check: ok}, HealthCheck{Name: "scheduler", Check: ok})
+
+	rsp, code := getHealth(t, router, "/readyz")
+	if code != http.StatusOK || rsp.Status != HealthOk || len(rsp.Checks) != 2 {
+		t.Errorf("Service should be ready, got %d %v", code, rsp)
+	}
+
+	router = setupHealth(
+		HealthCheck{Name: "database", Check: ok},
+		HealthCheck{Name: "migrations", Check: func(context.Context) error {
+			return errors.New("Tables room are missing")
+		}},
+		// a check that hangs is given up on rather than holding up the response
+		HealthCheck{Name: "scheduler", Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	)
+
+	rsp, code = getHealth(t, router, "/readyz")
+	if code != http.StatusServiceUnavailable || rsp.Status != HealthDown {
+		t.Errorf("Service should not be ready, got %d %v", code, rsp)
+	}
+
+	if rsp.Checks["database"].Status != HealthOk {
+		t.Errorf("Database should be ok, got %v", rsp.Checks["database"])
+	}
+	if rsp.Checks["migrations"].Status != HealthDown || rsp.Checks["migrations"].Error != "Tables room are missing" {
+		t.Errorf("Migrations should be down, got %v", rsp.Checks["migrations"])
+	}
+	if rsp.Checks["scheduler"].Status != HealthDown {
+		t.Errorf("Scheduler should be down, got %v", rsp.Checks["scheduler"])
+	}
+}
+
+// getHealth gets one of the health paths without a user id cookie
+func getHealth(t *testing.T, router *web.Router, path string) (HealthResponse, int) {
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	rsp := HealthResponse{}
+	err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+	if err != nil {
+		t.Fatalf("Error reading health response %s: %s", rr.Body.String(), err.Error())
+	}
+
+	return rsp, rr.Code
+}
+
+func setupHealth(checks ...HealthCheck) *web.Router {
+	router := router.NewRouter()
+	healthService := HealthService{RestObj: RestServiceObject{Router: router}, Checks: checks}
+	err := healthService.Init()
+	if err != nil {
+		panic(err)
+	}
+
+	return router
+}
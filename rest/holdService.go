@@ -0,0 +1,144 @@
+/*
+	The hold rest service. Lets users block a slot while they finish booking it.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"avaros/dataAccess"
+	"avaros/metrics"
+	"avaros/router"
+
+	"github.com/gocraft/web"
+)
+
+type HoldService struct {
+	RestObj RestServiceObject
+	// how long a hold lasts, and the longest a user can ask for
+	HoldLength time.Duration
+}
+
+// The request object when placing a hold. The slot is given by the start time and
+// length as when reserving. The hold can be asked to last less than the default
+type HoldRequest struct {
+	ReservationRequest
+	HoldSeconds int `json:"holdSeconds"`
+}
+
+// Init initialises the service and starts listening for its paths
+func (hs *HoldService) Init() error {
+	if hs.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+	if hs.HoldLength <= 0 {
+		return errors.New("Holds must last longer than 0 seconds")
+	}
+
+	hs.RestObj.Router.Post("/rooms/:id/holds", hs.placeHold)
+	hs.RestObj.Router.Post("/holds/:id/confirm", hs.confirmHold)
+	hs.RestObj.Router.Delete("/holds/:id", hs.releaseHold)
+	return nil
+}
+
+// placeHold blocks a slot on a room for a short while. The id of the hold is returned and
+// is used to confirm it. If it is not confirmed in time the slot is released
+func (hs *HoldService) placeHold(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	roomId := getIdAsInt(req.PathParams["id"])
+
+	var holdReq HoldRequest
+	readRequest(req, &holdReq)
+
+	holdLength := hs.HoldLength
+	if holdReq.HoldSeconds > 0 && time.Duration(holdReq.HoldSeconds)*time.Second < holdLength {
+		holdLength = time.Duration(holdReq.HoldSeconds) * time.Second
+	}
+
+	// If there is no start time provided hold it from now
+	startTime := time.Now()
+	if !holdReq.StartTime.IsZero() {
+		startTime = holdReq.StartTime.Local()
+	}
+
+	userId := organizer(ctx, req, holdReq.OnBehalfOf, hs.RestObj.Reservations)
+	if reason := checkAttendees(req, []int32{roomId}, holdReq.Attendees, hs.RestObj.Rooms, hs.RestObj.Reservations); reason != "" {
+		sendResponse(ReservationResponse{Result: false, Reason: reason}, rw)
+		return
+	}
+	checkPriority(req, userId, holdReq.Priority, hs.RestObj.Reservations)
+	if reason := checkBookingPolicy(req, []int32{roomId}, startTime,
+		dataAccess.EndTime(startTime, holdReq.ReservationLength), hs.RestObj.Rooms); reason != "" {
+		sendResponse(ReservationResponse{Result: false, Reason: reason}, rw)
+		return
+	}
+
+	holdId, held, err := dataAccess.PlaceHold(req.Context(), roomId, userId, ctx.UserId, startTime,
+		dataAccess.EndTime(startTime, holdReq.ReservationLength), time.Now().Add(holdLength),
+		holdReq.ReservationDetails, hs.RestObj.Db)
+	if reason, ok := bookingRefused(err); ok {
+		sendResponse(ReservationResponse{Result: false, Reason: reason}, rw)
+		return
+	}
+	if err != nil {
+		panic("Error placing hold: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: held,
+	}
+	if held {
+		resRsp.Ids = []int32{holdId}
+	} else {
+		metrics.BookingRejections.WithLabelValues(metrics.RejectedConflict).Inc()
+		resRsp.Reason = "Reservation already exists."
+	}
+
+	sendResponse(resRsp, rw)
+}
+
+// confirmHold turns a hold into a reservation
+func (hs *HoldService) confirmHold(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	holdId := getIdAsInt(req.PathParams["id"])
+
+	confirmed, err := dataAccess.ConfirmHold(req.Context(), holdId, ctx.UserId, hs.RestObj.Db)
+	if reason, ok := bookingRefused(err); ok {
+		sendResponse(ReservationResponse{Result: false, Reason: reason}, rw)
+		return
+	}
+	if err != nil {
+		panic("Error confirming hold: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: confirmed,
+	}
+	if confirmed {
+		resRsp.Ids = []int32{holdId}
+	} else {
+		resRsp.Reason = fmt.Sprintf("Hold %d does not exist or has expired.", holdId)
+	}
+
+	sendResponse(resRsp, rw)
+}
+
+// releaseHold releases a hold that is no longer needed
+func (hs *HoldService) releaseHold(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	holdId := getIdAsInt(req.PathParams["id"])
+
+	released, err := dataAccess.ReleaseHold(req.Context(), holdId, ctx.UserId, hs.RestObj.Db)
+	if err != nil {
+		panic("Error releasing hold: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: released,
+	}
+	if !released {
+		resRsp.Reason = fmt.Sprintf("Hold %d does not exist.", holdId)
+	}
+
+	sendResponse(resRsp, rw)
+}
@@ -0,0 +1,35 @@
+/*
+	The metrics rest service. Serves the service's metrics for Prometheus to scrape.
+*/
+
+package rest
+
+import (
+	"errors"
+
+	"avaros/metrics"
+	"avaros/router"
+
+	"github.com/gocraft/web"
+)
+
+type MetricsService struct {
+	RestObj RestServiceObject
+}
+
+// Init initialises the service and starts listening for its paths
+func (ms *MetricsService) Init() error {
+	if ms.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+
+	// Prometheus scrapes without logging in
+	router.AllowAnonymous("/metrics")
+	ms.RestObj.Router.Get("/metrics", ms.metrics)
+	return nil
+}
+
+// metrics sends every metric in the Prometheus text format
+func (ms *MetricsService) metrics(rw web.ResponseWriter, req *web.Request) {
+	metrics.Handler().ServeHTTP(rw, req.Request)
+}
@@ -0,0 +1,87 @@
+/*
+	The notification rest service. Lets users choose which emails they get.
+*/
+
+package rest
+
+import (
+	"errors"
+
+	"avaros/dataAccess"
+	"avaros/notification"
+	"avaros/router"
+
+	"github.com/gocraft/web"
+)
+
+type NotificationService struct {
+	RestObj RestServiceObject
+}
+
+// NotificationPreferences says whether the user wants each kind of notification,
+// keyed by the kind
+type NotificationPreferences map[string]bool
+
+// Init initialises the service and starts listening for its paths
+func (ns *NotificationService) Init() error {
+	if ns.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+
+	ns.RestObj.Router.Get("/notifications/preferences", ns.getPreferences)
+	ns.RestObj.Router.Put("/notifications/preferences", ns.updatePreferences)
+	return nil
+}
+
+// getPreferences gets which notifications the user wants
+func (ns *NotificationService) getPreferences(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	sendResponse(ns.preferences(req, ctx.UserId), rw)
+}
+
+// updatePreferences turns notifications on or off for the user. Any kind of
+// notification not in the request is left as it was
+func (ns *NotificationService) updatePreferences(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	var update NotificationPreferences
+	readRequest(req, &update)
+
+	preferences := ns.preferences(req, ctx.UserId)
+	for kind, enabled := range update {
+		if _, ok := preferences[kind]; !ok {
+			panic("Unknown notification: " + kind)
+		}
+		preferences[kind] = enabled
+	}
+
+	optOuts := []string{}
+	for _, kind := range notification.Kinds {
+		if !preferences[kind] {
+			optOuts = append(optOuts, kind)
+		}
+	}
+
+	err := dataAccess.SetNotificationOptOuts(req.Context(), ctx.UserId, optOuts, ns.RestObj.Db)
+	if err != nil {
+		panic("Error updating notification preferences: " + err.Error())
+	}
+
+	sendResponse(preferences, rw)
+}
+
+// preferences gets the user's preferences with every kind of notification on
+// unless they have opted out of it
+func (ns *NotificationService) preferences(req *web.Request, userId int32) NotificationPreferences {
+	optOuts, err := dataAccess.GetNotificationOptOuts(req.Context(), userId, ns.RestObj.Db)
+	if err != nil {
+		panic("Error getting notification preferences: " + err.Error())
+	}
+
+	preferences := NotificationPreferences{}
+	for _, kind := range notification.Kinds {
+		preferences[kind] = true
+	}
+	for _, optOut := range optOuts {
+		preferences[optOut] = false
+	}
+
+	return preferences
+}
@@ -0,0 +1,50 @@
+/*
+	The booking policy every reservation has to meet before it is made.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"avaros/dataAccess"
+	"avaros/metrics"
+	"avaros/repository"
+
+	"github.com/gocraft/web"
+)
+
+// checkBookingPolicy checks a reservation of the rooms for the time supplied is allowed.
+// Returns why it is not, counting it as refused, or an empty string if it is
+func checkBookingPolicy(req *web.Request, roomIds []int32, startTime time.Time, endTime *time.Time, rooms repository.RoomRepository) string {
+	for _, roomId := range roomIds {
+		open, err := rooms.CheckOpen(req.Context(), roomId, startTime, endTime)
+		if err != nil {
+			panic("Error checking business hours: " + err.Error())
+		}
+		if !open {
+			metrics.BookingRejections.WithLabelValues(metrics.RejectedPolicy).Inc()
+			return fmt.Sprintf("Room with id %d is outside business hours or on a holiday then.", roomId)
+		}
+	}
+
+	return ""
+}
+
+// bookingRefused gets why a reservation was refused, if it would take the user over their
+// quota or its room needs approval no one can give, and counts it as refused
+func bookingRefused(err error) (string, bool) {
+	var quotaErr *dataAccess.QuotaError
+	if errors.As(err, &quotaErr) {
+		metrics.BookingRejections.WithLabelValues(metrics.RejectedQuota).Inc()
+		return quotaErr.Reason, true
+	}
+	if errors.Is(err, dataAccess.ErrNoApprover) {
+		metrics.BookingRejections.WithLabelValues(metrics.RejectedPolicy).Inc()
+		return "Room needs approval but has no approver.", true
+	}
+
+	return "", false
+}
@@ -0,0 +1,61 @@
+/*
+	The priority rest service. Sets the highest priority each role can reserve rooms at.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+
+	"avaros/dataAccess"
+	"avaros/models"
+
+	"github.com/gocraft/web"
+)
+
+type PriorityService struct {
+	RestObj RestServiceObject
+}
+
+// Init initialises the service and starts listening for its paths
+func (ps *PriorityService) Init() error {
+	if ps.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+
+	ps.RestObj.Router.Get("/priorities", ps.getPriorities)
+	ps.RestObj.Router.Put("/priorities", ps.setPriority)
+	return nil
+}
+
+// getPriorities gets the highest priority each role can reserve rooms at
+func (ps *PriorityService) getPriorities(rw web.ResponseWriter, req *web.Request) {
+	priorities, err := dataAccess.GetRolePriorities(req.Context(), ps.RestObj.Db)
+	if err != nil {
+		panic("Error getting priorities: " + err.Error())
+	}
+
+	sendResponse(priorities, rw)
+}
+
+// setPriority sets the highest priority a role can reserve rooms at
+func (ps *PriorityService) setPriority(rw web.ResponseWriter, req *web.Request) {
+	var priority models.RolePriority
+	readRequest(req, &priority)
+
+	if priority.Role == "" {
+		panic("A role must be supplied")
+	}
+	if priority.MaxPriority < models.PriorityNormal || priority.MaxPriority > models.PriorityCritical {
+		panic(fmt.Sprintf("Invalid priority %d, expected %d to %d", priority.MaxPriority,
+			models.PriorityNormal, models.PriorityCritical))
+	}
+
+	err := dataAccess.SetRolePriority(req.Context(), priority, ps.RestObj.Db)
+	if err != nil {
+		panic("Error setting priority: " + err.Error())
+	}
+
+	sendResponse(ReservationResponse{Result: true}, rw)
+}
@@ -0,0 +1,106 @@
+/*
+	The quota rest service. Sets how much users, teams and roles can reserve.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+
+	"avaros/dataAccess"
+	"avaros/models"
+	"avaros/router"
+
+	"github.com/gocraft/web"
+)
+
+type QuotaService struct {
+	RestObj RestServiceObject
+}
+
+// Init initialises the service and starts listening for its paths
+func (qs *QuotaService) Init() error {
+	if qs.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+
+	qs.RestObj.Router.Get("/quotas", qs.getQuotas)
+	qs.RestObj.Router.Put("/quotas", qs.setQuota)
+	qs.RestObj.Router.Get("/quotas/remaining", qs.getRemaining)
+	qs.RestObj.Router.Delete("/quotas/:id", qs.deleteQuota)
+	return nil
+}
+
+// getQuotas gets every quota
+func (qs *QuotaService) getQuotas(rw web.ResponseWriter, req *web.Request) {
+	quotas, err := dataAccess.GetQuotas(req.Context(), qs.RestObj.Db)
+	if err != nil {
+		panic("Error getting quotas: " + err.Error())
+	}
+
+	sendResponse(quotas, rw)
+}
+
+// setQuota sets the quota for a user, team or role. A quota without any of them is the
+// default for everyone
+func (qs *QuotaService) setQuota(rw web.ResponseWriter, req *web.Request) {
+	var quota models.Quota
+	readRequest(req, &quota)
+
+	scopes := 0
+	if quota.UserId != nil {
+		scopes++
+	}
+	if quota.Team != "" {
+		scopes++
+	}
+	if quota.Role != "" {
+		scopes++
+	}
+	if scopes > 1 {
+		panic("A quota can only be for one of a user, a team or a role")
+	}
+
+	if (quota.MaxFutureReservations != nil && *quota.MaxFutureReservations < 0) ||
+		(quota.MaxHoursPerWeek != nil && *quota.MaxHoursPerWeek < 0) ||
+		(quota.MaxPerRoomPerDay != nil && *quota.MaxPerRoomPerDay < 0) {
+		panic("Quota limits cannot be negative")
+	}
+
+	err := dataAccess.SetQuota(req.Context(), &quota, qs.RestObj.Db)
+	if err != nil {
+		panic("Error setting quota: " + err.Error())
+	}
+
+	sendResponse(ReservationResponse{Result: true, Ids: []int32{quota.Id}}, rw)
+}
+
+// getRemaining gets the user's quota and how much of it they have left
+func (qs *QuotaService) getRemaining(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	usage, err := dataAccess.GetQuotaUsage(req.Context(), ctx.UserId, qs.RestObj.Db)
+	if err != nil {
+		panic("Error getting quota usage: " + err.Error())
+	}
+
+	sendResponse(usage, rw)
+}
+
+// deleteQuota removes a quota
+func (qs *QuotaService) deleteQuota(rw web.ResponseWriter, req *web.Request) {
+	quotaId := getIdAsInt(req.PathParams["id"])
+
+	deleted, err := dataAccess.DeleteQuota(req.Context(), quotaId, qs.RestObj.Db)
+	if err != nil {
+		panic("Error deleting quota: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: deleted,
+	}
+	if !deleted {
+		resRsp.Reason = fmt.Sprintf("Quota %d does not exist.", quotaId)
+	}
+
+	sendResponse(resRsp, rw)
+}
@@ -0,0 +1,370 @@
+/*
+	The reservation rest service. Handles reservations that are not for a single room.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"avaros/dataAccess"
+	"avaros/metrics"
+	"avaros/models"
+	"avaros/router"
+
+	"github.com/gocraft/web"
+	"github.com/jackc/pgx/v4"
+)
+
+type ReservationService struct {
+	RestObj RestServiceObject
+}
+
+// The request object when reserving several rooms at once. Every room is reserved
+// for the same start time and length
+type BatchReservationRequest struct {
+	RoomIds []int32 `json:"roomIds"`
+	ReservationRequest
+}
+
+// The request object when approving or rejecting a reservation
+type DecisionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// The request object when inviting more people to a reservation
+type AttendeesRequest struct {
+	Attendees []models.Attendee `json:"attendees"`
+}
+
+// The request object when answering an invitation
+type RsvpRequest struct {
+	Rsvp string `json:"rsvp"`
+}
+
+// Init initialises the service and starts listening for its paths
+func (rs *ReservationService) Init() error {
+	if rs.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+
+	rs.RestObj.Router.Post("/reservations/batch", rs.reserveRooms)
+	rs.RestObj.Router.Get("/reservations/pending", rs.getPendingApprovals)
+	rs.RestObj.Router.Get("/reservations/bumped", rs.getBumps)
+	rs.RestObj.Router.Post("/reservations/:id/approve", rs.approveReservation)
+	rs.RestObj.Router.Post("/reservations/:id/reject", rs.rejectReservation)
+	rs.RestObj.Router.Get("/reservations/invitations", rs.getInvitations)
+	rs.RestObj.Router.Get("/reservations/:id/attendees", rs.getAttendees)
+	rs.RestObj.Router.Post("/reservations/:id/attendees", rs.addAttendees)
+	rs.RestObj.Router.Delete("/reservations/:id/attendees/:attendeeId", rs.removeAttendee)
+	rs.RestObj.Router.Put("/reservations/:id/rsvp", rs.rsvp)
+	rs.RestObj.Router.Post("/reservations/:id/check-in", rs.checkIn)
+	return nil
+}
+
+// reserveRooms reserves several rooms at once. Either every room is reserved or, if any
+// of them already has a reservation for that time, none are and the conflicts are returned
+func (rs *ReservationService) reserveRooms(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	var resReq BatchReservationRequest
+	readRequest(req, &resReq)
+
+	if len(resReq.RoomIds) == 0 {
+		panic("At least one room id must be supplied")
+	}
+
+	seen := map[int32]bool{}
+	for _, roomId := range resReq.RoomIds {
+		if seen[roomId] {
+			panic(fmt.Sprintf("Room with id %d is listed more than once", roomId))
+		}
+		seen[roomId] = true
+	}
+
+	// If there is no start time provided reserve now
+	startTime := time.Now()
+	if !resReq.StartTime.IsZero() {
+		startTime = resReq.StartTime.Local()
+	}
+
+	userId := organizer(ctx, req, resReq.OnBehalfOf, rs.RestObj.Reservations)
+	if reason := checkAttendees(req, resReq.RoomIds, resReq.Attendees, rs.RestObj.Rooms, rs.RestObj.Reservations); reason != "" {
+		sendResponse(ReservationResponse{Result: false, Reason: reason}, rw)
+		return
+	}
+	checkPriority(req, userId, resReq.Priority, rs.RestObj.Reservations)
+	if reason := checkBookingPolicy(req, resReq.RoomIds, startTime,
+		dataAccess.EndTime(startTime, resReq.ReservationLength), rs.RestObj.Rooms); reason != "" {
+		sendResponse(ReservationResponse{Result: false, Reason: reason}, rw)
+		return
+	}
+
+	ids, conflicts, err := dataAccess.ReserveRooms(req.Context(), resReq.RoomIds, userId, ctx.UserId, startTime,
+		resReq.ReservationLength, resReq.ReservationDetails, rs.RestObj.Db)
+	if reason, ok := bookingRefused(err); ok {
+		sendResponse(ReservationResponse{Result: false, Reason: reason}, rw)
+		return
+	}
+	if err != nil {
+		panic("Error reserving rooms: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{}
+	if len(conflicts) > 0 {
+		metrics.BookingRejections.WithLabelValues(metrics.RejectedConflict).Inc()
+		rooms := []string{}
+		for _, roomId := range conflicts {
+			rooms = append(rooms, fmt.Sprint(roomId))
+		}
+		resRsp.Result = false
+		resRsp.Reason = "Reservation already exists for rooms " + strings.Join(rooms, ", ") + "."
+		resRsp.Conflicts = conflicts
+	} else {
+		resRsp.Result = true
+		resRsp.Ids = ids
+	}
+
+	sendResponse(resRsp, rw)
+}
+
+// getPendingApprovals gets the reservations waiting on the user to approve them
+func (rs *ReservationService) getPendingApprovals(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	reservations, err := dataAccess.GetPendingApprovals(req.Context(), ctx.UserId, rs.RestObj.Db)
+	if err != nil {
+		panic("Error getting pending approvals: " + err.Error())
+	}
+
+	sendResponse(reservations, rw)
+}
+
+// getBumps gets the user's reservations that were displaced by higher priority ones and why
+func (rs *ReservationService) getBumps(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	bumps, err := dataAccess.GetBumps(req.Context(), ctx.UserId, rs.RestObj.Db)
+	if err != nil {
+		panic("Error getting displaced reservations: " + err.Error())
+	}
+
+	sendResponse(bumps, rw)
+}
+
+// approveReservation approves a reservation waiting on the user
+func (rs *ReservationService) approveReservation(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	reservationId := getIdAsInt(req.PathParams["id"])
+
+	var decision DecisionRequest
+	readRequest(req, &decision)
+
+	approved, err := dataAccess.ApproveReservation(req.Context(), reservationId, ctx.UserId, decision.Reason, rs.RestObj.Db)
+	if err != nil {
+		panic("Error approving reservation: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: approved,
+	}
+	if approved {
+		resRsp.Ids = []int32{reservationId}
+	} else {
+		resRsp.Reason = fmt.Sprintf("Reservation %d is not waiting on your approval.", reservationId)
+	}
+
+	sendResponse(resRsp, rw)
+}
+
+// rejectReservation rejects a reservation waiting on the user. The reason is passed on to
+// the user who made the reservation
+func (rs *ReservationService) rejectReservation(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	reservationId := getIdAsInt(req.PathParams["id"])
+
+	var decision DecisionRequest
+	readRequest(req, &decision)
+
+	if decision.Reason == "" {
+		panic("A reason must be supplied when rejecting a reservation")
+	}
+
+	rejected, err := dataAccess.RejectReservation(req.Context(), reservationId, ctx.UserId, decision.Reason, rs.RestObj.Db)
+	if err != nil {
+		panic("Error rejecting reservation: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: rejected,
+	}
+	if rejected {
+		resRsp.Ids = []int32{reservationId}
+	} else {
+		resRsp.Reason = fmt.Sprintf("Reservation %d is not waiting on your approval.", reservationId)
+	}
+
+	sendResponse(resRsp, rw)
+}
+
+// getInvitations gets the reservations the user is invited to
+func (rs *ReservationService) getInvitations(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	invitations, err := dataAccess.GetInvitations(req.Context(), ctx.UserId, rs.RestObj.Db)
+	if err != nil {
+		panic("Error getting invitations: " + err.Error())
+	}
+
+	sendResponse(invitations, rw)
+}
+
+// getAttendees gets the people invited to a reservation. Only the organizer, their delegates
+// and the people invited can see who else is
+func (rs *ReservationService) getAttendees(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	reservation := rs.getReservation(req, getIdAsInt(req.PathParams["id"]))
+
+	invited := false
+	for _, attendee := range reservation.Attendees {
+		if attendee.UserId != nil && *attendee.UserId == ctx.UserId {
+			invited = true
+		}
+	}
+	if !invited {
+		allowed, err := dataAccess.CanActFor(req.Context(), ctx.UserId, reservation.UserId, rs.RestObj.Db)
+		if err != nil {
+			panic("Error checking delegation: " + err.Error())
+		}
+		if !allowed {
+			panic(fmt.Sprintf("You cannot see who is invited to reservation %d", reservation.Id))
+		}
+	}
+
+	attendees := reservation.Attendees
+	if attendees == nil {
+		attendees = []models.Attendee{}
+	}
+	sendResponse(attendees, rw)
+}
+
+// addAttendees invites more people to a reservation. Only the organizer or their delegates
+// can invite people, and only as many as fit in the room
+func (rs *ReservationService) addAttendees(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	reservation := rs.getReservation(req, getIdAsInt(req.PathParams["id"]))
+	rs.checkOrganizer(ctx, req, reservation)
+
+	var attReq AttendeesRequest
+	readRequest(req, &attReq)
+	if reason := checkAttendees(req, nil, attReq.Attendees, rs.RestObj.Rooms, rs.RestObj.Reservations); reason != "" {
+		sendResponse(ReservationResponse{Result: false, Reason: reason}, rw)
+		return
+	}
+
+	added, fits, err := dataAccess.AddAttendees(req.Context(), reservation.Id, attReq.Attendees, rs.RestObj.Db)
+	if err != nil {
+		panic("Error adding attendees: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: fits,
+		Ids:    []int32{},
+	}
+	if fits {
+		for _, attendee := range added {
+			resRsp.Ids = append(resRsp.Ids, attendee.Id)
+		}
+	} else {
+		resRsp.Reason = fmt.Sprintf("Room with id %d is not big enough for everyone.", reservation.RoomId)
+	}
+
+	sendResponse(resRsp, rw)
+}
+
+// removeAttendee withdraws someone's invitation. Only the organizer or their delegates
+// can remove people
+func (rs *ReservationService) removeAttendee(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	reservation := rs.getReservation(req, getIdAsInt(req.PathParams["id"]))
+	rs.checkOrganizer(ctx, req, reservation)
+	attendeeId := getIdAsInt(req.PathParams["attendeeId"])
+
+	removed, err := dataAccess.RemoveAttendee(req.Context(), reservation.Id, attendeeId, rs.RestObj.Db)
+	if err != nil {
+		panic("Error removing attendee: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: removed,
+	}
+	if !removed {
+		resRsp.Reason = fmt.Sprintf("Attendee %d is not invited to reservation %d.", attendeeId, reservation.Id)
+	}
+
+	sendResponse(resRsp, rw)
+}
+
+// rsvp answers the user's invitation to a reservation
+func (rs *ReservationService) rsvp(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	reservationId := getIdAsInt(req.PathParams["id"])
+
+	var rsvpReq RsvpRequest
+	readRequest(req, &rsvpReq)
+
+	switch rsvpReq.Rsvp {
+	case models.RsvpAccepted, models.RsvpDeclined, models.RsvpTentative:
+	default:
+		panic("Unknown rsvp: " + rsvpReq.Rsvp)
+	}
+
+	answered, err := dataAccess.SetRsvp(req.Context(), reservationId, ctx.UserId, rsvpReq.Rsvp, rs.RestObj.Db)
+	if err != nil {
+		panic("Error answering invitation: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: answered,
+	}
+	if !answered {
+		resRsp.Reason = fmt.Sprintf("You are not invited to reservation %d.", reservationId)
+	}
+
+	sendResponse(resRsp, rw)
+}
+
+// checkIn records that the user has turned up to a reservation, so it is not released as a
+// no-show
+func (rs *ReservationService) checkIn(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	reservationId := getIdAsInt(req.PathParams["id"])
+
+	checkedIn, err := dataAccess.CheckIn(req.Context(), reservationId, ctx.UserId, rs.RestObj.Db)
+	if err != nil {
+		panic("Error checking in: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: checkedIn,
+	}
+	if checkedIn {
+		resRsp.Ids = []int32{reservationId}
+	} else {
+		resRsp.Reason = fmt.Sprintf("You cannot check in to reservation %d.", reservationId)
+	}
+
+	sendResponse(resRsp, rw)
+}
+
+// getReservation gets a reservation, panicking if it does not exist
+func (rs *ReservationService) getReservation(req *web.Request, reservationId int32) models.Reservation {
+	reservation, err := dataAccess.GetReservation(req.Context(), reservationId, rs.RestObj.Db)
+	if err == pgx.ErrNoRows {
+		panic(fmt.Sprintf("Reservation with id %d does not exist", reservationId))
+	}
+	if err != nil {
+		panic("Error getting reservation: " + err.Error())
+	}
+
+	return reservation
+}
+
+// checkOrganizer stops anyone but the organizer of a reservation or their delegates changing it
+func (rs *ReservationService) checkOrganizer(ctx *router.Context, req *web.Request, reservation models.Reservation) {
+	allowed, err := dataAccess.CanActFor(req.Context(), ctx.UserId, reservation.UserId, rs.RestObj.Db)
+	if err != nil {
+		panic("Error checking delegation: " + err.Error())
+	}
+	if !allowed {
+		panic(fmt.Sprintf("You cannot change reservation %d", reservation.Id))
+	}
+}
@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dataAccess "avaros/dataAccess"
+	models "avaros/models"
+	test "avaros/test"
+)
+
+func TestBatchReservation(t *testing.T) {
+	ctx := context.Background()
+	db, router := setup()
+	defer test.CloseDb(db)
+
+	resReq := BatchReservationRequest{
+		RoomIds: []int32{1, 2},
+	}
+	jsonStr, err := json.Marshal(resReq)
+
+	req, err := http.NewRequest("POST", "/reservations/batch", bytes.NewBuffer(jsonStr))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.AddCookie(&http.Cookie{
+		Name:  "userId",
+		Value: "1",
+	})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatal(err)
+	}
+
+	resRsp := ReservationResponse{}
+	json.Unmarshal(rr.Body.Bytes(), &resRsp)
+
+	if !resRsp.Result || len(resRsp.Ids) != 2 {
+		t.Fatalf("Both rooms should have been reserved")
+	}
+
+	for _, roomId := range []int32{1, 2} {
+		exists, err := dataAccess.CheckReservation(ctx, roomId, db)
+		if err != nil {
+			t.Errorf("Error checking a reservation: %s", err.Error())
+		}
+
+		if !exists {
+			t.Errorf("Reservation for room %d should exist", roomId)
+		}
+	}
+}
+
+func TestBatchReservationConflict(t *testing.T) {
+	ctx := context.Background()
+	db, router := setup()
+	defer test.CloseDb(db)
+
+	_, err := dataAccess.Reserve(ctx, 2, 1, 1, 0, models.ReservationDetails{}, db)
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+
+	resReq := BatchReservationRequest{
+		RoomIds: []int32{1, 2, 3},
+	}
+	jsonStr, err := json.Marshal(resReq)
+
+	req, err := http.NewRequest("POST", "/reservations/batch", bytes.NewBuffer(jsonStr))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.AddCookie(&http.Cookie{
+		Name:  "userId",
+		Value: "1",
+	})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatal(err)
+	}
+
+	resRsp := ReservationResponse{}
+	json.Unmarshal(rr.Body.Bytes(), &resRsp)
+
+	if resRsp.Result {
+		t.Fatalf("No rooms should have been reserved")
+	}
+
+	if len(resRsp.Conflicts) != 1 || resRsp.Conflicts[0] != 2 {
+		t.Errorf("Room 2 should be the only conflict")
+	}
+
+	for _, roomId := range []int32{1, 3} {
+		exists, err := dataAccess.CheckReservation(ctx, roomId, db)
+		if err != nil {
+			t.Errorf("Error checking a reservation: %s", err.Error())
+		}
+
+		if exists {
+			t.Errorf("Reservation for room %d should not exist", roomId)
+		}
+	}
+}
+
+func TestGetAttendees(t *testing.T) {
+	ctx := context.Background()
+	db, router := setup()
+	defer test.CloseDb(db)
+
+	// user 1 invites user 2 to a private meeting, user 3 is not invited
+	jane := int32(2)
+	reservationId, err := dataAccess.Reserve(ctx, 1, 1, 1, 60, models.ReservationDetails{
+		Private:   true,
+		Attendees: []models.Attendee{{UserId: &jane}},
+	}, db)
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	for userId, status := range map[string]int{
+		"1": http.StatusOK,
+		"2": http.StatusOK,
+		"3": http.StatusInternalServerError,
+	} {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/reservations/%d/attendees", reservationId), nil)
+		req.AddCookie(&http.Cookie{Name: "userId", Value: userId})
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != status {
+			t.Errorf("User %s should get %d for the attendees, got %d", userId, status, rr.Code)
+		}
+	}
+}
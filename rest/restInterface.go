@@ -4,6 +4,8 @@
 package rest
 
 import (
+	"avaros/repository"
+
 	"github.com/gocraft/web"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
@@ -16,5 +18,9 @@ type RestService interface {
 // RestServiceObject contains the objects a rest service needs to run
 type RestServiceObject struct {
 	Router *web.Router
-	Db     *pgxpool.Pool
+	// used by every service but the room service, which has not been moved to the repositories
+	// yet, so nil when the rooms are not stored in Postgres
+	Db           *pgxpool.Pool
+	Rooms        repository.RoomRepository
+	Reservations repository.ReservationRepository
 }
@@ -15,7 +15,10 @@ import (
 	"time"
 
 	"avaros/dataAccess"
+	"avaros/metrics"
 	"avaros/models"
+	"avaros/repository"
+	"avaros/router"
 
 	"github.com/gocraft/web"
 )
@@ -25,16 +28,22 @@ type RoomService struct {
 }
 
 type ReservationResponse struct {
-	Result bool    `json:"result"`
-	Reason string  `json:"reason"`
-	Ids    []int32 `json:"ids"`
+	Result    bool    `json:"result"`
+	Reason    string  `json:"reason"`
+	Ids       []int32 `json:"ids"`
+	Conflicts []int32 `json:"conflicts,omitempty"` // the rooms that could not be reserved
+	// what could be reserved instead when the room asked for is taken
+	Suggestions *models.Suggestions `json:"suggestions,omitempty"`
 }
 
 // The request obejct when making a reservation. Can contain the the start time the reservation
-// is for and the duration in the number of minutes
+// is for and the duration in the number of minutes. A delegate can make the reservation for
+// someone else by supplying their user id. The title, description and attendees are optional
 type ReservationRequest struct {
 	StartTime         time.Time `json:"startTime"`
 	ReservationLength int       `json:"reservationLength"`
+	OnBehalfOf        int32     `json:"onBehalfOf,omitempty"`
+	models.ReservationDetails
 }
 
 // Init initialises the service and starts listening for its paths
@@ -46,16 +55,17 @@ func (rs *RoomService) Init() error {
 	rs.RestObj.Router.Post("/room/reserve/:id", rs.reserveRoom)
 	rs.RestObj.Router.Delete("/room/delete-reservation/:id", rs.deleteReservation)
 	rs.RestObj.Router.Get("/room/check-reservation/:id", rs.checkReservation)
+	rs.RestObj.Router.Post("/room/end-reservation/:id", rs.endReservation)
 	return nil
 }
 
 // reserveRoom reserves a room. If a start time is supplied it will not reserve the room until
 // that time
-func (rs *RoomService) reserveRoom(rw web.ResponseWriter, req *web.Request) {
+func (rs *RoomService) reserveRoom(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
 	// Get the id from the url parameters
 	roomId := getIdAsInt(req.PathParams["id"])
 
-	roomExists, err := dataAccess.CheckRoomExists(roomId, rs.RestObj.Db)
+	roomExists, err := rs.RestObj.Rooms.CheckRoomExists(req.Context(), roomId)
 	if err != nil {
 		panic("Error determining if room exists: " + err.Error())
 	}
@@ -63,6 +73,12 @@ func (rs *RoomService) reserveRoom(rw web.ResponseWriter, req *web.Request) {
 	if !roomExists {
 		panic(fmt.Sprintf("Room with id %d does not exist", roomId))
 	}
+
+	room, err := rs.RestObj.Rooms.GetRoom(req.Context(), roomId)
+	if err != nil {
+		panic("Error getting room: " + err.Error())
+	}
+
 	// read the request body to get the values passed in, if any
 	b, err := ioutil.ReadAll(req.Body)
 	defer req.Body.Close()
@@ -76,34 +92,112 @@ func (rs *RoomService) reserveRoom(rw web.ResponseWriter, req *web.Request) {
 	if err != nil {
 		panic("Error unmarshalling request body: " + err.Error())
 	}
-	// check if a reservation exists
-	reservationExists, err := dataAccess.CheckReservation(roomId, rs.RestObj.Db)
+	userId := organizer(ctx, req, resReq.OnBehalfOf, rs.RestObj.Reservations)
+	if reason := checkAttendees(req, []int32{roomId}, resReq.Attendees, rs.RestObj.Rooms, rs.RestObj.Reservations); reason != "" {
+		sendResponse(ReservationResponse{Result: false, Reason: reason}, rw)
+		return
+	}
+	checkPriority(req, userId, resReq.Priority, rs.RestObj.Reservations)
+
+	// check the room is open for all of the time it will be reserved for
+	startTime := time.Now()
+	if !resReq.StartTime.IsZero() {
+		startTime = resReq.StartTime.Local()
+	}
+	endTime := dataAccess.EndTime(startTime, resReq.ReservationLength)
+	policyReason := checkBookingPolicy(req, []int32{roomId}, startTime, endTime, rs.RestObj.Rooms)
+	roomClosed, err := rs.RestObj.Rooms.CheckClosed(req.Context(), roomId, startTime, endTime)
 	if err != nil {
-		panic("Error checking reservation: " + err.Error())
+		panic("Error checking closures: " + err.Error())
+	}
+
+	// a priority reservation displaces anything less important rather than conflicting with
+	// it, so ReserveRooms checks what it overlaps instead
+	prioritised := resReq.Priority > models.PriorityNormal
+
+	// check nothing overlaps with any of the time it will be reserved for, whether that is
+	// now or later
+	var reservationExists bool
+	if !prioritised {
+		reservationExists, err = rs.RestObj.Reservations.CheckReservationOverlap(req.Context(), roomId, startTime, endTime)
+		if err != nil {
+			panic("Error checking reservation: " + err.Error())
+		}
 	}
 
 	resRsp := ReservationResponse{}
-	// if a reservation exists then you cannot reserve the room.
-	if reservationExists {
+	// if the booking policy is not met, the room is closed or a reservation exists
+	// then you cannot reserve the room.
+	if policyReason != "" {
+		resRsp.Result = false
+		resRsp.Reason = policyReason
+	} else if roomClosed {
+		metrics.BookingRejections.WithLabelValues(metrics.RejectedClosed).Inc()
+		resRsp.Result = false
+		resRsp.Reason = "Room is closed for that time."
+	} else if reservationExists {
+		metrics.BookingRejections.WithLabelValues(metrics.RejectedConflict).Inc()
 		resRsp.Result = false
 		resRsp.Reason = "Reservation already exists."
+		resRsp.Suggestions = suggest(req, roomId, startTime, endTime, len(resReq.Attendees)+1, rs.RestObj.Rooms)
+	} else if room.RequiresApproval || prioritised {
+		// reservations of rooms that need approval are stored straight away,
+		// even if they are for the future, so they can be approved before they start.
+		// Priority reservations are too so they displace anything in their way now
+		ids, conflicts, err := rs.RestObj.Reservations.ReserveRooms(req.Context(), []int32{roomId}, userId, ctx.UserId, startTime,
+			resReq.ReservationLength, resReq.ReservationDetails)
+		if reason, ok := bookingRefused(err); ok {
+			resRsp.Result = false
+			resRsp.Reason = reason
+		} else if err != nil {
+			panic("Error reserving room: " + err.Error())
+		} else if len(conflicts) > 0 {
+			metrics.BookingRejections.WithLabelValues(metrics.RejectedConflict).Inc()
+			resRsp.Result = false
+			resRsp.Reason = "Reservation already exists."
+			resRsp.Suggestions = suggest(req, roomId, startTime, endTime, len(resReq.Attendees)+1, rs.RestObj.Rooms)
+		} else {
+			resRsp.Result = true
+			resRsp.Ids = ids
+			if room.RequiresApproval {
+				resRsp.Reason = "Reservation needs to be approved."
+			}
+		}
 	} else {
 		// If there is no start time provided reserve now
 		if resReq.StartTime.IsZero() {
 			// call reserve and handle any error passed back
-			reservationId, err := dataAccess.Reserve(roomId, resReq.ReservationLength, rs.RestObj.Db)
-			if err != nil {
+			reservationId, err := rs.RestObj.Reservations.Reserve(req.Context(), roomId, userId, ctx.UserId, resReq.ReservationLength,
+				resReq.ReservationDetails)
+			if reason, ok := bookingRefused(err); ok {
+				resRsp.Result = false
+				resRsp.Reason = reason
+			} else if errors.Is(err, dataAccess.ErrSlotTaken) {
+				// someone else reserved it since it was checked
+				metrics.BookingRejections.WithLabelValues(metrics.RejectedConflict).Inc()
+				resRsp.Result = false
+				resRsp.Reason = "Reservation already exists."
+				resRsp.Suggestions = suggest(req, roomId, startTime, endTime, len(resReq.Attendees)+1, rs.RestObj.Rooms)
+			} else if err != nil {
 				panic("Error reserving room: " + err.Error())
+			} else {
+				resRsp.Result = true
+				resRsp.Ids = []int32{reservationId}
 			}
-			resRsp.Result = true
-			resRsp.Ids = []int32{reservationId}
+		} else if reason, ok := bookingRefused(rs.RestObj.Reservations.CheckQuota(req.Context(), userId, []models.Reservation{{
+			RoomId:    roomId,
+			StartTime: startTime,
+			EndTime:   endTime,
+		}})); ok {
+			// the quota is checked again when the reservation is created
+			resRsp.Result = false
+			resRsp.Reason = reason
 		} else {
 			// get the time difference for when to create the future reservation
 			startTimeDelay := math.Abs(time.Now().Sub(resReq.StartTime).Minutes())
-			//fmt.Println(startTimeDelay)
-			// fire off a thread that will handle creating that reservation
-			// at the correct time
-			go dataAccess.CreateFutureReservation(startTimeDelay, roomId, resReq.ReservationLength, rs.RestObj.Db)
+			// have that reservation created in the background at the correct time
+			rs.RestObj.Reservations.ReserveLater(req.Context(), startTimeDelay, roomId, userId, ctx.UserId, resReq.ReservationLength,
+				resReq.ReservationDetails)
 			resRsp.Result = true
 		}
 	}
@@ -112,11 +206,12 @@ func (rs *RoomService) reserveRoom(rw web.ResponseWriter, req *web.Request) {
 }
 
 // deleteReservation deletes a reservation
-func (rs *RoomService) deleteReservation(rw web.ResponseWriter, req *web.Request) {
+func (rs *RoomService) deleteReservation(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
 	// get the id of the room to delete the reservation for
 	roomId := getIdAsInt(req.PathParams["id"])
+	checkCanManage(ctx, req, roomId, rs.RestObj.Reservations)
 	// check if the room has a reservation
-	reservationExists, err := dataAccess.CheckReservation(roomId, rs.RestObj.Db)
+	reservationExists, err := rs.RestObj.Reservations.CheckReservation(req.Context(), roomId)
 	if err != nil {
 		panic("Error checking reservation: " + err.Error())
 	}
@@ -129,7 +224,7 @@ func (rs *RoomService) deleteReservation(rw web.ResponseWriter, req *web.Request
 		resRsp.Reason = fmt.Sprintf("Reservation for room %d does not exist exists.", roomId)
 	} else {
 		// otherwise delete the reservation
-		err := dataAccess.DeleteReservation(roomId, rs.RestObj.Db)
+		err := rs.RestObj.Reservations.DeleteReservation(req.Context(), roomId)
 		if err != nil {
 			panic("Error deleting reservation: " + err.Error())
 		}
@@ -138,11 +233,31 @@ func (rs *RoomService) deleteReservation(rw web.ResponseWriter, req *web.Request
 	sendResponse(resRsp, rw)
 }
 
+// endReservation ends a room's current reservation early so anyone waiting on the room can have it
+func (rs *RoomService) endReservation(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	roomId := getIdAsInt(req.PathParams["id"])
+	checkCanManage(ctx, req, roomId, rs.RestObj.Reservations)
+
+	ended, err := rs.RestObj.Reservations.EndReservation(req.Context(), roomId)
+	if err != nil {
+		panic("Error ending reservation: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: ended,
+	}
+	if !ended {
+		resRsp.Reason = fmt.Sprintf("Room %d does not have a reservation to end.", roomId)
+	}
+
+	sendResponse(resRsp, rw)
+}
+
 // checkReservation checks if a room has a reservation currently or not
 func (rs *RoomService) checkReservation(rw web.ResponseWriter, req *web.Request) {
 	roomId := getIdAsInt(req.PathParams["id"])
 	// get the reservation status for the room passed in
-	reservationExists, err := dataAccess.CheckReservation(roomId, rs.RestObj.Db)
+	reservationExists, err := rs.RestObj.Reservations.CheckReservation(req.Context(), roomId)
 	if err != nil {
 		panic("Error checking reservation: " + err.Error())
 	}
@@ -169,13 +284,125 @@ func getIdAsInt(roomIdStr string) int32 {
 	return int32(roomId)
 }
 
+// organizer gets who a reservation is for. A user can only make one for someone else if
+// that user has made them their delegate
+func organizer(ctx *router.Context, req *web.Request, onBehalfOf int32, reservations repository.ReservationRepository) int32 {
+	if onBehalfOf == 0 {
+		return ctx.UserId
+	}
+
+	allowed, err := reservations.CanActFor(req.Context(), ctx.UserId, onBehalfOf)
+	if err != nil {
+		panic("Error checking delegation: " + err.Error())
+	}
+	if !allowed {
+		panic(fmt.Sprintf("You are not a delegate of user %d", onBehalfOf))
+	}
+
+	return onBehalfOf
+}
+
+// checkAttendees makes sure every attendee is a user or an email address and that each
+// room is big enough for all of them and the organizer. Returns why they cannot be invited
+// if a user id is not one of the users, or an empty string if they can
+func checkAttendees(req *web.Request, roomIds []int32, attendees []models.Attendee, rooms repository.RoomRepository, reservations repository.ReservationRepository) string {
+	for _, attendee := range attendees {
+		if attendee.UserId == nil && attendee.Email == "" {
+			panic("Each attendee must have a user id or an email")
+		}
+		if attendee.UserId == nil {
+			continue
+		}
+
+		exists, err := reservations.UserExists(req.Context(), *attendee.UserId)
+		if err != nil {
+			panic("Error checking attendee: " + err.Error())
+		}
+		if !exists {
+			return fmt.Sprintf("User with id %d does not exist.", *attendee.UserId)
+		}
+	}
+
+	// every room fits the organizer on their own
+	if len(attendees) == 0 {
+		return ""
+	}
+
+	for _, roomId := range roomIds {
+		fits, err := rooms.RoomFits(req.Context(), roomId, len(attendees)+1)
+		if err != nil {
+			panic("Error checking room capacity: " + err.Error())
+		}
+		if !fits {
+			panic(fmt.Sprintf("Room with id %d is not big enough for %d people", roomId, len(attendees)+1))
+		}
+	}
+
+	return ""
+}
+
+// checkPriority makes sure the organizer's role lets them reserve rooms at the priority supplied
+func checkPriority(req *web.Request, userId int32, priority int32, reservations repository.ReservationRepository) {
+	if priority < models.PriorityNormal || priority > models.PriorityCritical {
+		panic(fmt.Sprintf("Invalid priority %d, expected %d to %d", priority, models.PriorityNormal, models.PriorityCritical))
+	}
+	if priority == models.PriorityNormal {
+		return
+	}
+
+	maxPriority, err := reservations.MaxPriority(req.Context(), userId)
+	if err != nil {
+		panic("Error checking priority: " + err.Error())
+	}
+	if priority > maxPriority {
+		panic(fmt.Sprintf("User %d cannot reserve rooms at priority %d", userId, priority))
+	}
+}
+
+// suggest gets what could be reserved instead of a room that is taken for the time supplied
+func suggest(req *web.Request, roomId int32, startTime time.Time, endTime *time.Time, people int, rooms repository.RoomRepository) *models.Suggestions {
+	suggestions, err := rooms.SuggestAlternatives(req.Context(), roomId, startTime, endTime, people)
+	if err != nil {
+		panic("Error suggesting alternatives: " + err.Error())
+	}
+
+	return &suggestions
+}
+
+// checkCanManage stops a user changing or cancelling a room's reservation unless it is
+// theirs or they are a delegate of whoever it is for
+func checkCanManage(ctx *router.Context, req *web.Request, roomId int32, reservations repository.ReservationRepository) {
+	allowed, err := reservations.CanManageReservation(req.Context(), roomId, ctx.UserId)
+	if err != nil {
+		panic("Error checking delegation: " + err.Error())
+	}
+	if !allowed {
+		panic(fmt.Sprintf("You cannot change the reservation for room %d", roomId))
+	}
+}
+
+// readRequest reads the request body into the object supplied
+func readRequest(req *web.Request, v interface{}) {
+	b, err := ioutil.ReadAll(req.Body)
+	defer req.Body.Close()
+	if err != nil {
+		panic("Error reading request body: " + err.Error())
+	}
+
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		panic("Error unmarshalling request body: " + err.Error())
+	}
+}
+
 // sendResponse is used to send the response back to the client
-func sendResponse(resRsp ReservationResponse, rw web.ResponseWriter) {
+func sendResponse(resRsp interface{}, rw web.ResponseWriter) {
 	rsp := models.RestResponse{
 		Result:     resRsp,
 		StatusCode: http.StatusOK,
 	}
 
+	rw.Header().Set("Content-Type", "application/json")
 	err := json.NewEncoder(rw).Encode(rsp.Result)
 	if err != nil {
 		panic("Error sending response: " + err.Error())
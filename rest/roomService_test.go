@@ -6,6 +6,7 @@ package rest
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -14,14 +15,19 @@ import (
 
 	"github.com/gocraft/web"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	dataAccess "avaros/dataAccess"
 	database "avaros/database"
+	metrics "avaros/metrics"
+	models "avaros/models"
+	repository "avaros/repository"
 	router "avaros/router"
 	test "avaros/test"
 )
 
 func TestCheckReservation(t *testing.T) {
+	ctx := context.Background()
 
 	db, router := setup()
 	defer test.CloseDb(db)
@@ -50,7 +56,7 @@ func TestCheckReservation(t *testing.T) {
 		t.Fatal("Reservation should not exist")
 	}
 
-	_, err = dataAccess.Reserve(1, 0, db)
+	_, err = dataAccess.Reserve(ctx, 1, 1, 1, 0, models.ReservationDetails{}, db)
 	if err != nil {
 		t.Errorf("Error reserving a room: %s", err.Error())
 	}
@@ -71,11 +77,12 @@ func TestCheckReservation(t *testing.T) {
 }
 
 func TestDeleteReservation(t *testing.T) {
+	ctx := context.Background()
 
 	db, router := setup()
 	defer test.CloseDb(db)
 
-	_, err := dataAccess.Reserve(1, 0, db)
+	_, err := dataAccess.Reserve(ctx, 1, 1, 1, 0, models.ReservationDetails{}, db)
 	if err != nil {
 		t.Errorf("Error reserving a room: %s", err.Error())
 	}
@@ -97,7 +104,7 @@ func TestDeleteReservation(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	exists, err := dataAccess.CheckReservation(1, db)
+	exists, err := dataAccess.CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a reservation: %s", err.Error())
 	}
@@ -108,6 +115,7 @@ func TestDeleteReservation(t *testing.T) {
 }
 
 func TestReservation(t *testing.T) {
+	ctx := context.Background()
 	db, router := setup()
 	defer test.CloseDb(db)
 
@@ -131,7 +139,7 @@ func TestReservation(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	exists, err := dataAccess.CheckReservation(1, db)
+	exists, err := dataAccess.CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a reservation: %s", err.Error())
 	}
@@ -142,6 +150,7 @@ func TestReservation(t *testing.T) {
 }
 
 func TestReservationExpiry(t *testing.T) {
+	ctx := context.Background()
 	db, router := setup()
 	defer test.CloseDb(db)
 
@@ -168,7 +177,7 @@ func TestReservationExpiry(t *testing.T) {
 	}
 
 	time.Sleep(70 * time.Second)
-	exists, err := dataAccess.CheckReservation(1, db)
+	exists, err := dataAccess.CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a reservation: %s", err.Error())
 	}
@@ -179,6 +188,7 @@ func TestReservationExpiry(t *testing.T) {
 }
 
 func TestFutureReservation(t *testing.T) {
+	ctx := context.Background()
 	db, router := setup()
 	defer test.CloseDb(db)
 
@@ -204,7 +214,7 @@ func TestFutureReservation(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	exists, err := dataAccess.CheckReservation(1, db)
+	exists, err := dataAccess.CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a reservation: %s", err.Error())
 	}
@@ -214,7 +224,7 @@ func TestFutureReservation(t *testing.T) {
 	}
 
 	time.Sleep(70 * time.Second)
-	exists, err = dataAccess.CheckReservation(1, db)
+	exists, err = dataAccess.CheckReservation(ctx, 1, db)
 	if err != nil {
 		t.Errorf("Error checking a reservation: %s", err.Error())
 	}
@@ -229,17 +239,239 @@ func setup() (*pgxpool.Pool, *web.Router) {
 	database.Seed(db)
 	router := router.NewRouter()
 
+	repo := repository.NewPostgres(db)
 	RestObj := RestServiceObject{
-		router,
-		db,
+		Router:       router,
+		Db:           db,
+		Rooms:        repo,
+		Reservations: repo,
 	}
 
-	restService := &RoomService{RestObj}
+	restServices := []RestService{
+		&RoomService{RestObj},
+		&ReservationService{RestObj},
+	}
+
+	for _, service := range restServices {
+		err := service.Init()
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return db, router
+}
+
+func TestReservationInMemory(t *testing.T) {
+	ctx := context.Background()
+	repo, router := setupMemory()
+
+	resRsp := reserveInMemory(t, router, ReservationRequest{ReservationLength: 60})
+	if !resRsp.Result || len(resRsp.Ids) != 1 {
+		t.Fatalf("Room 1 should have been reserved, got %v", resRsp)
+	}
+
+	exists, err := repo.CheckReservation(ctx, 1)
+	if err != nil {
+		t.Errorf("Error checking a reservation: %s", err.Error())
+	}
+
+	if !exists {
+		t.Errorf("Reservation should exist")
+	}
+
+	// the room is taken so the other room in the building with a screen is suggested
+	conflicts := metrics.BookingRejections.WithLabelValues(metrics.RejectedConflict)
+	before := testutil.ToFloat64(conflicts)
+	resRsp = reserveInMemory(t, router, ReservationRequest{ReservationLength: 60})
+	if resRsp.Result || resRsp.Reason != "Reservation already exists." {
+		t.Fatalf("Room 1 should already be reserved, got %v", resRsp)
+	}
+
+	if resRsp.Suggestions == nil || len(resRsp.Suggestions.OtherRooms) != 1 ||
+		resRsp.Suggestions.OtherRooms[0].Room.Id != 2 {
+		t.Errorf("Room 2 should be suggested, got %v", resRsp.Suggestions)
+	}
+
+	if testutil.ToFloat64(conflicts)-before != 1 {
+		t.Errorf("The conflict should be counted")
+	}
+}
+
+func TestEndReservationInMemory(t *testing.T) {
+	ctx := context.Background()
+	repo, router := setupMemory()
+
+	_, err := repo.Reserve(ctx, 1, 1, 1, 0, models.ReservationDetails{})
+	if err != nil {
+		t.Errorf("Error reserving a room: %s", err.Error())
+	}
+
+	req, err := http.NewRequest("POST", "/room/end-reservation/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.AddCookie(&http.Cookie{
+		Name:  "userId",
+		Value: "1",
+	})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Ending the reservation failed: %s", rr.Body.String())
+	}
+
+	exists, err := repo.CheckReservation(ctx, 1)
+	if err != nil {
+		t.Errorf("Error checking a reservation: %s", err.Error())
+	}
+
+	if exists {
+		t.Errorf("Reservation should have ended")
+	}
+}
+
+func TestDelegateReservationInMemory(t *testing.T) {
+	repo, router := setupMemory()
+
+	// user 1 is not a delegate of user 2 yet
+	req, err := http.NewRequest("POST", "/room/reserve/1", bytes.NewBufferString(`{"onBehalfOf": 2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.AddCookie(&http.Cookie{
+		Name:  "userId",
+		Value: "1",
+	})
 
-	err := restService.Init()
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("Reservation should not be made for someone who has not delegated to you")
+	}
+
+	repo.AddDelegation(2, 1)
+	resRsp := reserveInMemory(t, router, ReservationRequest{OnBehalfOf: 2})
+	if !resRsp.Result {
+		t.Fatalf("Reservation should be made for user 2, got %v", resRsp)
+	}
+
+	reservation, err := repo.GetReservation(resRsp.Ids[0])
+	if err != nil {
+		t.Fatalf("Error getting reservation: %s", err.Error())
+	}
+
+	if reservation.UserId != 2 || reservation.CreatedBy != 1 {
+		t.Errorf("Reservation should be for user 2 made by user 1, got %v", reservation)
+	}
+}
+
+func TestFutureReservationInMemory(t *testing.T) {
+	ctx := context.Background()
+	repo, router := setupMemory()
+	tomorrow := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+
+	_, err := repo.Reserve(ctx, 1, 2, 2, 60, models.ReservationDetails{})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+	_, _, err = repo.ReserveRooms(ctx, []int32{1}, 2, 2, tomorrow, 60, models.ReservationDetails{})
+	if err != nil {
+		t.Fatalf("Error reserving a room: %s", err.Error())
+	}
+
+	// the room being reserved now does not stop it being reserved once it is free
+	resRsp := reserveInMemory(t, router, ReservationRequest{StartTime: tomorrow.Add(2 * time.Hour), ReservationLength: 60})
+	if !resRsp.Result {
+		t.Errorf("Room 1 should be free tomorrow, got %v", resRsp)
+	}
+
+	// but a reservation tomorrow does
+	resRsp = reserveInMemory(t, router, ReservationRequest{StartTime: tomorrow.Add(30 * time.Minute), ReservationLength: 60})
+	if resRsp.Result || resRsp.Reason != "Reservation already exists." {
+		t.Errorf("Room 1 should already be reserved tomorrow, got %v", resRsp)
+	}
+}
+
+func TestUnknownAttendeeInMemory(t *testing.T) {
+	_, router := setupMemory()
+
+	unknown, jane := int32(99), int32(2)
+	resRsp := reserveInMemory(t, router, ReservationRequest{ReservationDetails: models.ReservationDetails{
+		Attendees: []models.Attendee{{UserId: &unknown}},
+	}})
+	if resRsp.Result || resRsp.Reason != "User with id 99 does not exist." {
+		t.Errorf("Someone who is not a user should not be invited, got %v", resRsp)
+	}
+
+	resRsp = reserveInMemory(t, router, ReservationRequest{ReservationDetails: models.ReservationDetails{
+		Attendees: []models.Attendee{{UserId: &jane}},
+	}})
+	if !resRsp.Result {
+		t.Errorf("A user should be invited, got %v", resRsp)
+	}
+}
+
+// reserveInMemory reserves room 1 as user 1 through the router supplied
+func reserveInMemory(t *testing.T, router *web.Router, resReq ReservationRequest) ReservationResponse {
+	jsonStr, err := json.Marshal(resReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("POST", "/room/reserve/1", bytes.NewBuffer(jsonStr))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.AddCookie(&http.Cookie{
+		Name:  "userId",
+		Value: "1",
+	})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Reserving the room failed: %s", rr.Body.String())
+	}
+
+	resRsp := ReservationResponse{}
+	json.Unmarshal(rr.Body.Bytes(), &resRsp)
+	return resRsp
+}
+
+// setupMemory creates a room service backed by an in memory repository, so it can be
+// tested without a database
+func setupMemory() (*repository.Memory, *web.Router) {
+	repo := repository.NewMemory()
+	repo.AddUser(models.User{Id: 1, Name: "Conor Downey", Role: "employee"})
+	repo.AddUser(models.User{Id: 2, Name: "Jane Doe", Role: "manager"})
+
+	floor := int32(1)
+	for _, room := range []models.Room{
+		{Name: "Meeting Room", Building: "Head Office", Site: "Dublin", Floor: &floor, Amenities: []string{"screen"}},
+		{Name: "Conference Room", Building: "Head Office", Site: "Dublin", Floor: &floor, Amenities: []string{"screen", "video"}},
+		{Name: "Lunch Room", Building: "Head Office", Site: "Dublin", BufferAfter: 15},
+	} {
+		repo.AddRoom(room)
+	}
+
+	router := router.NewRouter()
+	roomService := RoomService{RestServiceObject{
+		Router:       router,
+		Rooms:        repo,
+		Reservations: repo,
+	}}
+	err := roomService.Init()
 	if err != nil {
 		panic(err)
 	}
 
-	return db, router
+	return repo, router
 }
@@ -0,0 +1,144 @@
+/*
+	The waitlist rest service. Lets users wait on a room that is already reserved.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"avaros/dataAccess"
+	"avaros/router"
+
+	"github.com/gocraft/web"
+)
+
+type WaitlistService struct {
+	RestObj RestServiceObject
+}
+
+// The request object when joining the waitlist for a room. The slot is given by the start
+// time and length as when reserving. If auto accept is set the slot is reserved as soon as
+// it is free, otherwise it is offered to the user who then has a while to accept it
+type WaitlistRequest struct {
+	ReservationRequest
+	AutoAccept bool `json:"autoAccept"`
+}
+
+// Init initialises the service and starts listening for its paths
+func (ws *WaitlistService) Init() error {
+	if ws.RestObj.Router == nil {
+		return errors.New("A router must be present for the service to listen on")
+	}
+
+	ws.RestObj.Router.Post("/rooms/:id/waitlist", ws.joinWaitlist)
+	ws.RestObj.Router.Get("/waitlist", ws.getWaitlist)
+	ws.RestObj.Router.Post("/waitlist/:id/accept", ws.acceptOffer)
+	ws.RestObj.Router.Delete("/waitlist/:id", ws.leaveWaitlist)
+	return nil
+}
+
+// joinWaitlist adds the user to the waitlist for a room and time slot. The id of
+// their place on the waitlist is returned
+func (ws *WaitlistService) joinWaitlist(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	roomId := getIdAsInt(req.PathParams["id"])
+
+	roomExists, err := dataAccess.CheckRoomExists(req.Context(), roomId, ws.RestObj.Db)
+	if err != nil {
+		panic("Error determining if room exists: " + err.Error())
+	}
+
+	if !roomExists {
+		panic(fmt.Sprintf("Room with id %d does not exist", roomId))
+	}
+
+	var waitReq WaitlistRequest
+	readRequest(req, &waitReq)
+	userId := organizer(ctx, req, waitReq.OnBehalfOf, ws.RestObj.Reservations)
+
+	// If there is no start time provided wait on the room from now
+	startTime := time.Now()
+	if !waitReq.StartTime.IsZero() {
+		startTime = waitReq.StartTime.Local()
+	}
+	endTime := dataAccess.EndTime(startTime, waitReq.ReservationLength)
+
+	reservationExists, err := dataAccess.CheckReservationOverlap(req.Context(), roomId, startTime, endTime, ws.RestObj.Db)
+	if err != nil {
+		panic("Error checking reservation: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{}
+	// there is no need to wait on a room that is free
+	if !reservationExists {
+		resRsp.Result = false
+		resRsp.Reason = "Room is free for that time, reserve it instead."
+	} else {
+		entry, err := dataAccess.JoinWaitlist(req.Context(), roomId, userId, startTime, endTime,
+			waitReq.AutoAccept, ws.RestObj.Db)
+		if err != nil {
+			panic("Error joining waitlist: " + err.Error())
+		}
+		resRsp.Result = true
+		resRsp.Ids = []int32{entry.Id}
+	}
+
+	sendResponse(resRsp, rw)
+}
+
+// getWaitlist gets the user's places on waitlists that are still waiting or have been offered
+func (ws *WaitlistService) getWaitlist(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	entries, err := dataAccess.GetWaitlistEntries(req.Context(), ctx.UserId, ws.RestObj.Db)
+	if err != nil {
+		panic("Error getting waitlist: " + err.Error())
+	}
+
+	sendResponse(entries, rw)
+}
+
+// acceptOffer reserves a slot the user, or someone they are a delegate of, has been offered
+// from the waitlist. The id of the reservation is returned
+func (ws *WaitlistService) acceptOffer(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	entryId := getIdAsInt(req.PathParams["id"])
+
+	reservationId, accepted, err := dataAccess.AcceptWaitlistOffer(req.Context(), entryId, ctx.UserId, ws.RestObj.Db)
+	if reason, ok := bookingRefused(err); ok {
+		sendResponse(ReservationResponse{Result: false, Reason: reason}, rw)
+		return
+	}
+	if err != nil {
+		panic("Error accepting waitlist offer: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: accepted,
+	}
+	if accepted {
+		resRsp.Ids = []int32{reservationId}
+	} else {
+		resRsp.Reason = fmt.Sprintf("Waitlist entry %d does not have an offer to accept.", entryId)
+	}
+
+	sendResponse(resRsp, rw)
+}
+
+// leaveWaitlist takes the user, or someone they are a delegate of, off a waitlist
+func (ws *WaitlistService) leaveWaitlist(ctx *router.Context, rw web.ResponseWriter, req *web.Request) {
+	entryId := getIdAsInt(req.PathParams["id"])
+
+	left, err := dataAccess.LeaveWaitlist(req.Context(), entryId, ctx.UserId, ws.RestObj.Db)
+	if err != nil {
+		panic("Error leaving waitlist: " + err.Error())
+	}
+
+	resRsp := ReservationResponse{
+		Result: left,
+	}
+	if !left {
+		resRsp.Reason = fmt.Sprintf("Waitlist entry %d does not exist.", entryId)
+	}
+
+	sendResponse(resRsp, rw)
+}
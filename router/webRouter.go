@@ -5,13 +5,37 @@
 package router
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
+
+	"avaros/logging"
+	"avaros/metrics"
+	"avaros/tracing"
 
 	"github.com/gocraft/web"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// how long a request can take before it is given up on, no limit if zero
+var requestTimeout time.Duration
+
+// the paths anyone can use without being authenticated
+var anonymousPaths = map[string]bool{}
+
+// a middleware as gocraft/web takes it
+type middleware func(c *Context, rw web.ResponseWriter, r *web.Request, next web.NextMiddlewareFunc)
+
 type Context struct {
 	UserId int32
 	Token  string
@@ -25,13 +49,221 @@ type User struct {
 func NewRouter() *web.Router {
 	router := web.New(Context{})
 	router.Error((*Context).Error)
-	router.Middleware((*Context).UserAuthentication)
+	router.Middleware((*Context).Trace)
+	router.Middleware(traced("log", (*Context).Log))
+	router.Middleware(traced("metrics", (*Context).Metrics))
+	router.Middleware(traced("timeout", (*Context).Timeout))
+	router.Middleware(traced("authentication", (*Context).UserAuthentication))
+	router.Middleware((*Context).TraceHandler)
 	return router
 }
 
+// SetRequestTimeout sets how long a request can take before it is given up on
+func SetRequestTimeout(timeout time.Duration) {
+	requestTimeout = timeout
+}
+
+// AllowAnonymous lets anyone use the paths supplied without being authenticated, so things like
+// load balancers can check on the service. Should only be called while setting up the routes
+func AllowAnonymous(paths ...string) {
+	for _, path := range paths {
+		anonymousPaths[path] = true
+	}
+}
+
+// Trace starts the span for the request, carrying on the caller's trace if it sent one. It is
+// named after the route the request matched, so paths with ids in them are grouped together
+func (c *Context) Trace(rw web.ResponseWriter, r *web.Request, next web.NextMiddlewareFunc) {
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	ctx, span := tracing.Start(ctx, r.Method, trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.HTTPRequestMethodKey.String(r.Method), semconv.URLPath(r.URL.Path)))
+	r.Request = r.Request.WithContext(ctx)
+
+	defer func() {
+		err := recover()
+		status := responseStatus(rw, r, err)
+
+		if r.IsRouted() {
+			span.SetName(r.Method + " " + r.RoutePath())
+			span.SetAttributes(semconv.HTTPRoute(r.RoutePath()))
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if err != nil {
+			tracing.Fail(span, err)
+		} else if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		span.End()
+
+		// let Error send the response
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	next(rw, r)
+}
+
+// TraceHandler times the handler the request was routed to. It must be the last middleware
+func (c *Context) TraceHandler(rw web.ResponseWriter, r *web.Request, next web.NextMiddlewareFunc) {
+	ctx, span := tracing.Start(r.Context(), "handler")
+	r.Request = r.Request.WithContext(ctx)
+
+	defer func() {
+		if r.IsRouted() {
+			span.SetName("handler " + r.RoutePath())
+		}
+		if err := recover(); err != nil {
+			tracing.Fail(span, err)
+			span.End()
+			panic(err)
+		}
+		span.End()
+	}()
+
+	next(rw, r)
+}
+
+// traced gives a middleware a span of its own that ends when it calls next, so the time it
+// takes itself can be told apart from the time taken by everything after it
+func traced(name string, mw middleware) middleware {
+	return func(c *Context, rw web.ResponseWriter, r *web.Request, next web.NextMiddlewareFunc) {
+		parent := trace.SpanFromContext(r.Context())
+		ctx, span := tracing.Start(r.Context(), "middleware "+name)
+		r.Request = r.Request.WithContext(ctx)
+
+		ended := false
+		defer func() {
+			if ended {
+				return
+			}
+			// the middleware itself panicked, like authentication does for a missing cookie
+			if err := recover(); err != nil {
+				tracing.Fail(span, err)
+				span.End()
+				panic(err)
+			}
+			span.End()
+		}()
+
+		mw(c, rw, r, func(rw web.ResponseWriter, r *web.Request) {
+			ended = true
+			span.End()
+			// what comes next belongs to the request rather than this middleware, but keeps
+			// anything else the middleware added, like a deadline
+			r.Request = r.Request.WithContext(trace.ContextWithSpan(r.Context(), parent))
+			next(rw, r)
+		})
+	}
+}
+
+// Log gives the request an id, taken from its X-Request-ID header if it came with one, that is
+// sent back and added to everything logged for it. Once done the request itself is logged
+func (c *Context) Log(rw web.ResponseWriter, r *web.Request, next web.NextMiddlewareFunc) {
+	start := time.Now()
+	requestId := r.Header.Get(RequestIdHeader)
+	if !validRequestId(requestId) {
+		requestId = logging.NewRequestId()
+	}
+	rw.Header().Set(RequestIdHeader, requestId)
+	r.Request = r.Request.WithContext(logging.WithRequestId(r.Context(), requestId))
+
+	method, path := r.Method, r.URL.Path
+	defer func() {
+		err := recover()
+		status := responseStatus(rw, r, err)
+
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		attrs := []slog.Attr{
+			slog.String("method", method),
+			slog.String("path", path),
+			slog.Int("status", status),
+			slog.Int64("durationMs", time.Since(start).Milliseconds()),
+			slog.Int("userId", int(c.UserId)),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", fmt.Sprint(err)))
+		}
+		slog.LogAttrs(r.Context(), level, "request", attrs...)
+
+		// let Error send the response
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	next(rw, r)
+}
+
+// RequestIdHeader is the header a request's id is read from and sent back in
+const RequestIdHeader = "X-Request-ID"
+
+// validRequestId checks an id supplied by the client is safe to log and send back
+func validRequestId(requestId string) bool {
+	if requestId == "" || len(requestId) > 128 {
+		return false
+	}
+	for _, r := range requestId {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// Metrics counts and times each request by the route it matched, so paths with ids in them
+// are counted together. Requests that panic are counted with the status Error sends back
+func (c *Context) Metrics(rw web.ResponseWriter, r *web.Request, next web.NextMiddlewareFunc) {
+	start := time.Now()
+	method := r.Method
+	defer func() {
+		err := recover()
+		status := responseStatus(rw, r, err)
+
+		route := "unmatched"
+		if r.IsRouted() {
+			route = r.RoutePath()
+		}
+		labels := []string{route, method, strconv.Itoa(status)}
+		metrics.HTTPRequests.WithLabelValues(labels...).Inc()
+		metrics.HTTPDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+
+		// let Error send the response
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	next(rw, r)
+}
+
+// Timeout gives the request a deadline, after which anything it is waiting on in the database
+// is cancelled. Streams of events are left open for as long as the client wants them
+func (c *Context) Timeout(rw web.ResponseWriter, r *web.Request, next web.NextMiddlewareFunc) {
+	if requestTimeout <= 0 || r.Header.Get("Upgrade") != "" ||
+		strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		next(rw, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	r.Request = r.Request.WithContext(ctx)
+	next(rw, r)
+}
+
 // UserAuthentication is a (very) dummy function that would authenticate the user.
 // I use userid instead of token for the sake of ease for someone running the code
 func (c *Context) UserAuthentication(rw web.ResponseWriter, r *web.Request, next web.NextMiddlewareFunc) {
+	if anonymousPaths[r.URL.Path] {
+		next(rw, r)
+		return
+	}
+
 	cookie, err := r.Cookie("userId")
 	if err != nil {
 		panic("Error getting user id cookie")
@@ -46,8 +278,7 @@ func (c *Context) UserAuthentication(rw web.ResponseWriter, r *web.Request, next
 		c.UserId = int32(userId)
 		next(rw, r)
 	} else {
-		rw.WriteHeader(http.StatusUnauthorized)
-		fmt.Fprint(rw, `{"error": "You are not authorized to use this service"}`)
+		sendError(rw, http.StatusUnauthorized, "You are not authorized to use this service")
 	}
 }
 
@@ -55,8 +286,43 @@ func (c *Context) UserAuthentication(rw web.ResponseWriter, r *web.Request, next
 // and the error message returned. Probably want to send back a user friendly error message but i
 // just left it as the error as returned for this
 func (c *Context) Error(rw web.ResponseWriter, r *web.Request, err interface{}) {
-	rw.WriteHeader(http.StatusInternalServerError)
-	fmt.Fprint(rw, `{"error": "`+err.(string)+`"}`)
+	status, message := errorStatus(r, err)
+	sendError(rw, status, message)
+}
+
+// sendError sends back an error as JSON. The message is encoded rather than pasted in, as
+// errors often quote what they could not parse
+func sendError(rw web.ResponseWriter, status int, message string) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	json.NewEncoder(rw).Encode(map[string]string{"error": message})
+}
+
+// responseStatus works out the status sent back for a request, given the value it panicked with if it did
+func responseStatus(rw web.ResponseWriter, r *web.Request, err interface{}) int {
+	if err != nil {
+		status, _ := errorStatus(r, err)
+		return status
+	}
+	// net/http sends a 200 for a handler that writes nothing
+	if rw.Written() {
+		return rw.StatusCode()
+	}
+	return http.StatusOK
+}
+
+// errorStatus works out the status and message to send back for a panic
+func errorStatus(r *web.Request, err interface{}) (int, string) {
+	message := fmt.Sprint(err)
+	switch {
+	case errors.Is(r.Context().Err(), context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, "The request took too long to complete"
+	case strings.Contains(message, context.DeadlineExceeded.Error()):
+		// the request still had time left, so it was the database that was too slow
+		return http.StatusServiceUnavailable, "The database took too long to respond"
+	default:
+		return http.StatusInternalServerError, message
+	}
 }
 
 func authenticateUser(userId int64) bool {
@@ -0,0 +1,205 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"avaros/logging"
+	"avaros/metrics"
+
+	"github.com/gocraft/web"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	SetRequestTimeout(10 * time.Millisecond)
+	defer SetRequestTimeout(0)
+
+	router := NewRouter()
+	router.Get("/slow", func(rw web.ResponseWriter, req *web.Request) {
+		<-req.Context().Done()
+		panic("Error getting reservations: " + req.Context().Err().Error())
+	})
+
+	rr := serve(router, "/slow", nil)
+	if rr.Code != http.StatusGatewayTimeout {
+		t.Errorf("Request that ran out of time should return %d, got %d", http.StatusGatewayTimeout, rr.Code)
+	}
+
+	// streams are not timed out
+	router.Get("/stream", func(rw web.ResponseWriter, req *web.Request) {
+		if _, ok := req.Context().Deadline(); ok {
+			panic("Stream should not have a deadline")
+		}
+	})
+
+	rr = serve(router, "/stream", http.Header{"Accept": []string{"text/event-stream"}})
+	if rr.Code != http.StatusOK {
+		t.Errorf("Stream should not be timed out: %s", rr.Body.String())
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	router := NewRouter()
+	router.Get("/query", func(rw web.ResponseWriter, req *web.Request) {
+		panic("Error getting reservations: timeout: " + context.DeadlineExceeded.Error())
+	})
+
+	rr := serve(router, "/query", nil)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Query that ran out of time should return %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	router := NewRouter()
+	router.Get("/rooms/:id", func(rw web.ResponseWriter, req *web.Request) {
+		if req.PathParams["id"] == "2" {
+			panic("Room with id 2 does not exist")
+		}
+	})
+
+	ok := metrics.HTTPRequests.WithLabelValues("/rooms/:id", "GET", "200")
+	failed := metrics.HTTPRequests.WithLabelValues("/rooms/:id", "GET", "500")
+	unmatched := metrics.HTTPRequests.WithLabelValues("unmatched", "GET", "404")
+	before := []float64{testutil.ToFloat64(ok), testutil.ToFloat64(failed), testutil.ToFloat64(unmatched)}
+
+	serve(router, "/rooms/1", nil)
+	serve(router, "/rooms/3", nil)
+	rr := serve(router, "/rooms/2", nil)
+	serve(router, "/nowhere", nil)
+
+	// a panic is counted with the status sent back and still gets its error response
+	if rr.Code != http.StatusInternalServerError || !strings.Contains(rr.Body.String(), "does not exist") {
+		t.Errorf("Panicking request should still get an error, got %d %s", rr.Code, rr.Body.String())
+	}
+
+	// requests are counted by the route they matched rather than their path
+	if count := testutil.ToFloat64(ok) - before[0]; count != 2 {
+		t.Errorf("2 requests to the room route should have succeeded, got %v", count)
+	}
+	if count := testutil.ToFloat64(failed) - before[1]; count != 1 {
+		t.Errorf("1 request to the room route should have failed, got %v", count)
+	}
+	if count := testutil.ToFloat64(unmatched) - before[2]; count != 1 {
+		t.Errorf("1 request should not have matched a route, got %v", count)
+	}
+}
+
+func TestRequestLogged(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	var buf bytes.Buffer
+	logging.Setup(&buf, "info", "json")
+
+	var handlerId string
+	router := NewRouter()
+	router.Get("/rooms/:id", func(rw web.ResponseWriter, req *web.Request) {
+		handlerId = logging.RequestId(req.Context())
+	})
+
+	// an id sent by the client is kept
+	rr := serve(router, "/rooms/1", http.Header{RequestIdHeader: []string{"from-client"}})
+	if rr.Header().Get(RequestIdHeader) != "from-client" || handlerId != "from-client" {
+		t.Errorf("Request id from the client should be used, got %s sent back and %s in the handler",
+			rr.Header().Get(RequestIdHeader), handlerId)
+	}
+
+	var record map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &record)
+	if err != nil {
+		t.Fatalf("Error reading log line %s: %s", buf.String(), err.Error())
+	}
+	for key, value := range map[string]interface{}{
+		"msg": "request", "method": "GET", "path": "/rooms/1", "status": 200.0, "userId": 1.0, "requestId": "from-client",
+	} {
+		if record[key] != value {
+			t.Errorf("Request log should have %s %v, got %v", key, value, record[key])
+		}
+	}
+	if _, ok := record["durationMs"]; !ok {
+		t.Error("Request log should have the duration")
+	}
+
+	// otherwise one is made up, as it is for an id that is not safe to log
+	rr = serve(router, "/rooms/1", http.Header{RequestIdHeader: []string{"bad\nid"}})
+	requestId := rr.Header().Get(RequestIdHeader)
+	if requestId == "" || requestId == "bad\nid" || requestId != handlerId {
+		t.Errorf("Request id should be generated, got %q sent back and %q in the handler", requestId, handlerId)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	router := NewRouter()
+	router.Get("/rooms/:id", func(rw web.ResponseWriter, req *web.Request) {
+		if req.PathParams["id"] == "2" {
+			panic("Room with id 2 does not exist")
+		}
+	})
+
+	serve(router, "/rooms/1", nil)
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	request, ok := spans["GET /rooms/:id"]
+	if !ok {
+		t.Fatalf("Request span should be named after its route, got %v", spans)
+	}
+	// each middleware and the handler is a child of the request, rather than of the one before
+	for _, name := range []string{"middleware log", "middleware metrics", "middleware timeout",
+		"middleware authentication", "handler /rooms/:id"} {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("Expected a %s span", name)
+			continue
+		}
+		if span.Parent().SpanID() != request.SpanContext().SpanID() {
+			t.Errorf("%s span should be a child of the request", name)
+		}
+	}
+
+	// a panic fails the request and the handler it came from
+	before := len(recorder.Ended())
+	serve(router, "/rooms/2", nil)
+	for _, span := range recorder.Ended()[before:] {
+		if span.Name() == "GET /rooms/:id" || span.Name() == "handler /rooms/:id" {
+			if span.Status().Description != "Room with id 2 does not exist" {
+				t.Errorf("%s span should have failed, got %v", span.Name(), span.Status())
+			}
+		} else if span.Status().Description != "" {
+			t.Errorf("%s span should not have failed, got %v", span.Name(), span.Status())
+		}
+	}
+}
+
+// serve sends a request from the only user that can use the service
+func serve(router *web.Router, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	for key, values := range header {
+		req.Header[http.CanonicalHeaderKey(key)] = values
+	}
+	req.AddCookie(&http.Cookie{
+		Name:  "userId",
+		Value: "1",
+	})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
@@ -0,0 +1,172 @@
+/*
+	Runs background jobs at a given time or on an interval.
+*/
+
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"avaros/logging"
+	"avaros/metrics"
+	"avaros/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// job is a function to run at a given time. Jobs with an interval are put
+// back on the queue after they run. Each run is given a context with its own id to log with
+// and its own trace
+type job struct {
+	name     string
+	runAt    time.Time
+	interval time.Duration
+	fn       func(ctx context.Context)
+}
+
+// jobQueue orders jobs by when they are due to run, soonest first
+type jobQueue []*job
+
+func (q jobQueue) Len() int            { return len(q) }
+func (q jobQueue) Less(i, j int) bool  { return q[i].runAt.Before(q[j].runAt) }
+func (q jobQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *jobQueue) Push(x interface{}) { *q = append(*q, x.(*job)) }
+func (q *jobQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
+
+// Scheduler runs jobs when they are due. Each job runs in its own thread so
+// a slow job does not hold up the rest
+type Scheduler struct {
+	mu      sync.Mutex
+	queue   jobQueue
+	wake    chan struct{}
+	running sync.WaitGroup
+	started bool
+}
+
+// New creates a scheduler. Jobs can be added before or after Run is called
+func New() *Scheduler {
+	return &Scheduler{
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// At runs a job once at the time supplied, or as soon as possible if that time has passed
+func (s *Scheduler) At(runAt time.Time, name string, fn func(ctx context.Context)) {
+	s.add(&job{name: name, runAt: runAt, fn: fn})
+}
+
+// Every runs a job on the interval supplied, starting one interval from now
+func (s *Scheduler) Every(interval time.Duration, name string, fn func(ctx context.Context)) {
+	s.add(&job{name: name, runAt: time.Now().Add(interval), interval: interval, fn: fn})
+}
+
+func (s *Scheduler) add(j *job) {
+	s.mu.Lock()
+	heap.Push(&s.queue, j)
+	s.mu.Unlock()
+
+	// let Run know in case this job is due before the one it is waiting on
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run runs jobs as they become due until the context is cancelled. Should only be opened in a thread
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	s.started = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.started = false
+		s.mu.Unlock()
+	}()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		now := time.Now()
+		for len(s.queue) > 0 && !s.queue[0].runAt.After(now) {
+			j := heap.Pop(&s.queue).(*job)
+			metrics.SchedulerLag.WithLabelValues(j.name).Observe(now.Sub(j.runAt).Seconds())
+			s.start(j)
+			if j.interval > 0 {
+				heap.Push(&s.queue, &job{name: j.name, runAt: now.Add(j.interval), interval: j.interval, fn: j.fn})
+			}
+		}
+
+		// sleep until the next job is due, or for a while if there is nothing to run
+		wait := time.Hour
+		if len(s.queue) > 0 {
+			wait = time.Until(s.queue[0].runAt)
+		}
+		s.mu.Unlock()
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// Queued is how many jobs are waiting to run
+func (s *Scheduler) Queued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+// Running checks if Run is running jobs
+func (s *Scheduler) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
+
+// start runs a job in a thread. A panicking job is logged rather than taking the service down
+func (s *Scheduler) start(j *job) {
+	s.running.Add(1)
+	ctx := logging.WithRequestId(context.Background(), logging.NewRequestId())
+	go func() {
+		defer s.running.Done()
+		ctx, span := tracing.Start(ctx, "job "+j.name, trace.WithAttributes(attribute.String("job", j.name)))
+		defer span.End()
+		defer func() {
+			if err := recover(); err != nil {
+				tracing.Fail(span, err)
+				slog.ErrorContext(ctx, "Error running job", "job", j.name, "error", err)
+			}
+		}()
+		j.fn(ctx)
+	}()
+}
+
+// Wait waits for any jobs that are running to finish
+func (s *Scheduler) Wait() {
+	s.running.Wait()
+}
@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"avaros/logging"
+)
+
+func TestAt(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	ran := make(chan time.Time, 1)
+	runAt := time.Now().Add(100 * time.Millisecond)
+	s.At(runAt, "test", func(context.Context) {
+		ran <- time.Now()
+	})
+
+	select {
+	case at := <-ran:
+		if at.Before(runAt) {
+			t.Errorf("Job ran before it was due")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Job should have run")
+	}
+}
+
+func TestEvery(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.Run(ctx)
+
+	var runs int32
+	ids := make(chan string, 100)
+	s.Every(20*time.Millisecond, "test", func(ctx context.Context) {
+		atomic.AddInt32(&runs, 1)
+		ids <- logging.RequestId(ctx)
+	})
+
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+	s.Wait()
+	close(ids)
+
+	if atomic.LoadInt32(&runs) < 2 {
+		t.Errorf("Job should have run more than once, ran %d times", runs)
+	}
+
+	// each run is logged with its own id
+	seen := map[string]bool{}
+	for id := range ids {
+		if id == "" || seen[id] {
+			t.Errorf("Each run should have its own id, got %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestPanickingJob(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	ran := make(chan struct{})
+	s.At(time.Now(), "panics", func(context.Context) {
+		panic("job failed")
+	})
+	s.At(time.Now().Add(50*time.Millisecond), "after", func(context.Context) {
+		close(ran)
+	})
+
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Jobs should keep running after one panics")
+	}
+}
+
+func TestRunning(t *testing.T) {
+	s := New()
+	if s.Running() {
+		t.Fatalf("Scheduler should not be running before Run is called")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(stopped)
+	}()
+
+	for deadline := time.Now().Add(2 * time.Second); !s.Running(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Scheduler should be running")
+		}
+	}
+
+	cancel()
+	<-stopped
+	if s.Running() {
+		t.Errorf("Scheduler should not be running once its context is cancelled")
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// shutdownStep stops something that was started along with the server
+type shutdownStep struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// serve serves requests until the process is interrupted or terminated. It then stops taking
+// new requests, lets the ones in flight finish and runs each step in order, giving up on
+// whatever is left once the timeout supplied has passed
+func serve(server *http.Server, timeout time.Duration, steps []shutdownStep) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	failed := make(chan error, 1)
+	go func() {
+		failed <- server.ListenAndServe()
+	}()
+
+	exitCode := 0
+	select {
+	case sig := <-signals:
+		slog.Info("Shutting down", "signal", sig.String())
+	case err := <-failed:
+		slog.Error("Error serving requests", "error", err)
+		exitCode = 1
+	}
+	// a second signal kills the process straight away
+	signal.Stop(signals)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+
+	err := server.Shutdown(ctx)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("Error waiting on requests to finish", "error", err)
+		exitCode = 1
+	}
+
+	for _, step := range steps {
+		err := step.stop(ctx)
+		if err != nil {
+			slog.Error("Error stopping "+step.name, "error", err)
+			exitCode = 1
+		}
+	}
+
+	cancel()
+	slog.Info("server stopped")
+	os.Exit(exitCode)
+}
+
+// waitFor runs fn, which waits on something, until it returns or the context is done
+func waitFor(ctx context.Context, fn func()) error {
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
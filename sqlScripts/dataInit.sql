@@ -1,3 +1,10 @@
-INSERT INTO room (name) VALUES ('Meeting Room');
-INSERT INTO room (name) VALUES ('Conference Room');
-INSERT INTO room (name) VALUES ('Lunch Room');
\ No newline at end of file
+INSERT INTO users (name, email, team, role) VALUES ('Conor Downey', 'conor@avaros.local', 'Engineering', 'employee');
+INSERT INTO users (name, email, team, role) VALUES ('Jane Doe', 'jane@avaros.local', 'Executive', 'manager');
+INSERT INTO users (name, email, team, role) VALUES ('John Smith', 'john@avaros.local', 'Engineering', 'employee');
+
+INSERT INTO role_priority (role, max_priority) VALUES ('manager', 2);
+
+INSERT INTO room (name, building, site, floor, amenities, capacity) VALUES ('Meeting Room', 'Head Office', 'Dublin', 1, '{screen}', 8);
+INSERT INTO room (name, building, site, floor, amenities, capacity) VALUES ('Conference Room', 'Head Office', 'Dublin', 1, '{screen,video}', 20);
+INSERT INTO room (name, building, site, floor, buffer_after) VALUES ('Lunch Room', 'Head Office', 'Dublin', 0, 15);
+INSERT INTO room (name, building, site, floor, amenities, requires_approval, approver_id, capacity) VALUES ('Boardroom', 'Head Office', 'Dublin', 2, '{screen,video}', true, 2, 12);
\ No newline at end of file
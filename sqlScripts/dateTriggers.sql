@@ -28,6 +28,18 @@ BEFORE UPDATE ON room
 FOR EACH ROW
 EXECUTE PROCEDURE trigger_set_last_modified();
 
+-- users
+-- ===========================================
+CREATE TRIGGER users_insert
+BEFORE INSERT ON users
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_created();
+
+CREATE TRIGGER users_update
+BEFORE UPDATE ON users
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_last_modified();
+
 -- reservation
 -- ===========================================
 CREATE TRIGGER reservation_insert
@@ -39,3 +51,16 @@ CREATE TRIGGER reservation_update
 BEFORE UPDATE ON reservation
 FOR EACH ROW
 EXECUTE PROCEDURE trigger_set_last_modified();
+
+
+-- waitlist
+-- ===========================================
+CREATE TRIGGER waitlist_insert
+BEFORE INSERT ON waitlist
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_created();
+
+CREATE TRIGGER waitlist_update
+BEFORE UPDATE ON waitlist
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_last_modified();
\ No newline at end of file
@@ -1,3 +1,51 @@
+-- users
+----------------------------------------------------
+DROP TABLE if exists users cascade;
+CREATE TABLE users
+(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(80),
+    email VARCHAR(254),
+    team VARCHAR(80),
+    role VARCHAR(40),
+    last_modified TIMESTAMP,
+    created TIMESTAMP
+)
+
+TABLESPACE pg_default;
+
+-- quota
+----------------------------------------------------
+DROP TABLE if exists quota cascade;
+CREATE TABLE quota
+(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER,
+    team VARCHAR(80),
+    role VARCHAR(40),
+    max_future_reservations INTEGER,
+    max_hours_per_week DOUBLE PRECISION,
+    max_per_room_per_day INTEGER,
+    CHECK (num_nonnulls(user_id, team, role) <= 1),
+    CONSTRAINT user_id FOREIGN KEY (user_id)
+        REFERENCES public.users (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+)
+
+TABLESPACE pg_default;
+
+-- role_priority
+----------------------------------------------------
+DROP TABLE if exists role_priority cascade;
+CREATE TABLE role_priority
+(
+    role VARCHAR(40) PRIMARY KEY,
+    max_priority INTEGER NOT NULL DEFAULT 0
+)
+
+TABLESPACE pg_default;
+
 -- room
 ----------------------------------------------------
 DROP TABLE if exists room cascade;
@@ -5,8 +53,61 @@ CREATE TABLE room
 (
     id SERIAL PRIMARY KEY,
     name VARCHAR(80),
+    building VARCHAR(80),
+    site VARCHAR(80),
+    floor INTEGER,
+    amenities TEXT[] NOT NULL DEFAULT '{}',
+    requires_approval BOOLEAN DEFAULT false,
+    approver_id INTEGER,
+    capacity INTEGER,
+    buffer_before INTEGER NOT NULL DEFAULT 0,
+    buffer_after INTEGER NOT NULL DEFAULT 0,
     last_modified TIMESTAMP,
-    created TIMESTAMP
+    created TIMESTAMP,
+    CONSTRAINT approver_id FOREIGN KEY (approver_id)
+        REFERENCES public.users (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+        NOT VALID,
+    -- reservations of a room that needs approval have to have someone to approve them
+    CONSTRAINT approver_required CHECK (NOT requires_approval OR approver_id IS NOT NULL)
+)
+
+TABLESPACE pg_default;
+
+-- notification_opt_out
+----------------------------------------------------
+DROP TABLE if exists notification_opt_out cascade;
+CREATE TABLE notification_opt_out
+(
+    user_id INTEGER NOT NULL,
+    notification VARCHAR(20) NOT NULL,
+    PRIMARY KEY (user_id, notification),
+    CONSTRAINT user_id FOREIGN KEY (user_id)
+        REFERENCES public.users (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+)
+
+TABLESPACE pg_default;
+
+-- delegation
+----------------------------------------------------
+DROP TABLE if exists delegation cascade;
+CREATE TABLE delegation
+(
+    user_id INTEGER NOT NULL,
+    delegate_id INTEGER NOT NULL,
+    created TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, delegate_id),
+    CONSTRAINT user_id FOREIGN KEY (user_id)
+        REFERENCES public.users (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT delegate_id FOREIGN KEY (delegate_id)
+        REFERENCES public.users (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
 )
 
 TABLESPACE pg_default;
@@ -18,16 +119,209 @@ CREATE TABLE reservation
 (
     id SERIAL PRIMARY KEY,
     room_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    created_by INTEGER NOT NULL,
     start_time TIMESTAMP,
     end_time TIMESTAMP,
     expired BOOLEAN DEFAULT false,
+    status VARCHAR(10) NOT NULL DEFAULT 'confirmed',
+    hold_expires TIMESTAMP,
+    approver_id INTEGER,
+    approval_expires TIMESTAMP,
+    decision_reason TEXT,
+    title VARCHAR(200),
+    description TEXT,
+    private BOOLEAN NOT NULL DEFAULT false,
+    priority INTEGER NOT NULL DEFAULT 0,
+    checked_in TIMESTAMP,
     last_modified TIMESTAMP,
     created TIMESTAMP,
     CONSTRAINT room_id FOREIGN KEY (room_id)
         REFERENCES public.room (id) MATCH SIMPLE
         ON UPDATE NO ACTION
         ON DELETE NO ACTION
+        NOT VALID,
+    CONSTRAINT user_id FOREIGN KEY (user_id)
+        REFERENCES public.users (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+        NOT VALID,
+    CONSTRAINT created_by FOREIGN KEY (created_by)
+        REFERENCES public.users (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
         NOT VALID
 )
 
-TABLESPACE pg_default;
\ No newline at end of file
+TABLESPACE pg_default;
+
+-- attendee
+----------------------------------------------------
+DROP TABLE if exists attendee cascade;
+CREATE TABLE attendee
+(
+    id SERIAL PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    user_id INTEGER,
+    email VARCHAR(254),
+    rsvp VARCHAR(10) NOT NULL DEFAULT 'pending',
+    CHECK (user_id IS NOT NULL OR email IS NOT NULL),
+    CONSTRAINT reservation_id FOREIGN KEY (reservation_id)
+        REFERENCES public.reservation (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT user_id FOREIGN KEY (user_id)
+        REFERENCES public.users (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+)
+
+TABLESPACE pg_default;
+
+CREATE UNIQUE INDEX attendee_user ON attendee (reservation_id, user_id);
+CREATE UNIQUE INDEX attendee_email ON attendee (reservation_id, email);
+
+-- bump
+----------------------------------------------------
+DROP TABLE if exists bump cascade;
+CREATE TABLE bump
+(
+    id SERIAL PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    by_reservation_id INTEGER NOT NULL,
+    reason TEXT,
+    created TIMESTAMP DEFAULT NOW(),
+    CONSTRAINT reservation_id FOREIGN KEY (reservation_id)
+        REFERENCES public.reservation (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT by_reservation_id FOREIGN KEY (by_reservation_id)
+        REFERENCES public.reservation (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+)
+
+TABLESPACE pg_default;
+
+-- reminder
+----------------------------------------------------
+DROP TABLE if exists reminder cascade;
+CREATE TABLE reminder
+(
+    reservation_id INTEGER PRIMARY KEY,
+    remind_at TIMESTAMP NOT NULL,
+    CONSTRAINT reservation_id FOREIGN KEY (reservation_id)
+        REFERENCES public.reservation (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX reminder_remind_at ON reminder (remind_at);
+
+-- business_hours
+----------------------------------------------------
+DROP TABLE if exists business_hours cascade;
+CREATE TABLE business_hours
+(
+    id SERIAL PRIMARY KEY,
+    site VARCHAR(80) NOT NULL,
+    weekday INTEGER NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    opens TIME NOT NULL,
+    closes TIME NOT NULL,
+    CHECK (closes > opens)
+)
+
+TABLESPACE pg_default;
+
+-- holiday
+----------------------------------------------------
+DROP TABLE if exists holiday cascade;
+CREATE TABLE holiday
+(
+    id SERIAL PRIMARY KEY,
+    site VARCHAR(80) NOT NULL,
+    date DATE NOT NULL,
+    name VARCHAR(200),
+    UNIQUE (site, date)
+)
+
+TABLESPACE pg_default;
+
+-- closure
+----------------------------------------------------
+DROP TABLE if exists closure cascade;
+CREATE TABLE closure
+(
+    id SERIAL PRIMARY KEY,
+    room_id INTEGER,
+    building VARCHAR(80),
+    site VARCHAR(80),
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    recurrence VARCHAR(10) NOT NULL DEFAULT 'none',
+    repeat_until TIMESTAMP,
+    reason TEXT,
+    created_by INTEGER NOT NULL,
+    created TIMESTAMP DEFAULT NOW(),
+    CHECK (num_nonnulls(room_id, building, site) = 1),
+    CHECK (end_time > start_time),
+    CONSTRAINT room_id FOREIGN KEY (room_id)
+        REFERENCES public.room (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT created_by FOREIGN KEY (created_by)
+        REFERENCES public.users (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+)
+
+TABLESPACE pg_default;
+
+-- waitlist
+----------------------------------------------------
+DROP TABLE if exists waitlist cascade;
+CREATE TABLE waitlist
+(
+    id SERIAL PRIMARY KEY,
+    room_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP,
+    auto_accept BOOLEAN DEFAULT false,
+    status VARCHAR(10) NOT NULL DEFAULT 'waiting',
+    offer_expires TIMESTAMP,
+    reservation_id INTEGER,
+    last_modified TIMESTAMP,
+    created TIMESTAMP,
+    CONSTRAINT room_id FOREIGN KEY (room_id)
+        REFERENCES public.room (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+        NOT VALID,
+    CONSTRAINT user_id FOREIGN KEY (user_id)
+        REFERENCES public.users (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+        NOT VALID
+)
+
+TABLESPACE pg_default;
+
+-- reservation_event
+----------------------------------------------------
+DROP TABLE if exists reservation_event cascade;
+CREATE TABLE reservation_event
+(
+    id BIGSERIAL PRIMARY KEY,
+    room_id INTEGER NOT NULL,
+    reservation_id INTEGER NOT NULL,
+    operation VARCHAR(10) NOT NULL,
+    reserved BOOLEAN NOT NULL,
+    created TIMESTAMPTZ DEFAULT NOW()
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX reservation_event_room_id ON reservation_event (room_id, id);
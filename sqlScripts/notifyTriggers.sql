@@ -0,0 +1,46 @@
+-- Records every change to a reservation and notifies listeners on the
+-- reservation_events channel so each instance can push live updates
+CREATE OR REPLACE FUNCTION trigger_notify_reservation()
+RETURNS TRIGGER AS $$
+DECLARE
+	rec reservation;
+	event reservation_event;
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		rec = OLD;
+	ELSE
+		rec = NEW;
+	END IF;
+
+	INSERT INTO reservation_event (room_id, reservation_id, operation, reserved)
+	VALUES (
+		rec.room_id,
+		rec.id,
+		TG_OP,
+		EXISTS(
+			SELECT 1 FROM reservation
+			WHERE room_id = rec.room_id AND expired = false
+			AND start_time <= NOW() AND (end_time IS NULL OR end_time > NOW())
+			AND (status != 'hold' OR hold_expires > NOW())
+		)
+	)
+	RETURNING * INTO event;
+
+	PERFORM pg_notify('reservation_events', json_build_object(
+		'id', event.id,
+		'roomId', event.room_id,
+		'reservationId', event.reservation_id,
+		'operation', event.operation,
+		'reserved', event.reserved,
+		'created', event.created
+	)::text);
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- reservation
+-- ===========================================
+CREATE TRIGGER reservation_notify
+AFTER INSERT OR UPDATE OR DELETE ON reservation
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_notify_reservation();
@@ -2,37 +2,24 @@ package test
 
 import (
 	"context"
-	"fmt"
-	"os"
+
+	"avaros/config"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
+// NewDatabase connects to the test database. It is configured like the server's but from
+// TEST_DB_USER, TEST_DB_PASSWORD and so on, so it is never the live database
 func NewDatabase() *pgxpool.Pool {
+	cfg := config.Default().Database
+	cfg.Name = "postgres_test"
 
-	user := os.Getenv("TEST_DB_USER")
-	if user == "" {
-		user = "postgres"
-	}
-
-	name := os.Getenv("TEST_DB_NAME")
-	if name == "" {
-		name = "postgres_test"
-	}
-
-	password := os.Getenv("TEST_DB_NAME")
-	if password == "" {
-		password = "password"
-	}
-
-	host := os.Getenv("TEST_DB_HOST")
-	if host == "" {
-		host = "localhost"
+	err := config.ApplyEnv(&cfg, "TEST_")
+	if err != nil {
+		panic("Error loading test database config: " + err.Error())
 	}
 
-	conn, err := pgxpool.Connect(context.Background(),
-		fmt.Sprintf("user=%s dbname=%s password=%s host=%s sslmode=disable",
-			user, name, password, host))
+	conn, err := pgxpool.Connect(context.Background(), cfg.ConnString())
 	if err != nil {
 		panic("Unable to connect to database: " + err.Error())
 	}
@@ -50,6 +37,11 @@ func CloseDb(db *pgxpool.Pool) {
 	}
 
 	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists attendee cascade;
+		DROP TABLE if exists bump cascade;
+		DROP TABLE if exists closure cascade;
+		DROP TABLE if exists business_hours cascade;
+		DROP TABLE if exists holiday cascade;
 		DROP TABLE if exists reservation cascade;
 	`)
 
@@ -57,5 +49,33 @@ func CloseDb(db *pgxpool.Pool) {
 		panic("Error dropping reservation table: " + err.Error())
 	}
 
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists waitlist cascade;
+	`)
+
+	if err != nil {
+		panic("Error dropping waitlist table: " + err.Error())
+	}
+
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists reservation_event cascade;
+	`)
+
+	if err != nil {
+		panic("Error dropping reservation_event table: " + err.Error())
+	}
+
+	_, err = db.Exec(context.Background(), `
+		DROP TABLE if exists notification_opt_out cascade;
+		DROP TABLE if exists delegation cascade;
+		DROP TABLE if exists quota cascade;
+		DROP TABLE if exists role_priority cascade;
+		DROP TABLE if exists users cascade;
+	`)
+
+	if err != nil {
+		panic("Error dropping user tables: " + err.Error())
+	}
+
 	db.Close()
 }
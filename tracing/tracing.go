@@ -0,0 +1,148 @@
+/*
+	OpenTelemetry tracing for requests, database queries and background jobs. Spans are
+	exported to an OTLP collector, printed for local testing or dropped.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"avaros/config"
+
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const serviceName = "avaros"
+
+// the package whose functions the queries are made from
+const dataAccessPackage = "avaros/dataAccess."
+
+// Setup starts exporting spans as the config says. Returns a function that sends any spans
+// still waiting to be exported, which should be called once nothing else will be traced
+func Setup(ctx context.Context, cfg config.Tracing) (func(context.Context) error, error) {
+	// a trace started by whoever called us is carried on
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	default:
+		return nil, fmt.Errorf("Unknown trace exporter %s, it must be none, stdout or otlp", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start starts a span that is a child of any in the context
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(serviceName).Start(ctx, name, opts...)
+}
+
+// Fail marks a span as failed with the error, or the value of a panic, supplied
+func Fail(span trace.Span, err interface{}) {
+	message := fmt.Sprint(err)
+	if e, ok := err.(error); ok {
+		span.RecordError(e)
+	} else {
+		span.AddEvent("panic", trace.WithAttributes(attribute.String("message", message)))
+	}
+	span.SetStatus(codes.Error, message)
+}
+
+// Detach starts a span for work that carries on after the request that asked for it, like
+// expiring a reservation. It is the root of its own trace, linked to the request's, so the
+// request's trace does not last as long as the work
+func Detach(ctx context.Context, name string) (context.Context, trace.Span) {
+	return Start(ctx, name, trace.WithNewRoot(), trace.WithLinks(trace.LinkFromContext(ctx)))
+}
+
+// QueryLogger records a span for every query pgx runs on a connection it is the logger of.
+// pgx v4 only lets us know about a query once it has finished, so the span is recorded then,
+// starting as long ago as the query took. Failed queries do not say how long they took, so
+// they are recorded as taking no time
+type QueryLogger struct{}
+
+func (QueryLogger) Log(ctx context.Context, level pgx.LogLevel, msg string, data map[string]interface{}) {
+	sql, ok := data["sql"].(string)
+	if !ok {
+		// connecting and the like
+		return
+	}
+
+	end := time.Now()
+	start := end
+	if took, ok := data["time"].(time.Duration); ok {
+		start = end.Add(-took)
+	}
+
+	operation := strings.ToUpper(strings.SplitN(strings.TrimSpace(sql), " ", 2)[0])
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemPostgreSQL,
+		semconv.DBOperationName(operation),
+		semconv.DBQueryText(sql),
+	}
+	if caller := queryCaller(); caller != "" {
+		attrs = append(attrs, semconv.CodeFunction(caller))
+	}
+	if rows, ok := data["rowCount"].(int); ok {
+		attrs = append(attrs, attribute.Int("db.rows", rows))
+	}
+
+	_, span := Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+	if err, ok := data["err"].(error); ok {
+		Fail(span, err)
+	}
+	span.End(trace.WithTimestamp(end))
+}
+
+// queryCaller finds the data access function, like CheckRoomExists, that was called to make the
+// query. It is the outermost one, as they call each other
+func queryCaller() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+
+	caller := ""
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, dataAccessPackage) {
+			caller = strings.TrimPrefix(frame.Function, dataAccessPackage)
+		}
+		if !more {
+			return caller
+		}
+	}
+}
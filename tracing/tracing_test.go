@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"avaros/config"
+
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func TestQueryLogger(t *testing.T) {
+	recorder := record(t)
+
+	ctx, parent := Start(context.Background(), "request")
+	QueryLogger{}.Log(ctx, pgx.LogLevelInfo, "Query", map[string]interface{}{
+		"sql": "\n\t\tselect id from room", "time": 50 * time.Millisecond, "rowCount": 3,
+	})
+	QueryLogger{}.Log(ctx, pgx.LogLevelError, "Exec", map[string]interface{}{
+		"sql": "DELETE FROM room", "err": errors.New("relation does not exist"),
+	})
+	// only queries are traced
+	QueryLogger{}.Log(ctx, pgx.LogLevelInfo, "Dialing PostgreSQL server", map[string]interface{}{"host": "localhost"})
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 2 query spans and the request's, got %d", len(spans))
+	}
+
+	query := spans[0]
+	if query.Name() != "SELECT" || query.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("Query should be a SELECT span of the request, got %s", query.Name())
+	}
+	if took := query.EndTime().Sub(query.StartTime()); took != 50*time.Millisecond {
+		t.Errorf("Query span should last as long as the query took, got %s", took)
+	}
+	if !hasAttribute(query, string(semconv.DBQueryTextKey)) {
+		t.Error("Query span should have the query")
+	}
+
+	failed := spans[1]
+	if failed.Name() != "DELETE" || failed.Status().Code != codes.Error {
+		t.Errorf("Failed query should be marked as failed, got %s %v", failed.Name(), failed.Status())
+	}
+}
+
+func TestDetach(t *testing.T) {
+	recorder := record(t)
+
+	ctx, request := Start(context.Background(), "request")
+	_, job := Detach(ctx, "expire reservation")
+	job.End()
+	request.End()
+
+	spans := recorder.Ended()
+	if spans[0].Parent().IsValid() {
+		t.Error("Detached span should start a trace of its own")
+	}
+	links := spans[0].Links()
+	if len(links) != 1 || links[0].SpanContext.SpanID() != request.SpanContext().SpanID() {
+		t.Error("Detached span should be linked to the request")
+	}
+}
+
+func TestSetupNone(t *testing.T) {
+	stop, err := Setup(context.Background(), config.Tracing{Exporter: "none"})
+	if err != nil {
+		t.Fatalf("Error setting up tracing: %s", err.Error())
+	}
+	if err := stop(context.Background()); err != nil {
+		t.Errorf("Error stopping tracing: %s", err.Error())
+	}
+
+	_, err = Setup(context.Background(), config.Tracing{Exporter: "jaeger"})
+	if err == nil {
+		t.Error("Unknown exporter should not be accepted")
+	}
+}
+
+// record makes every span started in the test recorded
+func record(t *testing.T) *tracetest.SpanRecorder {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	return recorder
+}
+
+func hasAttribute(span sdktrace.ReadOnlySpan, key string) bool {
+	for _, attr := range span.Attributes() {
+		if string(attr.Key) == key {
+			return true
+		}
+	}
+	return false
+}